@@ -0,0 +1,140 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package access
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// A NonceStore keeps track of the account proof nonces issued by an app, so each nonce is only accepted once.
+type NonceStore interface {
+	// Consume marks the nonce as used. It returns an error wrapping flow.ErrInvalidNonce if the nonce was not issued,
+	// has expired, or was already used.
+	Consume(ctx context.Context, nonce string) error
+}
+
+// MemoryNonceStore is a NonceStore issuing random nonces, which expire after a time to live.
+//
+// Nonces are only kept in memory, so it is only suitable for apps running a single instance.
+type MemoryNonceStore struct {
+	mu     sync.Mutex
+	ttl    time.Duration
+	nonces map[string]time.Time
+}
+
+var _ NonceStore = (*MemoryNonceStore)(nil)
+
+// NewMemoryNonceStore returns a nonce store issuing nonces which expire after the time to live.
+func NewMemoryNonceStore(ttl time.Duration) *MemoryNonceStore {
+	return &MemoryNonceStore{
+		ttl:    ttl,
+		nonces: make(map[string]time.Time),
+	}
+}
+
+// Issue returns a new hex encoded random nonce of flow.AccountProofNonceMinLenBytes bytes.
+func (s *MemoryNonceStore) Issue(_ context.Context) (string, error) {
+	b := make([]byte, flow.AccountProofNonceMinLenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("cannot generate nonce: %w", err)
+	}
+	nonce := hex.EncodeToString(b)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.removeExpired(now)
+	s.nonces[nonce] = now.Add(s.ttl)
+
+	return nonce, nil
+}
+
+// Consume marks the nonce as used.
+func (s *MemoryNonceStore) Consume(_ context.Context, nonce string) error {
+	nonce = strings.ToLower(strings.TrimPrefix(nonce, "0x"))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiry, ok := s.nonces[nonce]
+	if !ok {
+		return fmt.Errorf("%w: nonce was not issued or was already used", flow.ErrInvalidNonce)
+	}
+	delete(s.nonces, nonce)
+
+	if !time.Now().Before(expiry) {
+		return fmt.Errorf("%w: nonce has expired", flow.ErrInvalidNonce)
+	}
+	return nil
+}
+
+func (s *MemoryNonceStore) removeExpired(now time.Time) {
+	for nonce, expiry := range s.nonces {
+		if !now.Before(expiry) {
+			delete(s.nonces, nonce)
+		}
+	}
+}
+
+// VerifyAccountProof verifies an FCL account proof for the app ID, and returns the verified address.
+//
+// The nonce of the proof is consumed from the nonce store, so a proof is only accepted once, and the signatures
+// are verified against the keys of the account with their weights, see flow.VerifyAccountProof.
+// Account proofs returned by FCL can be parsed with flow.ParseAccountProof.
+//
+// The account keys are fetched at the latest sealed block, unless AtBlockHeight is given.
+func VerifyAccountProof(
+	ctx context.Context,
+	client Client,
+	appID string,
+	proof *flow.AccountProof,
+	nonces NonceStore,
+	opts ...VerifyOption,
+) (flow.Address, error) {
+	options := newVerifyOptions(opts)
+
+	// check the format of the nonce and app ID before consuming the nonce
+	if _, err := flow.EncodeAccountProofMessage(proof.Address, appID, proof.Nonce); err != nil {
+		return flow.EmptyAddress, err
+	}
+
+	if err := nonces.Consume(ctx, proof.Nonce); err != nil {
+		return flow.EmptyAddress, err
+	}
+
+	keys, err := getAccountKeys(ctx, client, proof.Address, options)
+	if err != nil {
+		return flow.EmptyAddress, err
+	}
+
+	if err := flow.VerifyAccountProof(proof, appID, keys, options.mode); err != nil {
+		return flow.EmptyAddress, err
+	}
+
+	return proof.Address, nil
+}
@@ -0,0 +1,107 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package access_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+	"github.com/onflow/flow-go-sdk/access/mocks"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestVerifyAccountProof(t *testing.T) {
+	ctx := context.Background()
+	const appID = "test-app"
+
+	address := test.AddressGenerator().New()
+	key, signer := test.AccountKeyGenerator().NewWithSigner()
+
+	client := mocks.NewClient(t)
+	client.On("GetAccountKeysAtLatestBlock", mock.Anything, address).
+		Return([]*flow.AccountKey{key}, nil).
+		Maybe()
+
+	nonces := access.NewMemoryNonceStore(time.Minute)
+
+	newProof := func(t *testing.T, nonce string) *flow.AccountProof {
+		message, err := flow.EncodeAccountProofMessage(address, appID, nonce)
+		require.NoError(t, err)
+		signature, err := flow.SignUserMessage(signer, message)
+		require.NoError(t, err)
+
+		return &flow.AccountProof{
+			Address:    address,
+			Nonce:      nonce,
+			Signatures: []flow.CompositeSignature{{Address: address, KeyIndex: key.Index, Signature: signature}},
+		}
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		nonce, err := nonces.Issue(ctx)
+		require.NoError(t, err)
+		proof := newProof(t, nonce)
+
+		verified, err := access.VerifyAccountProof(ctx, client, appID, proof, nonces)
+		require.NoError(t, err)
+		assert.Equal(t, address, verified)
+
+		// nonces can only be used once
+		_, err = access.VerifyAccountProof(ctx, client, appID, proof, nonces)
+		assert.ErrorIs(t, err, flow.ErrInvalidNonce)
+	})
+
+	t.Run("Unknown nonce", func(t *testing.T) {
+		proof := newProof(t, "3037366134636339643564623330316636626239323161663465346131393662")
+		_, err := access.VerifyAccountProof(ctx, client, appID, proof, nonces)
+		assert.ErrorIs(t, err, flow.ErrInvalidNonce)
+	})
+
+	t.Run("Invalid nonce", func(t *testing.T) {
+		proof := newProof(t, "3037366134636339643564623330316636626239323161663465346131393662")
+		proof.Nonce = "0102"
+		_, err := access.VerifyAccountProof(ctx, client, appID, proof, nonces)
+		assert.ErrorIs(t, err, flow.ErrInvalidNonce)
+	})
+
+	t.Run("Expired nonce", func(t *testing.T) {
+		expiring := access.NewMemoryNonceStore(0)
+		nonce, err := expiring.Issue(ctx)
+		require.NoError(t, err)
+
+		_, err = access.VerifyAccountProof(ctx, client, appID, newProof(t, nonce), expiring)
+		assert.ErrorIs(t, err, flow.ErrInvalidNonce)
+		assert.ErrorContains(t, err, "expired")
+	})
+
+	t.Run("Other app", func(t *testing.T) {
+		nonce, err := nonces.Issue(ctx)
+		require.NoError(t, err)
+
+		_, err = access.VerifyAccountProof(ctx, client, "other-app", newProof(t, nonce), nonces)
+		assert.ErrorIs(t, err, flow.ErrInvalidSignature)
+	})
+}
@@ -0,0 +1,703 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+func (c *Client) Ping(_ context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return status.Error(codes.Unavailable, "client is closed")
+	}
+	return nil
+}
+
+func (c *Client) GetNetworkParameters(_ context.Context) (*flow.NetworkParameters, error) {
+	return &flow.NetworkParameters{ChainID: c.chainID}, nil
+}
+
+func (c *Client) GetNodeVersionInfo(_ context.Context) (*flow.NodeVersionInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return &flow.NodeVersionInfo{
+		Semver:  "v0.0.0-fake",
+		SporkId: c.blocks[0].ID,
+	}, nil
+}
+
+func (c *Client) GetLatestBlockHeader(ctx context.Context, isSealed bool) (*flow.BlockHeader, error) {
+	block, err := c.GetLatestBlock(ctx, isSealed)
+	if err != nil {
+		return nil, err
+	}
+	return &block.BlockHeader, nil
+}
+
+func (c *Client) GetBlockHeaderByID(ctx context.Context, blockID flow.Identifier) (*flow.BlockHeader, error) {
+	block, err := c.GetBlockByID(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+	return &block.BlockHeader, nil
+}
+
+func (c *Client) GetBlockHeaderByHeight(ctx context.Context, height uint64) (*flow.BlockHeader, error) {
+	block, err := c.GetBlockByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return &block.BlockHeader, nil
+}
+
+func (c *Client) GetLatestBlock(_ context.Context, isSealed bool) (*flow.Block, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if isSealed {
+		return c.blockAt(c.sealedHeight()), nil
+	}
+	return c.blockAt(c.head().Height), nil
+}
+
+func (c *Client) GetBlockByID(_ context.Context, blockID flow.Identifier) (*flow.Block, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, err := c.blockByID(blockID)
+	if err != nil {
+		return nil, err
+	}
+	return c.blockAt(b.Height), nil
+}
+
+func (c *Client) GetBlockByHeight(_ context.Context, height uint64) (*flow.Block, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if height > c.head().Height {
+		return nil, status.Errorf(codes.NotFound, "block at height %d not found", height)
+	}
+	return c.blockAt(height), nil
+}
+
+func (c *Client) GetCollection(ctx context.Context, colID flow.Identifier) (*flow.Collection, error) {
+	return c.GetCollectionByID(ctx, colID)
+}
+
+func (c *Client) GetCollectionByID(ctx context.Context, id flow.Identifier) (*flow.Collection, error) {
+	collection, err := c.GetFullCollectionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	light := collection.Light()
+	return &light, nil
+}
+
+func (c *Client) GetFullCollectionByID(_ context.Context, id flow.Identifier) (*flow.FullCollection, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	collection, ok := c.collections[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "collection %s not found", id)
+	}
+
+	copied := &flow.FullCollection{}
+	for _, tx := range collection.Transactions {
+		t := *tx
+		copied.Transactions = append(copied.Transactions, &t)
+	}
+	return copied, nil
+}
+
+func (c *Client) SendTransaction(_ context.Context, tx flow.Transaction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, err := c.sendTransaction(tx)
+	return err
+}
+
+func (c *Client) sendTransaction(tx flow.Transaction) (*transaction, error) {
+	if err := c.validateTransaction(&tx); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction: %v", err)
+	}
+
+	c.sequenceNumbers[tx.ProposalKey.Address][tx.ProposalKey.KeyIndex]++
+
+	sent := &transaction{tx: tx}
+	c.transactions[tx.ID()] = sent
+	c.pending = append(c.pending, sent)
+	return sent, nil
+}
+
+// validateTransaction checks a transaction like access nodes do, and its proposal key sequence number.
+func (c *Client) validateTransaction(tx *flow.Transaction) error {
+	if _, ok := c.transactions[tx.ID()]; ok {
+		return fmt.Errorf("transaction %s was already sent", tx.ID())
+	}
+
+	ref, ok := c.blocksByID[tx.ReferenceBlockID]
+	if !ok {
+		return fmt.Errorf("reference block %s not found", tx.ReferenceBlockID)
+	}
+	if c.head().Height-ref.Height >= TransactionExpiry {
+		return fmt.Errorf("transaction expired, reference block %s is at height %d", ref.ID, ref.Height)
+	}
+
+	if tx.Payer == flow.EmptyAddress {
+		return errors.New("missing payer")
+	}
+
+	keys := make(map[flow.Address][]*flow.AccountKey)
+	signers := append([]flow.Address{tx.ProposalKey.Address, tx.Payer}, tx.Authorizers...)
+	for _, address := range signers {
+		account := c.latestAccount(address)
+		if account == nil {
+			return fmt.Errorf("account %s not found", address)
+		}
+		keys[address] = account.Keys
+	}
+
+	proposalKey := tx.ProposalKey
+	var key *flow.AccountKey
+	for _, k := range keys[proposalKey.Address] {
+		if k.Index == proposalKey.KeyIndex {
+			key = k
+		}
+	}
+	switch {
+	case key == nil:
+		return fmt.Errorf("proposal key %d of account %s not found", proposalKey.KeyIndex, proposalKey.Address)
+	case key.Revoked:
+		return fmt.Errorf("proposal key %d of account %s is revoked", proposalKey.KeyIndex, proposalKey.Address)
+	}
+
+	expected := c.sequenceNumbers[proposalKey.Address][proposalKey.KeyIndex]
+	if proposalKey.SequenceNumber != expected {
+		return fmt.Errorf(
+			"invalid sequence number %d of proposal key %d of account %s, expected %d",
+			proposalKey.SequenceNumber,
+			proposalKey.KeyIndex,
+			proposalKey.Address,
+			expected,
+		)
+	}
+
+	if c.verifySignatures {
+		report, err := flow.VerifyTransactionSignatures(tx, keys)
+		if err != nil {
+			return err
+		}
+		if err := report.Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) GetTransaction(_ context.Context, txID flow.Identifier) (*flow.Transaction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tx, err := c.transaction(txID)
+	if err != nil {
+		return nil, err
+	}
+
+	copied := tx.tx
+	return &copied, nil
+}
+
+func (c *Client) GetTransactionsByBlockID(_ context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, err := c.blockByID(blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*flow.Transaction, 0, len(b.transactions))
+	for _, tx := range b.transactions {
+		copied := tx.tx
+		txs = append(txs, &copied)
+	}
+	return txs, nil
+}
+
+func (c *Client) GetTransactionResult(_ context.Context, txID flow.Identifier) (*flow.TransactionResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tx, err := c.transaction(txID)
+	if err != nil {
+		return nil, err
+	}
+	return c.transactionResult(tx), nil
+}
+
+func (c *Client) GetTransactionResultByIndex(
+	_ context.Context,
+	blockID flow.Identifier,
+	index uint32,
+) (*flow.TransactionResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, err := c.blockByID(blockID)
+	if err != nil {
+		return nil, err
+	}
+	if int(index) >= len(b.transactions) {
+		return nil, status.Errorf(codes.NotFound, "transaction %d of block %s not found", index, blockID)
+	}
+	return c.transactionResult(b.transactions[index]), nil
+}
+
+func (c *Client) GetTransactionResultsByBlockID(
+	_ context.Context,
+	blockID flow.Identifier,
+) ([]*flow.TransactionResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, err := c.blockByID(blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*flow.TransactionResult, 0, len(b.transactions))
+	for _, tx := range b.transactions {
+		results = append(results, c.transactionResult(tx))
+	}
+	return results, nil
+}
+
+func (c *Client) GetScheduledTransaction(_ context.Context, _ uint64) (*flow.Transaction, error) {
+	return nil, unimplemented("scheduled transactions")
+}
+
+func (c *Client) GetScheduledTransactionResult(_ context.Context, _ uint64) (*flow.TransactionResult, error) {
+	return nil, unimplemented("scheduled transactions")
+}
+
+func (c *Client) GetSystemTransaction(_ context.Context, _ flow.Identifier) (*flow.Transaction, error) {
+	return nil, unimplemented("system transactions")
+}
+
+func (c *Client) GetSystemTransactionWithID(
+	_ context.Context,
+	_ flow.Identifier,
+	_ flow.Identifier,
+) (*flow.Transaction, error) {
+	return nil, unimplemented("system transactions")
+}
+
+func (c *Client) GetSystemTransactionResult(_ context.Context, _ flow.Identifier) (*flow.TransactionResult, error) {
+	return nil, unimplemented("system transactions")
+}
+
+func (c *Client) GetSystemTransactionResultWithID(
+	_ context.Context,
+	_ flow.Identifier,
+	_ flow.Identifier,
+) (*flow.TransactionResult, error) {
+	return nil, unimplemented("system transactions")
+}
+
+func (c *Client) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	return c.GetAccountAtLatestBlock(ctx, address)
+}
+
+func (c *Client) GetAccountAtLatestBlock(_ context.Context, address flow.Address) (*flow.Account, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.account(address, c.sealedHeight())
+}
+
+func (c *Client) GetAccountAtBlockHeight(
+	_ context.Context,
+	address flow.Address,
+	blockHeight uint64,
+) (*flow.Account, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.account(address, blockHeight)
+}
+
+func (c *Client) GetAccountBalanceAtLatestBlock(ctx context.Context, address flow.Address) (uint64, error) {
+	account, err := c.GetAccountAtLatestBlock(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+	return account.Balance, nil
+}
+
+func (c *Client) GetAccountBalanceAtBlockHeight(
+	ctx context.Context,
+	address flow.Address,
+	blockHeight uint64,
+) (uint64, error) {
+	account, err := c.GetAccountAtBlockHeight(ctx, address, blockHeight)
+	if err != nil {
+		return 0, err
+	}
+	return account.Balance, nil
+}
+
+func (c *Client) GetAccountKeyAtLatestBlock(
+	ctx context.Context,
+	address flow.Address,
+	keyIndex uint32,
+) (*flow.AccountKey, error) {
+	account, err := c.GetAccountAtLatestBlock(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return accountKey(account, keyIndex)
+}
+
+func (c *Client) GetAccountKeyAtBlockHeight(
+	ctx context.Context,
+	address flow.Address,
+	keyIndex uint32,
+	height uint64,
+) (*flow.AccountKey, error) {
+	account, err := c.GetAccountAtBlockHeight(ctx, address, height)
+	if err != nil {
+		return nil, err
+	}
+	return accountKey(account, keyIndex)
+}
+
+func (c *Client) GetAccountKeysAtLatestBlock(ctx context.Context, address flow.Address) ([]*flow.AccountKey, error) {
+	account, err := c.GetAccountAtLatestBlock(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return account.Keys, nil
+}
+
+func (c *Client) GetAccountKeysAtBlockHeight(
+	ctx context.Context,
+	address flow.Address,
+	height uint64,
+) ([]*flow.AccountKey, error) {
+	account, err := c.GetAccountAtBlockHeight(ctx, address, height)
+	if err != nil {
+		return nil, err
+	}
+	return account.Keys, nil
+}
+
+func (c *Client) ExecuteScriptAtLatestBlock(
+	_ context.Context,
+	script []byte,
+	arguments []cadence.Value,
+) (cadence.Value, error) {
+	c.mu.Lock()
+	height := c.sealedHeight()
+	c.mu.Unlock()
+
+	return c.executeScript(height, script, arguments)
+}
+
+func (c *Client) ExecuteScriptAtBlockID(
+	_ context.Context,
+	blockID flow.Identifier,
+	script []byte,
+	arguments []cadence.Value,
+) (cadence.Value, error) {
+	c.mu.Lock()
+	b, err := c.executedBlockByID(blockID)
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.executeScript(b.Height, script, arguments)
+}
+
+func (c *Client) ExecuteScriptAtBlockHeight(
+	_ context.Context,
+	height uint64,
+	script []byte,
+	arguments []cadence.Value,
+) (cadence.Value, error) {
+	c.mu.Lock()
+	executed := c.executed
+	c.mu.Unlock()
+	if height > executed {
+		return nil, status.Errorf(codes.NotFound, "block at height %d is not executed", height)
+	}
+
+	return c.executeScript(height, script, arguments)
+}
+
+func (c *Client) executeScript(height uint64, script []byte, arguments []cadence.Value) (cadence.Value, error) {
+	c.mu.Lock()
+	value, ok := c.scriptResults[string(script)]
+	c.mu.Unlock()
+
+	switch {
+	case ok:
+		return value, nil
+	case c.scriptHandler != nil:
+		value, err := c.scriptHandler(height, script, arguments)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to execute script: %v", err)
+		}
+		return value, nil
+	default:
+		return nil, status.Error(codes.InvalidArgument, "failed to execute script: no result set for script")
+	}
+}
+
+func (c *Client) GetEventsForHeightRange(
+	_ context.Context,
+	eventType string,
+	startHeight uint64,
+	endHeight uint64,
+) ([]flow.BlockEvents, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	endHeight = min(endHeight, c.sealedHeight())
+	if startHeight > endHeight {
+		return nil, status.Errorf(codes.InvalidArgument, "start height %d is above end height %d", startHeight, endHeight)
+	}
+
+	filter := flow.EventFilter{EventTypes: []string{eventType}}
+	var events []flow.BlockEvents
+	for height := startHeight; height <= endHeight; height++ {
+		events = append(events, blockEvents(c.blocks[height], filter))
+	}
+	return events, nil
+}
+
+func (c *Client) GetEventsForBlockIDs(
+	_ context.Context,
+	eventType string,
+	blockIDs []flow.Identifier,
+) ([]flow.BlockEvents, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	filter := flow.EventFilter{EventTypes: []string{eventType}}
+	var events []flow.BlockEvents
+	for _, blockID := range blockIDs {
+		b, err := c.executedBlockByID(blockID)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, blockEvents(b, filter))
+	}
+	return events, nil
+}
+
+func (c *Client) GetLatestProtocolStateSnapshot(_ context.Context) ([]byte, error) {
+	return nil, unimplemented("protocol state snapshots")
+}
+
+func (c *Client) GetProtocolStateSnapshotByBlockID(_ context.Context, _ flow.Identifier) ([]byte, error) {
+	return nil, unimplemented("protocol state snapshots")
+}
+
+func (c *Client) GetProtocolStateSnapshotByHeight(_ context.Context, _ uint64) ([]byte, error) {
+	return nil, unimplemented("protocol state snapshots")
+}
+
+func (c *Client) GetExecutionResultByID(_ context.Context, id flow.Identifier) (*flow.ExecutionResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.results[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "execution result %s not found", id)
+	}
+	result := *b.result
+	return &result, nil
+}
+
+func (c *Client) GetExecutionResultForBlockID(
+	_ context.Context,
+	blockID flow.Identifier,
+) (*flow.ExecutionResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, err := c.executedBlockByID(blockID)
+	if err != nil {
+		return nil, err
+	}
+	result := *b.result
+	return &result, nil
+}
+
+func (c *Client) GetExecutionDataByBlockID(
+	_ context.Context,
+	blockID flow.Identifier,
+) (*flow.ExecutionData, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, err := c.executedBlockByID(blockID)
+	if err != nil {
+		return nil, err
+	}
+	return executionData(b), nil
+}
+
+func (c *Client) blockByID(blockID flow.Identifier) (*block, error) {
+	b, ok := c.blocksByID[blockID]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "block %s not found", blockID)
+	}
+	return b, nil
+}
+
+func (c *Client) executedBlockByID(blockID flow.Identifier) (*block, error) {
+	b, err := c.blockByID(blockID)
+	if err != nil {
+		return nil, err
+	}
+	if !b.executed {
+		return nil, status.Errorf(codes.NotFound, "block %s is not executed", blockID)
+	}
+	return b, nil
+}
+
+func (c *Client) transaction(txID flow.Identifier) (*transaction, error) {
+	tx, ok := c.transactions[txID]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "transaction %s not found", txID)
+	}
+	return tx, nil
+}
+
+func (c *Client) account(address flow.Address, height uint64) (*flow.Account, error) {
+	if height > c.executed {
+		return nil, status.Errorf(codes.NotFound, "block at height %d is not executed", height)
+	}
+
+	account := c.accountAt(address, height)
+	if account == nil {
+		return nil, status.Errorf(codes.NotFound, "account %s not found at height %d", address, height)
+	}
+	return copyAccount(account), nil
+}
+
+func accountKey(account *flow.Account, keyIndex uint32) (*flow.AccountKey, error) {
+	for _, key := range account.Keys {
+		if key.Index == keyIndex {
+			return key, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "key %d of account %s not found", keyIndex, account.Address)
+}
+
+// blockEvents returns the events of an executed block matching a filter.
+func blockEvents(b *block, filter flow.EventFilter) flow.BlockEvents {
+	events := flow.BlockEvents{
+		BlockID:        b.ID,
+		Height:         b.Height,
+		BlockTimestamp: b.Timestamp,
+		Events:         []flow.Event{},
+	}
+	for _, event := range b.events {
+		if matchesFilter(event, filter) {
+			events.Events = append(events.Events, event)
+		}
+	}
+	return events
+}
+
+// matchesFilter returns true if an event matches any of the types, addresses or contracts of a filter,
+// or if the filter is empty.
+func matchesFilter(event flow.Event, filter flow.EventFilter) bool {
+	if len(filter.EventTypes) == 0 && len(filter.Addresses) == 0 && len(filter.Contracts) == 0 {
+		return true
+	}
+
+	for _, eventType := range filter.EventTypes {
+		if event.Type == eventType {
+			return true
+		}
+	}
+
+	// events of contracts have types like A.<address>.<contract>.<event>
+	parts := strings.Split(event.Type, ".")
+	if len(parts) != 4 || parts[0] != "A" {
+		return false
+	}
+	for _, address := range filter.Addresses {
+		if flow.HexToAddress(address).Hex() == parts[1] {
+			return true
+		}
+	}
+	for _, contract := range filter.Contracts {
+		if contract == strings.Join(parts[:3], ".") {
+			return true
+		}
+	}
+	return false
+}
+
+func executionData(b *block) *flow.ExecutionData {
+	chunk := &flow.ChunkExecutionData{}
+	for _, tx := range b.transactions {
+		copied := tx.tx
+		chunk.Transactions = append(chunk.Transactions, &copied)
+		chunk.TransactionResults = append(chunk.TransactionResults, &flow.LightTransactionResult{
+			TransactionID: copied.ID(),
+			Failed:        tx.result.Error != nil,
+		})
+	}
+	for _, event := range b.events {
+		chunk.Events = append(chunk.Events, &event)
+	}
+
+	return &flow.ExecutionData{
+		BlockID:            b.ID,
+		ChunkExecutionData: []*flow.ChunkExecutionData{chunk},
+	}
+}
+
+func unimplemented(feature string) error {
+	return status.Errorf(codes.Unimplemented, "%s are not supported by the fake client", feature)
+}
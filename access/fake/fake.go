@@ -0,0 +1,584 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fake provides an in-memory Flow chain implementing access.Client, for unit tests of code using the
+// Access API without running the emulator.
+//
+// The chain starts with a sealed root block, and produces blocks when CommitBlock is called or, with
+// WithBlockInterval, on a ticker. Sent transactions are checked like the network does: the reference block must be
+// known and not expired, the proposer, payer and authorizers must exist, the proposal key sequence number must be
+// the next one, and the signatures must be valid. Transactions then move through their lifecycle as blocks are
+// committed:
+//
+//   - pending until the next block is committed, which includes them,
+//   - finalized with their block,
+//   - executed once WithExecutionDelay blocks were committed on top of their block,
+//   - sealed once WithSealingDelay blocks were committed on top of their block.
+//
+// Cadence is not executed. The events of transactions are returned by a TransactionHandler, additional events can
+// be emitted with EmitEvents, and script results are configured with SetScriptResult or a ScriptHandler:
+//
+//	chain := fake.NewClient()
+//	address := chain.CreateAccount(accountKey)
+//	chain.SetScriptResult(script, cadence.String("Hello"))
+//
+//	err := chain.SendTransaction(ctx, *tx)
+//	chain.CommitBlocks(3)
+//
+// Errors are gRPC status errors, like the ones returned by access nodes.
+package fake
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// TransactionExpiry is the number of blocks after its reference block a transaction expires.
+const TransactionExpiry = 600
+
+// A TransactionHandler executes a transaction, returning its events, or the error failing the transaction.
+//
+// Handlers are called while the chain is locked, and must not call the client.
+type TransactionHandler func(tx flow.Transaction) ([]flow.Event, error)
+
+// A ScriptHandler executes a script at a block height.
+type ScriptHandler func(height uint64, script []byte, arguments []cadence.Value) (cadence.Value, error)
+
+// An Option configures a Client.
+type Option func(*Client)
+
+// WithChainID sets the chain ID of the chain, which defaults to flow.Emulator.
+func WithChainID(chainID flow.ChainID) Option {
+	return func(c *Client) {
+		c.chainID = chainID
+	}
+}
+
+// WithBlockInterval commits a block at every interval, until the client is closed.
+func WithBlockInterval(interval time.Duration) Option {
+	return func(c *Client) {
+		c.blockInterval = interval
+	}
+}
+
+// WithExecutionDelay sets the number of blocks committed on top of a block before it is executed, which defaults to 1.
+func WithExecutionDelay(blocks uint64) Option {
+	return func(c *Client) {
+		c.executionDelay = blocks
+	}
+}
+
+// WithSealingDelay sets the number of blocks committed on top of a block before it is sealed, which defaults to 2.
+// The sealing delay is at least the execution delay.
+func WithSealingDelay(blocks uint64) Option {
+	return func(c *Client) {
+		c.sealingDelay = blocks
+	}
+}
+
+// WithoutSignatureVerification accepts transactions without verifying their signatures.
+func WithoutSignatureVerification() Option {
+	return func(c *Client) {
+		c.verifySignatures = false
+	}
+}
+
+// WithTransactionHandler sets the handler executing transactions.
+// By default, transactions succeed without events.
+func WithTransactionHandler(handler TransactionHandler) Option {
+	return func(c *Client) {
+		c.transactionHandler = handler
+	}
+}
+
+// WithScriptHandler sets the handler executing scripts without a result set with SetScriptResult.
+func WithScriptHandler(handler ScriptHandler) Option {
+	return func(c *Client) {
+		c.scriptHandler = handler
+	}
+}
+
+var _ access.Client = &Client{}
+
+// Client is an in-memory Flow chain implementing access.Client.
+type Client struct {
+	chainID            flow.ChainID
+	blockInterval      time.Duration
+	executionDelay     uint64
+	sealingDelay       uint64
+	verifySignatures   bool
+	transactionHandler TransactionHandler
+	scriptHandler      ScriptHandler
+
+	mu           sync.Mutex
+	blocks       []*block
+	blocksByID   map[flow.Identifier]*block
+	collections  map[flow.Identifier]*flow.FullCollection
+	results      map[flow.Identifier]*block
+	transactions map[flow.Identifier]*transaction
+	pending      []*transaction
+	emitted      []flow.Event
+	executed     uint64
+	accounts     map[flow.Address][]accountVersion
+	// sequenceNumbers are the next sequence numbers of the account keys, including pending transactions.
+	sequenceNumbers map[flow.Address]map[uint32]uint64
+	addresses       *flow.AddressGenerator
+	scriptResults   map[string]cadence.Value
+	// changed is closed and replaced when blocks are committed.
+	changed chan struct{}
+	done    chan struct{}
+	closed  bool
+}
+
+// block is a committed block, with its execution once executed.
+type block struct {
+	flow.Block
+	collection   *flow.FullCollection
+	transactions []*transaction
+	emitted      []flow.Event
+
+	executed bool
+	events   []flow.Event
+	result   *flow.ExecutionResult
+	resultID flow.Identifier
+}
+
+// transaction is a sent transaction, with its block once included and its result once executed.
+type transaction struct {
+	tx     flow.Transaction
+	block  *block
+	index  int
+	result *flow.TransactionResult
+}
+
+// accountVersion is the state of an account from a block height.
+type accountVersion struct {
+	height  uint64
+	account *flow.Account
+}
+
+// NewClient returns a chain with a sealed root block.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		chainID:          flow.Emulator,
+		executionDelay:   1,
+		sealingDelay:     2,
+		verifySignatures: true,
+		transactionHandler: func(flow.Transaction) ([]flow.Event, error) {
+			return nil, nil
+		},
+		blocksByID:      make(map[flow.Identifier]*block),
+		collections:     make(map[flow.Identifier]*flow.FullCollection),
+		results:         make(map[flow.Identifier]*block),
+		transactions:    make(map[flow.Identifier]*transaction),
+		accounts:        make(map[flow.Address][]accountVersion),
+		sequenceNumbers: make(map[flow.Address]map[uint32]uint64),
+		scriptResults:   make(map[string]cadence.Value),
+		changed:         make(chan struct{}),
+		done:            make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.sealingDelay < c.executionDelay {
+		c.sealingDelay = c.executionDelay
+	}
+
+	// account addresses are generated after the service account
+	c.addresses = flow.NewAddressGenerator(c.chainID).SetIndex(1)
+
+	root := c.newBlock(nil)
+	c.appendBlock(root)
+	c.execute(root)
+
+	if c.blockInterval > 0 {
+		go c.produceBlocks()
+	}
+
+	return c
+}
+
+func (c *Client) produceBlocks() {
+	ticker := time.NewTicker(c.blockInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			c.CommitBlock()
+		}
+	}
+}
+
+// CommitBlock commits a block including the pending transactions and emitted events, executes and seals the blocks
+// reaching the execution and sealing delays, and returns the committed block.
+func (c *Client) CommitBlock() *flow.Block {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.commitBlock()
+}
+
+// CommitBlocks commits n blocks, and returns the last committed block.
+func (c *Client) CommitBlocks(n int) *flow.Block {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var committed *flow.Block
+	for range n {
+		committed = c.commitBlock()
+	}
+	return committed
+}
+
+func (c *Client) commitBlock() *flow.Block {
+	parent := c.head()
+	b := c.newBlock(parent)
+
+	// pending transactions are included, unless they expired
+	var included []*transaction
+	for _, tx := range c.pending {
+		ref := c.blocksByID[tx.tx.ReferenceBlockID]
+		if b.Height-ref.Height > TransactionExpiry {
+			tx.result = &flow.TransactionResult{
+				Status:        flow.TransactionStatusExpired,
+				TransactionID: tx.tx.ID(),
+			}
+			continue
+		}
+		included = append(included, tx)
+	}
+	c.pending = nil
+
+	if len(included) > 0 {
+		collection := &flow.FullCollection{}
+		for i, tx := range included {
+			tx.block = b
+			tx.index = i
+			collection.Transactions = append(collection.Transactions, &tx.tx)
+		}
+		b.collection = collection
+		b.transactions = included
+		b.CollectionGuarantees = []*flow.CollectionGuarantee{{
+			CollectionID:     collection.ID(),
+			ReferenceBlockID: parent.ID,
+		}}
+		c.collections[collection.ID()] = collection
+	}
+
+	b.emitted = c.emitted
+	c.emitted = nil
+
+	sealed := c.sealedHeight()
+	c.appendBlock(b)
+	for height := c.executed + 1; height <= c.executedHeight(); height++ {
+		c.execute(c.blocks[height])
+	}
+
+	// the block seals the blocks sealed since its parent
+	for height := sealed + 1; height <= c.sealedHeight() && height < b.Height; height++ {
+		b.Seals = append(b.Seals, &flow.BlockSeal{
+			BlockID:  c.blocks[height].ID,
+			ResultId: c.blocks[height].resultID,
+		})
+	}
+
+	close(c.changed)
+	c.changed = make(chan struct{})
+
+	return c.blockAt(b.Height)
+}
+
+func (c *Client) newBlock(parent *block) *block {
+	b := &block{}
+	b.Timestamp = time.Now().UTC()
+	if parent != nil {
+		b.ParentID = parent.ID
+		b.Height = parent.Height + 1
+		b.ParentView = parent.View
+		b.View = parent.View + 1
+	}
+
+	var data [48]byte
+	copy(data[:], b.ParentID[:])
+	binary.BigEndian.PutUint64(data[32:], b.Height)
+	binary.BigEndian.PutUint64(data[40:], uint64(b.Timestamp.UnixNano()))
+	b.ID = hashID(data[:])
+
+	return b
+}
+
+func (c *Client) appendBlock(b *block) {
+	c.blocks = append(c.blocks, b)
+	c.blocksByID[b.ID] = b
+}
+
+// execute executes the transactions of a block, and updates the sequence numbers of their proposal keys.
+func (c *Client) execute(b *block) {
+	var events []flow.Event
+	for i, tx := range b.transactions {
+		id := tx.tx.ID()
+
+		txEvents, err := c.transactionHandler(tx.tx)
+		for j := range txEvents {
+			txEvents[j].TransactionID = id
+			txEvents[j].TransactionIndex = i
+			txEvents[j].EventIndex = j
+		}
+		events = append(events, txEvents...)
+
+		tx.result = &flow.TransactionResult{
+			Error:         err,
+			Events:        txEvents,
+			BlockID:       b.ID,
+			BlockHeight:   b.Height,
+			TransactionID: id,
+			CollectionID:  b.collection.ID(),
+		}
+
+		c.incrementSequenceNumber(tx.tx.ProposalKey, b.Height)
+	}
+	events = append(events, b.emitted...)
+
+	previousResultID := flow.EmptyID
+	if b.Height > 0 {
+		previousResultID = c.blocks[b.Height-1].resultID
+	}
+
+	eventsHash, _ := flow.CalculateEventsHash(events)
+	b.events = events
+	b.result = &flow.ExecutionResult{
+		PreviousResultID: previousResultID,
+		BlockID:          b.ID,
+		Chunks: []*flow.Chunk{{
+			EventCollection:      eventsHash,
+			BlockID:              b.ID,
+			NumberOfTransactions: uint16(len(b.transactions)),
+		}},
+	}
+	b.resultID = hashID(append([]byte("result"), b.ID[:]...))
+	b.executed = true
+
+	c.results[b.resultID] = b
+	c.executed = b.Height
+}
+
+func (c *Client) incrementSequenceNumber(proposalKey flow.ProposalKey, height uint64) {
+	account := c.latestAccount(proposalKey.Address)
+	if account == nil {
+		return
+	}
+
+	updated := copyAccount(account)
+	for _, key := range updated.Keys {
+		if key.Index == proposalKey.KeyIndex {
+			key.SequenceNumber++
+		}
+	}
+	c.setAccountVersion(updated, height)
+}
+
+// EmitEvents emits events in the next committed block, after the events of its transactions.
+func (c *Client) EmitEvents(events ...flow.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.emitted = append(c.emitted, events...)
+}
+
+// SetScriptResult sets the result of a script, at every block height and for all arguments.
+func (c *Client) SetScriptResult(script []byte, value cadence.Value) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.scriptResults[string(script)] = value
+}
+
+// CreateAccount creates an account with the keys at the latest sealed block, and returns its address.
+// The indexes of the keys are set to their positions.
+func (c *Client) CreateAccount(keys ...*flow.AccountKey) flow.Address {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	account := &flow.Account{
+		Address:   c.addresses.NextAddress(),
+		Contracts: make(map[string][]byte),
+	}
+	for i, key := range keys {
+		k := *key
+		k.Index = uint32(i)
+		account.Keys = append(account.Keys, &k)
+	}
+
+	c.setAccount(account)
+	return account.Address
+}
+
+// SetAccount creates or replaces an account at the latest sealed block.
+//
+// Changes of the account by blocks which are not sealed yet are discarded, and the sequence numbers of its keys are
+// reset to the ones of the account.
+func (c *Client) SetAccount(account flow.Account) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.setAccount(copyAccount(&account))
+}
+
+func (c *Client) setAccount(account *flow.Account) {
+	sealed := c.sealedHeight()
+
+	versions := c.accounts[account.Address]
+	for len(versions) > 0 && versions[len(versions)-1].height >= sealed {
+		versions = versions[:len(versions)-1]
+	}
+	c.accounts[account.Address] = append(versions, accountVersion{height: sealed, account: account})
+
+	sequenceNumbers := make(map[uint32]uint64)
+	for _, key := range account.Keys {
+		sequenceNumbers[key.Index] = key.SequenceNumber
+	}
+	c.sequenceNumbers[account.Address] = sequenceNumbers
+}
+
+func (c *Client) setAccountVersion(account *flow.Account, height uint64) {
+	versions := c.accounts[account.Address]
+	if n := len(versions); n > 0 && versions[n-1].height == height {
+		versions[n-1].account = account
+		return
+	}
+	c.accounts[account.Address] = append(versions, accountVersion{height: height, account: account})
+}
+
+// accountAt returns an account at a block height, or nil if it does not exist.
+func (c *Client) accountAt(address flow.Address, height uint64) *flow.Account {
+	versions := c.accounts[address]
+	for i := len(versions) - 1; i >= 0; i-- {
+		if versions[i].height <= height {
+			return versions[i].account
+		}
+	}
+	return nil
+}
+
+// latestAccount returns the latest state of an account, including the blocks which are not sealed yet.
+func (c *Client) latestAccount(address flow.Address) *flow.Account {
+	versions := c.accounts[address]
+	if len(versions) == 0 {
+		return nil
+	}
+	return versions[len(versions)-1].account
+}
+
+// Close stops the block production, and ends all subscriptions.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.closed {
+		c.closed = true
+		close(c.done)
+	}
+	return nil
+}
+
+func (c *Client) head() *block {
+	return c.blocks[len(c.blocks)-1]
+}
+
+// heightAt returns the height reached by blocks delayed by a number of blocks, when the chain is at a height.
+func (c *Client) heightAt(head uint64, delay uint64) uint64 {
+	if head < delay {
+		return 0
+	}
+	return head - delay
+}
+
+func (c *Client) executedHeight() uint64 {
+	return c.heightAt(c.head().Height, c.executionDelay)
+}
+
+func (c *Client) sealedHeight() uint64 {
+	return c.heightAt(c.head().Height, c.sealingDelay)
+}
+
+// blockAt returns a copy of the block at a height, with its current status.
+func (c *Client) blockAt(height uint64) *flow.Block {
+	b := c.blocks[height].Block
+	b.Status = flow.BlockStatusFinalized
+	if height <= c.sealedHeight() {
+		b.Status = flow.BlockStatusSealed
+	}
+	return &b
+}
+
+// transactionResult returns the result of a transaction with its current status.
+func (c *Client) transactionResult(tx *transaction) *flow.TransactionResult {
+	switch {
+	case tx.result != nil && tx.result.Status == flow.TransactionStatusExpired:
+		result := *tx.result
+		return &result
+	case tx.block == nil:
+		return &flow.TransactionResult{
+			Status:        flow.TransactionStatusPending,
+			TransactionID: tx.tx.ID(),
+		}
+	case tx.result == nil:
+		return &flow.TransactionResult{
+			Status:        flow.TransactionStatusFinalized,
+			BlockID:       tx.block.ID,
+			BlockHeight:   tx.block.Height,
+			TransactionID: tx.tx.ID(),
+			CollectionID:  tx.block.collection.ID(),
+		}
+	}
+
+	result := *tx.result
+	result.Status = flow.TransactionStatusExecuted
+	if tx.block.Height <= c.sealedHeight() {
+		result.Status = flow.TransactionStatusSealed
+	}
+	return &result
+}
+
+func copyAccount(account *flow.Account) *flow.Account {
+	copied := *account
+	copied.Keys = make([]*flow.AccountKey, len(account.Keys))
+	for i, key := range account.Keys {
+		k := *key
+		copied.Keys[i] = &k
+	}
+	copied.Contracts = make(map[string][]byte, len(account.Contracts))
+	for name, code := range account.Contracts {
+		copied.Contracts[name] = code
+	}
+	return &copied
+}
+
+func hashID(data []byte) flow.Identifier {
+	return flow.HashToID(crypto.NewSHA3_256().ComputeHash(data))
+}
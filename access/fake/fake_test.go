@@ -0,0 +1,345 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package fake_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access/fake"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+// newTransaction returns a transaction proposed, paid and authorized by an account, signed by its key.
+func newTransaction(
+	t *testing.T,
+	chain *fake.Client,
+	address flow.Address,
+	signer crypto.Signer,
+) *flow.Transaction {
+	ctx := context.Background()
+
+	header, err := chain.GetLatestBlockHeader(ctx, false)
+	require.NoError(t, err)
+	key, err := chain.GetAccountKeyAtLatestBlock(ctx, address, 0)
+	require.NoError(t, err)
+
+	return signTransaction(t, flow.NewTransaction().
+		SetScript([]byte("transaction { prepare(signer: &Account) {} }")).
+		SetReferenceBlockID(header.ID).
+		SetProposalKey(address, key.Index, key.SequenceNumber).
+		SetPayer(address).
+		AddAuthorizer(address), address, signer)
+}
+
+func signTransaction(t *testing.T, tx *flow.Transaction, address flow.Address, signer crypto.Signer) *flow.Transaction {
+	require.NoError(t, tx.SignEnvelope(address, 0, signer))
+	return tx
+}
+
+func TestClient_TransactionLifecycle(t *testing.T) {
+	ctx := context.Background()
+
+	eventType := "A.0000000000000001.Test.Executed"
+	chain := fake.NewClient(fake.WithTransactionHandler(func(tx flow.Transaction) ([]flow.Event, error) {
+		return []flow.Event{{Type: eventType}}, nil
+	}))
+	defer chain.Close()
+
+	accountKey, signer := test.AccountKeyGenerator().NewWithSigner()
+	address := chain.CreateAccount(accountKey)
+
+	tx := newTransaction(t, chain, address, signer)
+	require.NoError(t, chain.SendTransaction(ctx, *tx))
+
+	statuses := []flow.TransactionStatus{
+		flow.TransactionStatusPending,
+		flow.TransactionStatusFinalized,
+		flow.TransactionStatusExecuted,
+		flow.TransactionStatusSealed,
+	}
+	for i, expected := range statuses {
+		if i > 0 {
+			chain.CommitBlock()
+		}
+
+		result, err := chain.GetTransactionResult(ctx, tx.ID())
+		require.NoError(t, err)
+		assert.Equal(t, expected, result.Status)
+	}
+
+	result, err := chain.GetTransactionResult(ctx, tx.ID())
+	require.NoError(t, err)
+	require.NoError(t, result.Error)
+	assert.Equal(t, uint64(1), result.BlockHeight)
+	require.Len(t, result.Events, 1)
+	assert.Equal(t, tx.ID(), result.Events[0].TransactionID)
+
+	block, err := chain.GetBlockByHeight(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, flow.BlockStatusSealed, block.Status)
+	require.Len(t, block.CollectionGuarantees, 1)
+
+	collection, err := chain.GetCollectionByID(ctx, block.CollectionGuarantees[0].CollectionID)
+	require.NoError(t, err)
+	assert.Equal(t, []flow.Identifier{tx.ID()}, collection.TransactionIDs)
+
+	account, err := chain.GetAccount(ctx, address)
+	require.NoError(t, err)
+	assert.Equal(t, accountKey.SequenceNumber+1, account.Keys[0].SequenceNumber)
+
+	events, err := chain.GetEventsForHeightRange(ctx, eventType, 0, 100)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Empty(t, events[0].Events)
+	assert.Len(t, events[1].Events, 1)
+
+	t.Run("Sequence number of the next transaction", func(t *testing.T) {
+		next := newTransaction(t, chain, address, signer)
+		require.NoError(t, chain.SendTransaction(ctx, *next))
+
+		err := chain.SendTransaction(ctx, *signTransaction(t, newTransaction(t, chain, address, signer).SetGasLimit(42), address, signer))
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, err, "invalid sequence number")
+	})
+}
+
+func TestClient_SendTransaction_Invalid(t *testing.T) {
+	ctx := context.Background()
+
+	chain := fake.NewClient()
+	defer chain.Close()
+
+	keys := test.AccountKeyGenerator()
+	accountKey, signer := keys.NewWithSigner()
+	address := chain.CreateAccount(accountKey)
+	_, otherSigner := keys.NewWithSigner()
+
+	tests := []struct {
+		name   string
+		tx     func() *flow.Transaction
+		reason string
+	}{
+		{
+			name: "Unknown reference block",
+			tx: func() *flow.Transaction {
+				tx := newTransaction(t, chain, address, signer)
+				tx.SetReferenceBlockID(flow.Identifier{0x01})
+				return signTransaction(t, tx, address, signer)
+			},
+			reason: "reference block",
+		},
+		{
+			name: "Unknown account",
+			tx: func() *flow.Transaction {
+				tx := newTransaction(t, chain, address, signer)
+				tx.SetPayer(flow.HexToAddress("01"))
+				return tx
+			},
+			reason: "account 0000000000000001 not found",
+		},
+		{
+			name: "Unknown proposal key",
+			tx: func() *flow.Transaction {
+				tx := newTransaction(t, chain, address, signer)
+				tx.SetProposalKey(address, 1, 0)
+				return tx
+			},
+			reason: "proposal key 1",
+		},
+		{
+			name: "Invalid signature",
+			tx: func() *flow.Transaction {
+				tx := newTransaction(t, chain, address, signer)
+				tx.EnvelopeSignatures = nil
+				return signTransaction(t, tx, address, otherSigner)
+			},
+			reason: "missing signature for proposal key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := chain.SendTransaction(ctx, *tt.tx())
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.ErrorContains(t, err, tt.reason)
+		})
+	}
+
+	t.Run("Without signature verification", func(t *testing.T) {
+		chain := fake.NewClient(fake.WithoutSignatureVerification())
+		defer chain.Close()
+
+		address := chain.CreateAccount(accountKey)
+		tx := newTransaction(t, chain, address, signer)
+		tx.EnvelopeSignatures = nil
+
+		assert.NoError(t, chain.SendTransaction(ctx, *tx))
+	})
+}
+
+func TestClient_SendAndSubscribeTransactionStatuses(t *testing.T) {
+	ctx := context.Background()
+
+	chain := fake.NewClient(fake.WithTransactionHandler(func(tx flow.Transaction) ([]flow.Event, error) {
+		return nil, errors.New("panic: fail")
+	}))
+	defer chain.Close()
+
+	accountKey, signer := test.AccountKeyGenerator().NewWithSigner()
+	address := chain.CreateAccount(accountKey)
+
+	results, errs, err := chain.SendAndSubscribeTransactionStatuses(ctx, *newTransaction(t, chain, address, signer))
+	require.NoError(t, err)
+
+	chain.CommitBlocks(3)
+
+	var statuses []flow.TransactionStatus
+	for result := range results {
+		statuses = append(statuses, result.Status)
+		if result.Status >= flow.TransactionStatusExecuted {
+			assert.EqualError(t, result.Error, "panic: fail")
+		}
+	}
+	assert.Equal(t, []flow.TransactionStatus{
+		flow.TransactionStatusPending,
+		flow.TransactionStatusFinalized,
+		flow.TransactionStatusExecuted,
+		flow.TransactionStatusSealed,
+	}, statuses)
+
+	_, ok := <-errs
+	assert.False(t, ok)
+}
+
+func TestClient_Subscriptions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	chain := fake.NewClient(fake.WithBlockInterval(time.Millisecond))
+	defer chain.Close()
+
+	t.Run("Block headers", func(t *testing.T) {
+		headers, _, err := chain.SubscribeBlockHeadersFromStartHeight(ctx, 0, flow.BlockStatusSealed)
+		require.NoError(t, err)
+
+		for height := range uint64(5) {
+			header := <-headers
+			assert.Equal(t, height, header.Height)
+			assert.Equal(t, flow.BlockStatusSealed, header.Status)
+		}
+	})
+
+	t.Run("Events", func(t *testing.T) {
+		start, err := chain.GetLatestBlockHeader(ctx, false)
+		require.NoError(t, err)
+
+		events, _, err := chain.SubscribeEventsByBlockID(
+			ctx,
+			start.ID,
+			flow.EventFilter{Contracts: []string{"A.0000000000000001.Test"}},
+		)
+		require.NoError(t, err)
+
+		chain.EmitEvents(
+			flow.Event{Type: "A.0000000000000002.Other.Emitted"},
+			flow.Event{Type: "A.0000000000000001.Test.Emitted"},
+		)
+
+		blockEvents := <-events
+		assert.Greater(t, blockEvents.Height, start.Height)
+		require.Len(t, blockEvents.Events, 1)
+		assert.Equal(t, "A.0000000000000001.Test.Emitted", blockEvents.Events[0].Type)
+	})
+
+	t.Run("Account statuses", func(t *testing.T) {
+		address := flow.HexToAddress("01")
+		eventType := cadence.NewEventType(nil, flow.EventAccountCreated, []cadence.Field{
+			{Identifier: "address", Type: cadence.AddressType},
+		}, nil)
+		created := cadence.NewEvent([]cadence.Value{cadence.NewAddress(address)}).WithType(eventType)
+
+		statuses, _, err := chain.SubscribeAccountStatusesFromLatestBlock(ctx, flow.AccountStatusFilter{
+			EventFilter: flow.EventFilter{Addresses: []string{address.String()}},
+		})
+		require.NoError(t, err)
+
+		chain.EmitEvents(flow.Event{Type: flow.EventAccountCreated, Value: created})
+
+		accountStatus := <-statuses
+		assert.Equal(t, uint64(0), accountStatus.MessageIndex)
+		require.Len(t, accountStatus.Results, 1)
+		assert.Equal(t, address, accountStatus.Results[0].Address)
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		chain := fake.NewClient()
+		blocks, errs, err := chain.SubscribeBlocksFromLatest(ctx, flow.BlockStatusFinalized)
+		require.NoError(t, err)
+		<-blocks
+
+		require.NoError(t, chain.Close())
+		_, ok := <-blocks
+		assert.False(t, ok)
+		_, ok = <-errs
+		assert.False(t, ok)
+
+		assert.Equal(t, codes.Unavailable, status.Code(chain.Ping(ctx)))
+	})
+}
+
+func TestClient_ExecuteScript(t *testing.T) {
+	ctx := context.Background()
+
+	script := []byte("access(all) fun main(): String { return \"Hello\" }")
+	chain := fake.NewClient(fake.WithScriptHandler(
+		func(height uint64, script []byte, arguments []cadence.Value) (cadence.Value, error) {
+			if len(arguments) == 0 {
+				return nil, errors.New("missing argument")
+			}
+			return arguments[0], nil
+		},
+	))
+	defer chain.Close()
+	chain.SetScriptResult(script, cadence.String("Hello"))
+
+	value, err := chain.ExecuteScriptAtLatestBlock(ctx, script, nil)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.String("Hello"), value)
+
+	value, err = chain.ExecuteScriptAtBlockHeight(ctx, 0, []byte("echo"), []cadence.Value{cadence.NewInt(42)})
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewInt(42), value)
+
+	_, err = chain.ExecuteScriptAtLatestBlock(ctx, []byte("echo"), nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.ErrorContains(t, err, "missing argument")
+
+	_, err = chain.ExecuteScriptAtBlockHeight(ctx, 1, script, nil)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
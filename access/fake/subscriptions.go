@@ -0,0 +1,489 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fake
+
+import (
+	"context"
+
+	"github.com/onflow/cadence"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+)
+
+// progress is the progress of the blocks a subscription waits for.
+type progress int
+
+const (
+	finalized progress = iota
+	executed
+	sealed
+)
+
+func blockStatusProgress(blockStatus flow.BlockStatus) (progress, error) {
+	switch blockStatus {
+	case flow.BlockStatusFinalized:
+		return finalized, nil
+	case flow.BlockStatusSealed:
+		return sealed, nil
+	default:
+		return 0, status.Errorf(codes.InvalidArgument, "invalid block status %d", blockStatus)
+	}
+}
+
+// reached returns the height of the last block which reached a progress.
+func (c *Client) reached(p progress) uint64 {
+	switch p {
+	case executed:
+		return c.executed
+	case sealed:
+		return c.sealedHeight()
+	default:
+		return c.head().Height
+	}
+}
+
+// startHeight returns the height of a start block.
+func (c *Client) startHeight(blockID flow.Identifier) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, err := c.blockByID(blockID)
+	if err != nil {
+		return 0, err
+	}
+	return b.Height, nil
+}
+
+// latestHeight returns the height of the last block which reached a progress.
+func (c *Client) latestHeight(p progress) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.reached(p)
+}
+
+// subscribe streams the values of the blocks from a start height, once the blocks reached a progress.
+//
+// The value function is called while the chain is locked, and returns false for blocks without a value.
+// The subscription ends when the context is done or the client is closed.
+func subscribe[T any](
+	ctx context.Context,
+	c *Client,
+	start uint64,
+	p progress,
+	value func(b *block) (T, bool),
+) (<-chan T, <-chan error, error) {
+	if err := c.Ping(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	values := make(chan T)
+	errs := make(chan error)
+
+	go func() {
+		defer close(values)
+		defer close(errs)
+
+		for height := start; ; height++ {
+			var v T
+			var ok bool
+			for {
+				c.mu.Lock()
+				reached := height <= c.reached(p)
+				if reached {
+					v, ok = value(c.blocks[height])
+				}
+				changed := c.changed
+				c.mu.Unlock()
+
+				if reached {
+					break
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-c.done:
+					return
+				case <-changed:
+				}
+			}
+
+			if !ok {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-c.done:
+				return
+			case values <- v:
+			}
+		}
+	}()
+
+	return values, errs, nil
+}
+
+func (c *Client) SubscribeExecutionDataByBlockID(
+	ctx context.Context,
+	startBlockID flow.Identifier,
+) (<-chan *flow.ExecutionDataStreamResponse, <-chan error, error) {
+	start, err := c.startHeight(startBlockID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.SubscribeExecutionDataByBlockHeight(ctx, start)
+}
+
+func (c *Client) SubscribeExecutionDataByBlockHeight(
+	ctx context.Context,
+	startHeight uint64,
+) (<-chan *flow.ExecutionDataStreamResponse, <-chan error, error) {
+	return subscribe(ctx, c, startHeight, executed, func(b *block) (*flow.ExecutionDataStreamResponse, bool) {
+		return &flow.ExecutionDataStreamResponse{
+			Height:         b.Height,
+			ExecutionData:  executionData(b),
+			BlockTimestamp: b.Timestamp,
+		}, true
+	})
+}
+
+func (c *Client) SubscribeEventsByBlockID(
+	ctx context.Context,
+	startBlockID flow.Identifier,
+	filter flow.EventFilter,
+	opts ...access.SubscribeOption,
+) (<-chan flow.BlockEvents, <-chan error, error) {
+	start, err := c.startHeight(startBlockID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.SubscribeEventsByBlockHeight(ctx, start, filter, opts...)
+}
+
+// SubscribeEventsByBlockHeight streams the events of executed blocks matching the filter. Blocks without matching
+// events are only sent as heartbeats, every heartbeat interval blocks.
+func (c *Client) SubscribeEventsByBlockHeight(
+	ctx context.Context,
+	startHeight uint64,
+	filter flow.EventFilter,
+	opts ...access.SubscribeOption,
+) (<-chan flow.BlockEvents, <-chan error, error) {
+	conf := access.DefaultSubscribeConfig()
+	for _, apply := range opts {
+		apply(conf)
+	}
+
+	var sinceHeartbeat uint64
+	return subscribe(ctx, c, startHeight, executed, func(b *block) (flow.BlockEvents, bool) {
+		events := blockEvents(b, filter)
+
+		sinceHeartbeat++
+		if len(events.Events) == 0 && (conf.HeartbeatInterval == 0 || sinceHeartbeat < conf.HeartbeatInterval) {
+			return events, false
+		}
+		sinceHeartbeat = 0
+		return events, true
+	})
+}
+
+func (c *Client) SubscribeBlockDigestsFromStartBlockID(
+	ctx context.Context,
+	startBlockID flow.Identifier,
+	blockStatus flow.BlockStatus,
+) (<-chan *flow.BlockDigest, <-chan error, error) {
+	start, err := c.startHeight(startBlockID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.SubscribeBlockDigestsFromStartHeight(ctx, start, blockStatus)
+}
+
+func (c *Client) SubscribeBlockDigestsFromStartHeight(
+	ctx context.Context,
+	startHeight uint64,
+	blockStatus flow.BlockStatus,
+) (<-chan *flow.BlockDigest, <-chan error, error) {
+	p, err := blockStatusProgress(blockStatus)
+	if err != nil {
+		return nil, nil, err
+	}
+	return subscribe(ctx, c, startHeight, p, func(b *block) (*flow.BlockDigest, bool) {
+		return &flow.BlockDigest{
+			BlockID:   b.ID,
+			Height:    b.Height,
+			Timestamp: b.Timestamp,
+		}, true
+	})
+}
+
+func (c *Client) SubscribeBlockDigestsFromLatest(
+	ctx context.Context,
+	blockStatus flow.BlockStatus,
+) (<-chan *flow.BlockDigest, <-chan error, error) {
+	p, err := blockStatusProgress(blockStatus)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.SubscribeBlockDigestsFromStartHeight(ctx, c.latestHeight(p), blockStatus)
+}
+
+func (c *Client) SubscribeBlocksFromStartBlockID(
+	ctx context.Context,
+	startBlockID flow.Identifier,
+	blockStatus flow.BlockStatus,
+) (<-chan *flow.Block, <-chan error, error) {
+	start, err := c.startHeight(startBlockID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.SubscribeBlocksFromStartHeight(ctx, start, blockStatus)
+}
+
+func (c *Client) SubscribeBlocksFromStartHeight(
+	ctx context.Context,
+	startHeight uint64,
+	blockStatus flow.BlockStatus,
+) (<-chan *flow.Block, <-chan error, error) {
+	p, err := blockStatusProgress(blockStatus)
+	if err != nil {
+		return nil, nil, err
+	}
+	return subscribe(ctx, c, startHeight, p, func(b *block) (*flow.Block, bool) {
+		return c.blockAt(b.Height), true
+	})
+}
+
+func (c *Client) SubscribeBlocksFromLatest(
+	ctx context.Context,
+	blockStatus flow.BlockStatus,
+) (<-chan *flow.Block, <-chan error, error) {
+	p, err := blockStatusProgress(blockStatus)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.SubscribeBlocksFromStartHeight(ctx, c.latestHeight(p), blockStatus)
+}
+
+func (c *Client) SubscribeBlockHeadersFromStartBlockID(
+	ctx context.Context,
+	startBlockID flow.Identifier,
+	blockStatus flow.BlockStatus,
+) (<-chan *flow.BlockHeader, <-chan error, error) {
+	start, err := c.startHeight(startBlockID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.SubscribeBlockHeadersFromStartHeight(ctx, start, blockStatus)
+}
+
+func (c *Client) SubscribeBlockHeadersFromStartHeight(
+	ctx context.Context,
+	startHeight uint64,
+	blockStatus flow.BlockStatus,
+) (<-chan *flow.BlockHeader, <-chan error, error) {
+	p, err := blockStatusProgress(blockStatus)
+	if err != nil {
+		return nil, nil, err
+	}
+	return subscribe(ctx, c, startHeight, p, func(b *block) (*flow.BlockHeader, bool) {
+		return &c.blockAt(b.Height).BlockHeader, true
+	})
+}
+
+func (c *Client) SubscribeBlockHeadersFromLatest(
+	ctx context.Context,
+	blockStatus flow.BlockStatus,
+) (<-chan *flow.BlockHeader, <-chan error, error) {
+	p, err := blockStatusProgress(blockStatus)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.SubscribeBlockHeadersFromStartHeight(ctx, c.latestHeight(p), blockStatus)
+}
+
+// SubscribeAccountStatusesFromStartHeight streams the account events of executed blocks matching the filter,
+// grouped by account. Blocks without matching events are not sent.
+func (c *Client) SubscribeAccountStatusesFromStartHeight(
+	ctx context.Context,
+	startBlockHeight uint64,
+	filter flow.AccountStatusFilter,
+) (<-chan *flow.AccountStatus, <-chan error, error) {
+	addresses := make(map[flow.Address]bool)
+	for _, address := range filter.Addresses {
+		addresses[flow.HexToAddress(address)] = true
+	}
+	typeFilter := flow.EventFilter{EventTypes: filter.EventTypes}
+
+	var messageIndex uint64
+	return subscribe(ctx, c, startBlockHeight, executed, func(b *block) (*flow.AccountStatus, bool) {
+		accountStatus := &flow.AccountStatus{
+			BlockID:      b.ID,
+			BlockHeight:  b.Height,
+			MessageIndex: messageIndex,
+		}
+
+		results := make(map[flow.Address]*flow.AccountStatusResult)
+		for _, event := range b.events {
+			address, ok := accountEventAddress(event)
+			if !ok || !matchesFilter(event, typeFilter) || (len(addresses) > 0 && !addresses[address]) {
+				continue
+			}
+
+			result, ok := results[address]
+			if !ok {
+				result = &flow.AccountStatusResult{Address: address}
+				results[address] = result
+				accountStatus.Results = append(accountStatus.Results, result)
+			}
+			result.Events = append(result.Events, event)
+		}
+
+		if len(accountStatus.Results) == 0 {
+			return nil, false
+		}
+		messageIndex++
+		return accountStatus, true
+	})
+}
+
+func (c *Client) SubscribeAccountStatusesFromStartBlockID(
+	ctx context.Context,
+	startBlockID flow.Identifier,
+	filter flow.AccountStatusFilter,
+) (<-chan *flow.AccountStatus, <-chan error, error) {
+	start, err := c.startHeight(startBlockID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.SubscribeAccountStatusesFromStartHeight(ctx, start, filter)
+}
+
+func (c *Client) SubscribeAccountStatusesFromLatestBlock(
+	ctx context.Context,
+	filter flow.AccountStatusFilter,
+) (<-chan *flow.AccountStatus, <-chan error, error) {
+	return c.SubscribeAccountStatusesFromStartHeight(ctx, c.latestHeight(executed), filter)
+}
+
+// accountEventTypes are the types of the events streamed by account status subscriptions.
+var accountEventTypes = map[string]bool{
+	flow.EventAccountCreated:         true,
+	flow.EventAccountKeyAdded:        true,
+	flow.EventAccountKeyRemoved:      true,
+	flow.EventAccountContractAdded:   true,
+	flow.EventAccountContractUpdated: true,
+	flow.EventAccountContractRemoved: true,
+}
+
+// accountEventAddress returns the address of the account of an account event.
+func accountEventAddress(event flow.Event) (flow.Address, bool) {
+	if !accountEventTypes[event.Type] || event.Value.EventType == nil {
+		return flow.EmptyAddress, false
+	}
+
+	address, ok := cadence.SearchFieldByName(event.Value, "address").(cadence.Address)
+	if !ok {
+		return flow.EmptyAddress, false
+	}
+	return flow.BytesToAddress(address.Bytes()), true
+}
+
+// SendAndSubscribeTransactionStatuses sends a transaction, and streams its result every time its status changes,
+// until it is sealed or expired.
+func (c *Client) SendAndSubscribeTransactionStatuses(
+	ctx context.Context,
+	tx flow.Transaction,
+) (<-chan *flow.TransactionResult, <-chan error, error) {
+	if err := c.Ping(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	c.mu.Lock()
+	sent, err := c.sendTransaction(tx)
+	c.mu.Unlock()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results := make(chan *flow.TransactionResult)
+	errs := make(chan error)
+
+	go func() {
+		defer close(results)
+		defer close(errs)
+
+		last := flow.TransactionStatusUnknown
+		for {
+			c.mu.Lock()
+			result := c.transactionResult(sent)
+			changed := c.changed
+			c.mu.Unlock()
+
+			// statuses reached by committing several blocks at once are sent in order
+			for _, next := range statusesSince(last, result.Status) {
+				update := *result
+				update.Status = next
+				if next < flow.TransactionStatusExecuted {
+					update.Events = nil
+					update.Error = nil
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-c.done:
+					return
+				case results <- &update:
+				}
+				last = next
+			}
+
+			if last == flow.TransactionStatusSealed || last == flow.TransactionStatusExpired {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-c.done:
+				return
+			case <-changed:
+			}
+		}
+	}()
+
+	return results, errs, nil
+}
+
+// statusesSince returns the statuses of the transaction lifecycle after a status, up to a current status.
+func statusesSince(last flow.TransactionStatus, current flow.TransactionStatus) []flow.TransactionStatus {
+	if current == flow.TransactionStatusExpired {
+		return []flow.TransactionStatus{current}
+	}
+
+	var statuses []flow.TransactionStatus
+	for status := last + 1; status <= current; status++ {
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
@@ -29,6 +29,7 @@ import (
 	"context"
 
 	"github.com/onflow/flow-go-sdk/access"
+	"github.com/onflow/flow-go-sdk/access/logging"
 
 	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"google.golang.org/grpc"
@@ -51,6 +52,8 @@ type options struct {
 	dialOptions   []grpc.DialOption
 	jsonOptions   []jsoncdc.Option
 	eventEncoding flow.EventEncodingVersion
+	telemetry     *telemetryOptions
+	logger        *logging.Logger
 }
 
 func DefaultClientOptions() *options {
@@ -94,7 +97,19 @@ func NewClient(host string, opts ...ClientOption) (*Client, error) {
 		apply(cfg)
 	}
 
-	client, err := NewBaseClient(host, cfg.dialOptions...)
+	dialOptions := cfg.dialOptions
+	if cfg.telemetry != nil {
+		telemetryOptions, err := telemetryDialOptions(cfg.telemetry)
+		if err != nil {
+			return nil, err
+		}
+		dialOptions = append(dialOptions, telemetryOptions...)
+	}
+	if cfg.logger != nil {
+		dialOptions = append(dialOptions, loggingDialOptions(cfg.logger)...)
+	}
+
+	client, err := NewBaseClient(host, dialOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -114,6 +114,32 @@ func MessageToAccountStatusResults(m []*executiondata.SubscribeAccountStatusesRe
 	return results, nil
 }
 
+func AccountStatusToMessage(a flow.AccountStatus, encodingVersion flow.EventEncodingVersion) (*executiondata.SubscribeAccountStatusesResponse, error) {
+	results := make([]*executiondata.SubscribeAccountStatusesResponse_Result, len(a.Results))
+	for i, r := range a.Results {
+		events := make([]*entities.Event, len(r.Events))
+		for j, event := range r.Events {
+			eventMsg, err := EventToMessage(event, encodingVersion)
+			if err != nil {
+				return nil, fmt.Errorf("error converting events: %w", err)
+			}
+			events[j] = eventMsg
+		}
+
+		results[i] = &executiondata.SubscribeAccountStatusesResponse_Result{
+			Address: r.Address.Bytes(),
+			Events:  events,
+		}
+	}
+
+	return &executiondata.SubscribeAccountStatusesResponse{
+		BlockId:      IdentifierToMessage(a.BlockID),
+		BlockHeight:  a.BlockHeight,
+		MessageIndex: a.MessageIndex,
+		Results:      results,
+	}, nil
+}
+
 func AccountKeyToMessage(a *flow.AccountKey) *entities.AccountKey {
 	return &entities.AccountKey{
 		Index:          uint32(a.Index),
@@ -934,7 +960,7 @@ func MessageToTransactionResult(m *access.TransactionResultResponse, options []j
 	if statusCode != 0 {
 		errorMsg := m.GetErrorMessage()
 		if errorMsg != "" {
-			err = errors.New(errorMsg)
+			err = flow.ParseExecutionErrorMessage(errorMsg)
 		} else {
 			err = errors.New("transaction execution failed")
 		}
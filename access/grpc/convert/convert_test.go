@@ -52,6 +52,35 @@ func TestConvert_AccountKey(t *testing.T) {
 	assert.Equal(t, keyA, keyB)
 }
 
+func TestConvert_AccountStatus(t *testing.T) {
+	events := test.EventGenerator(flow.EventEncodingVersionJSONCDC)
+	statusA := flow.AccountStatus{
+		BlockID:      test.IdentifierGenerator().New(),
+		BlockHeight:  42,
+		MessageIndex: 3,
+		Results: []*flow.AccountStatusResult{
+			{
+				Address: test.AddressGenerator().New(),
+				Events:  []flow.Event{events.New(), events.New()},
+			},
+		},
+	}
+
+	msg, err := AccountStatusToMessage(statusA, flow.EventEncodingVersionJSONCDC)
+	require.NoError(t, err)
+
+	statusB, err := MessageToAccountStatus(msg)
+	require.NoError(t, err)
+
+	// Force evaluation of type IDs, which are cached in types.
+	// Necessary for equality check below
+	for _, event := range statusB.Results[0].Events {
+		_ = event.Value.Type().ID()
+	}
+
+	assert.Equal(t, statusA, statusB)
+}
+
 func TestConvert_Block(t *testing.T) {
 	blockA := test.BlockGenerator().New()
 
@@ -292,7 +321,7 @@ func TestConvert_Transaction(t *testing.T) {
 
 func TestConvert_TransactionResult(t *testing.T) {
 	t.Run("with JSON-CDC encoded events", func(t *testing.T) {
-		resultA := test.TransactionResultGenerator(flow.EventEncodingVersionJSONCDC).New()
+		resultA := test.TransactionResultGenerator(flow.EventEncodingVersionJSONCDC).NewWithExecutionError()
 
 		msg, err := TransactionResultToMessage(resultA, flow.EventEncodingVersionJSONCDC)
 
@@ -309,7 +338,7 @@ func TestConvert_TransactionResult(t *testing.T) {
 	})
 
 	t.Run("with CCF encoded events", func(t *testing.T) {
-		resultA := test.TransactionResultGenerator(flow.EventEncodingVersionCCF).New()
+		resultA := test.TransactionResultGenerator(flow.EventEncodingVersionCCF).NewWithExecutionError()
 
 		msg, err := TransactionResultToMessage(resultA, flow.EventEncodingVersionCCF)
 
@@ -600,7 +600,7 @@ func TestClient_GetSystemTransactionResult(t *testing.T) {
 	t.Run("Success", clientTest(func(t *testing.T, ctx context.Context, rpc *mocks.MockRPCClient, c *BaseClient) {
 		results := test.TransactionResultGenerator(flow.EventEncodingVersionCCF)
 		blockID := ids.New()
-		expectedResult := results.New()
+		expectedResult := results.NewWithExecutionError()
 		response, _ := convert.TransactionResultToMessage(expectedResult, flow.EventEncodingVersionCCF)
 
 		rpc.On("GetSystemTransactionResult", ctx, mock.Anything).Return(response, nil)
@@ -620,7 +620,7 @@ func TestClient_GetSystemTransactionResult(t *testing.T) {
 	t.Run("Success with jsoncdc", clientTest(func(t *testing.T, ctx context.Context, rpc *mocks.MockRPCClient, c *BaseClient) {
 		results := test.TransactionResultGenerator(flow.EventEncodingVersionJSONCDC)
 		blockID := ids.New()
-		expectedResult := results.New()
+		expectedResult := results.NewWithExecutionError()
 		response, _ := convert.TransactionResultToMessage(expectedResult, flow.EventEncodingVersionJSONCDC)
 
 		rpc.On("GetSystemTransactionResult", ctx, mock.Anything).Return(response, nil)
@@ -718,7 +718,7 @@ func TestClient_GetSystemTransactionResult_WithID_SetsId(t *testing.T) {
 
 		// Build a minimal result
 		results := test.TransactionResultGenerator(flow.EventEncodingVersionCCF)
-		expectedResult := results.New()
+		expectedResult := results.NewWithExecutionError()
 		response, _ := convert.TransactionResultToMessage(expectedResult, flow.EventEncodingVersionCCF)
 
 		rpc.
@@ -750,7 +750,7 @@ func TestClient_GetSystemTransactionResult_WithEmptyID_OmitsId(t *testing.T) {
 		blockID := ids.New()
 
 		results := test.TransactionResultGenerator(flow.EventEncodingVersionCCF)
-		expectedResult := results.New()
+		expectedResult := results.NewWithExecutionError()
 		response, _ := convert.TransactionResultToMessage(expectedResult, flow.EventEncodingVersionCCF)
 
 		rpc.
@@ -780,7 +780,7 @@ func TestClient_GetTransactionResult(t *testing.T) {
 	t.Run("Success", clientTest(func(t *testing.T, ctx context.Context, rpc *mocks.MockRPCClient, c *BaseClient) {
 		results := test.TransactionResultGenerator(flow.EventEncodingVersionCCF)
 		txID := ids.New()
-		expectedResult := results.New()
+		expectedResult := results.NewWithExecutionError()
 		response, _ := convert.TransactionResultToMessage(expectedResult, flow.EventEncodingVersionCCF)
 
 		rpc.On("GetTransactionResult", ctx, mock.Anything).Return(response, nil)
@@ -801,7 +801,7 @@ func TestClient_GetTransactionResult(t *testing.T) {
 	t.Run("Success with jsoncdc", clientTest(func(t *testing.T, ctx context.Context, rpc *mocks.MockRPCClient, c *BaseClient) {
 		results := test.TransactionResultGenerator(flow.EventEncodingVersionJSONCDC)
 		txID := ids.New()
-		expectedResult := results.New()
+		expectedResult := results.NewWithExecutionError()
 		response, _ := convert.TransactionResultToMessage(expectedResult, flow.EventEncodingVersionJSONCDC)
 
 		rpc.On("GetTransactionResult", ctx, mock.Anything).Return(response, nil)
@@ -838,7 +838,7 @@ func TestClient_GetTransactionResultsByBlockID(t *testing.T) {
 	t.Run("Success", clientTest(func(t *testing.T, ctx context.Context, rpc *mocks.MockRPCClient, c *BaseClient) {
 		resultGenerator := test.TransactionResultGenerator(flow.EventEncodingVersionCCF)
 		blockID := ids.New()
-		expectedResult := resultGenerator.New()
+		expectedResult := resultGenerator.NewWithExecutionError()
 		response, err := convert.TransactionResultToMessage(expectedResult, flow.EventEncodingVersionCCF)
 		require.NoError(t, err)
 
@@ -866,7 +866,7 @@ func TestClient_GetTransactionResultsByBlockID(t *testing.T) {
 	t.Run("Success with jsoncdc", clientTest(func(t *testing.T, ctx context.Context, rpc *mocks.MockRPCClient, c *BaseClient) {
 		resultGenerator := test.TransactionResultGenerator(flow.EventEncodingVersionJSONCDC)
 		blockID := ids.New()
-		expectedResult := resultGenerator.New()
+		expectedResult := resultGenerator.NewWithExecutionError()
 		response, err := convert.TransactionResultToMessage(expectedResult, flow.EventEncodingVersionJSONCDC)
 		require.NoError(t, err)
 
@@ -943,7 +943,7 @@ func TestClient_GetScheduledTransactionResult(t *testing.T) {
 	t.Run("Success", clientTest(func(t *testing.T, ctx context.Context, rpc *mocks.MockRPCClient, c *BaseClient) {
 		results := test.TransactionResultGenerator(flow.EventEncodingVersionCCF)
 		var scheduledTxID uint64 = 42
-		expectedResult := results.New()
+		expectedResult := results.NewWithExecutionError()
 		response, err := convert.TransactionResultToMessage(expectedResult, flow.EventEncodingVersionCCF)
 		require.NoError(t, err)
 
@@ -958,7 +958,7 @@ func TestClient_GetScheduledTransactionResult(t *testing.T) {
 	t.Run("Success with jsoncdc", clientTest(func(t *testing.T, ctx context.Context, rpc *mocks.MockRPCClient, c *BaseClient) {
 		results := test.TransactionResultGenerator(flow.EventEncodingVersionJSONCDC)
 		var scheduledTxID uint64 = 42
-		expectedResult := results.New()
+		expectedResult := results.NewWithExecutionError()
 		response, err := convert.TransactionResultToMessage(expectedResult, flow.EventEncodingVersionJSONCDC)
 		require.NoError(t, err)
 
@@ -0,0 +1,277 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package grpctest provides an in-process gRPC Access API server, for tests exercising the gRPC client and the
+// network layer without an access node.
+//
+// The server implements the Access API and the Execution Data API with the data of a source, any access.Client:
+//   - a fake.Client, for a scripted timeline of blocks and transactions,
+//   - a mocks.Client, returning fixtures of the test generators,
+//   - a record replayer, serving recorded responses.
+//
+// The server listens on an in-memory bufconn listener, or on a local port with WithAddress, and faults can be
+// injected per method:
+//
+//	server, err := grpctest.NewServer(fake.NewClient())
+//	defer server.Stop()
+//
+//	server.SetFault("GetAccountAtLatestBlock", grpctest.Fault{Err: status.Error(codes.Unavailable, "unavailable")})
+//	server.SetFault("SubscribeEvents", grpctest.Fault{Latency: 100 * time.Millisecond})
+//
+//	client, err := server.NewClient()
+package grpctest
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/executiondata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	base "github.com/onflow/flow-go-sdk/access"
+	accessgrpc "github.com/onflow/flow-go-sdk/access/grpc"
+)
+
+// AllMethods is the method name of faults applying to all methods without their own fault.
+const AllMethods = "*"
+
+// bufconnAddress is the address of servers listening on bufconn.
+const bufconnAddress = "passthrough:///bufnet"
+
+// A Fault is injected in the calls of a method.
+type Fault struct {
+	// Err is the error returned by calls, or ending streams after AfterMessages messages.
+	// It should be a gRPC status error.
+	Err error
+	// Latency delays calls, and every message of streams.
+	Latency time.Duration
+	// AfterMessages is the number of messages sent by streams before they end with Err.
+	AfterMessages int
+}
+
+// An Option configures a Server.
+type Option func(*config)
+
+type config struct {
+	address       string
+	serverOptions []grpc.ServerOption
+}
+
+// WithAddress listens on a TCP address, e.g. "127.0.0.1:0" for a free local port, instead of bufconn.
+func WithAddress(address string) Option {
+	return func(c *config) {
+		c.address = address
+	}
+}
+
+// WithServerOptions adds options to the gRPC server.
+func WithServerOptions(opts ...grpc.ServerOption) Option {
+	return func(c *config) {
+		c.serverOptions = append(c.serverOptions, opts...)
+	}
+}
+
+// Server is a running gRPC server of the Access API and the Execution Data API.
+type Server struct {
+	server   *grpc.Server
+	listener net.Listener
+	bufconn  *bufconn.Listener
+
+	mu     sync.Mutex
+	faults map[string]Fault
+}
+
+// NewServer starts a server serving the data of the source.
+func NewServer(source base.Client, opts ...Option) (*Server, error) {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	s := &Server{faults: make(map[string]Fault)}
+
+	if cfg.address == "" {
+		s.bufconn = bufconn.Listen(1 << 20)
+		s.listener = s.bufconn
+	} else {
+		listener, err := net.Listen("tcp", cfg.address)
+		if err != nil {
+			return nil, err
+		}
+		s.listener = listener
+	}
+
+	serverOptions := append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(s.unaryFaultInterceptor),
+		grpc.ChainStreamInterceptor(s.streamFaultInterceptor),
+	}, cfg.serverOptions...)
+	s.server = grpc.NewServer(serverOptions...)
+
+	service := NewService(source)
+	access.RegisterAccessAPIServer(s.server, service)
+	executiondata.RegisterExecutionDataAPIServer(s.server, service)
+
+	go func() { _ = s.server.Serve(s.listener) }()
+
+	return s, nil
+}
+
+// Address returns the address to dial the server.
+func (s *Server) Address() string {
+	if s.bufconn != nil {
+		return bufconnAddress
+	}
+	return s.listener.Addr().String()
+}
+
+// DialOptions returns the options to dial the server with grpc.NewClient.
+func (s *Server) DialOptions() []grpc.DialOption {
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if s.bufconn != nil {
+		opts = append(opts, grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return s.bufconn.DialContext(ctx)
+		}))
+	}
+	return opts
+}
+
+// NewClient returns an access client connected to the server.
+func (s *Server) NewClient(opts ...accessgrpc.ClientOption) (*accessgrpc.Client, error) {
+	opts = append([]accessgrpc.ClientOption{accessgrpc.WithGRPCDialOptions(s.DialOptions()...)}, opts...)
+	return accessgrpc.NewClient(s.Address(), opts...)
+}
+
+// SetFault injects the fault in the calls of the method, e.g. "GetBlockByHeight", or of AllMethods.
+// Calls in progress are not affected.
+func (s *Server) SetFault(method string, fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults[method] = fault
+}
+
+// ClearFaults removes all faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = make(map[string]Fault)
+}
+
+// Stop stops the server, closing all connections and streams.
+func (s *Server) Stop() {
+	s.server.Stop()
+}
+
+// fault returns the fault of a full gRPC method name, e.g. "/flow.access.AccessAPI/GetBlockByHeight".
+func (s *Server) fault(fullMethod string) (Fault, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	if fault, ok := s.faults[method]; ok {
+		return fault, true
+	}
+	fault, ok := s.faults[AllMethods]
+	return fault, ok
+}
+
+func (s *Server) unaryFaultInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	fault, ok := s.fault(info.FullMethod)
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	if err := wait(ctx, fault.Latency); err != nil {
+		return nil, err
+	}
+	if fault.Err != nil {
+		return nil, fault.Err
+	}
+	return handler(ctx, req)
+}
+
+func (s *Server) streamFaultInterceptor(
+	srv any,
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	fault, ok := s.fault(info.FullMethod)
+	if !ok {
+		return handler(srv, stream)
+	}
+
+	if err := wait(stream.Context(), fault.Latency); err != nil {
+		return err
+	}
+	if fault.Err != nil && fault.AfterMessages == 0 {
+		return fault.Err
+	}
+	return handler(srv, &faultStream{ServerStream: stream, fault: fault})
+}
+
+// faultStream delays the messages of a stream, and fails the stream after the messages of the fault.
+type faultStream struct {
+	grpc.ServerStream
+	fault Fault
+	sent  int
+}
+
+func (s *faultStream) SendMsg(m any) error {
+	if s.sent > 0 {
+		if err := wait(s.Context(), s.fault.Latency); err != nil {
+			return err
+		}
+	}
+	if s.fault.Err != nil && s.sent >= s.fault.AfterMessages {
+		return s.fault.Err
+	}
+
+	s.sent++
+	return s.ServerStream.SendMsg(m)
+}
+
+// wait waits for the latency, or until the context is done.
+func wait(ctx context.Context, latency time.Duration) error {
+	if latency <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(latency)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	case <-timer.C:
+		return nil
+	}
+}
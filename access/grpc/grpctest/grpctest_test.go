@@ -0,0 +1,255 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpctest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+	base "github.com/onflow/flow-go-sdk/access"
+	"github.com/onflow/flow-go-sdk/access/fake"
+	accessgrpc "github.com/onflow/flow-go-sdk/access/grpc"
+	"github.com/onflow/flow-go-sdk/access/grpc/grpctest"
+	"github.com/onflow/flow-go-sdk/access/mocks"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func newServer(t *testing.T, source base.Client, opts ...grpctest.Option) (*grpctest.Server, *accessgrpc.Client) {
+	server, err := grpctest.NewServer(source, opts...)
+	require.NoError(t, err)
+	t.Cleanup(server.Stop)
+
+	client, err := server.NewClient()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	return server, client
+}
+
+func TestServer_Fake(t *testing.T) {
+	ctx := context.Background()
+
+	events := test.EventGenerator(flow.EventEncodingVersionJSONCDC)
+	chain := fake.NewClient(fake.WithTransactionHandler(func(tx flow.Transaction) ([]flow.Event, error) {
+		return []flow.Event{events.New()}, nil
+	}))
+	defer chain.Close()
+
+	_, client := newServer(t, chain)
+
+	accountKey, signer := test.AccountKeyGenerator().NewWithSigner()
+	address := chain.CreateAccount(accountKey)
+
+	header, err := client.GetLatestBlockHeader(ctx, true)
+	require.NoError(t, err)
+	assert.Equal(t, flow.BlockStatusSealed, header.Status)
+
+	tx := flow.NewTransaction().
+		SetScript([]byte("transaction { prepare(signer: &Account) {} }")).
+		SetReferenceBlockID(header.ID).
+		SetProposalKey(address, 0, accountKey.SequenceNumber).
+		SetPayer(address).
+		AddAuthorizer(address)
+	require.NoError(t, tx.SignEnvelope(address, 0, signer))
+
+	t.Run("Transaction statuses", func(t *testing.T) {
+		results, errs, err := client.SendAndSubscribeTransactionStatuses(ctx, *tx)
+		require.NoError(t, err)
+
+		var statuses []flow.TransactionStatus
+		for len(statuses) < 4 {
+			select {
+			case result := <-results:
+				statuses = append(statuses, result.Status)
+				chain.CommitBlock()
+			case err := <-errs:
+				require.NoError(t, err)
+			}
+		}
+		assert.Equal(t, []flow.TransactionStatus{
+			flow.TransactionStatusPending,
+			flow.TransactionStatusFinalized,
+			flow.TransactionStatusExecuted,
+			flow.TransactionStatusSealed,
+		}, statuses)
+
+		result, err := client.GetTransactionResult(ctx, tx.ID())
+		require.NoError(t, err)
+		require.Len(t, result.Events, 1)
+		assert.Equal(t, tx.ID(), result.Events[0].TransactionID)
+	})
+
+	t.Run("Events with heartbeats", func(t *testing.T) {
+		subCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		blocks, errs, err := client.SubscribeEventsByBlockHeight(subCtx, 0, flow.EventFilter{},
+			base.WithHeartbeatInterval(1))
+		require.NoError(t, err)
+
+		var found int
+		for height := uint64(0); height < 3; height++ {
+			select {
+			case block := <-blocks:
+				assert.Equal(t, height, block.Height)
+				found += len(block.Events)
+			case err := <-errs:
+				require.NoError(t, err)
+			}
+		}
+		assert.Equal(t, 1, found)
+	})
+
+	t.Run("Account statuses", func(t *testing.T) {
+		subCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		address := flow.HexToAddress("01")
+		eventType := cadence.NewEventType(nil, flow.EventAccountCreated, []cadence.Field{
+			{Identifier: "address", Type: cadence.AddressType},
+		}, nil)
+		created := cadence.NewEvent([]cadence.Value{cadence.NewAddress(address)}).WithType(eventType)
+
+		chain.EmitEvents(flow.Event{Type: flow.EventAccountCreated, Value: created})
+		block := chain.CommitBlock()
+		chain.CommitBlock()
+
+		statuses, errs, err := client.SubscribeAccountStatusesFromStartHeight(subCtx, block.Height, flow.AccountStatusFilter{
+			EventFilter: flow.EventFilter{Addresses: []string{address.String()}},
+		})
+		require.NoError(t, err)
+
+		select {
+		case accountStatus := <-statuses:
+			assert.Equal(t, uint64(0), accountStatus.MessageIndex)
+			require.Len(t, accountStatus.Results, 1)
+			assert.Equal(t, address, accountStatus.Results[0].Address)
+		case err := <-errs:
+			require.NoError(t, err)
+		}
+	})
+}
+
+func TestServer_Fixtures(t *testing.T) {
+	ctx := context.Background()
+
+	source := mocks.NewClient(t)
+	_, client := newServer(t, source)
+
+	block := test.BlockGenerator().New()
+	// the generated strings are random bytes, which are not valid UTF-8 on the wire
+	block.ChainID = flow.EmptyID
+	for _, result := range block.ExecutionResultsList {
+		for _, event := range result.ServiceEvents {
+			event.Type = "flow.EpochSetup"
+		}
+	}
+	source.On("GetBlockByHeight", mock.Anything, block.Height).Return(block, nil)
+	source.On("GetBlockByHeight", mock.Anything, mock.Anything).
+		Return(nil, status.Error(codes.NotFound, "block not found"))
+
+	received, err := client.GetBlockByHeight(ctx, block.Height)
+	require.NoError(t, err)
+	assert.Equal(t, block.ID, received.ID)
+	assert.Equal(t, block.CollectionGuarantees, received.CollectionGuarantees)
+
+	_, err = client.GetBlockByHeight(ctx, block.Height+1)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServer_Faults(t *testing.T) {
+	ctx := context.Background()
+
+	chain := fake.NewClient()
+	defer chain.Close()
+	chain.CommitBlocks(5)
+
+	server, client := newServer(t, chain)
+
+	t.Run("Error", func(t *testing.T) {
+		defer server.ClearFaults()
+		server.SetFault("GetBlockByHeight", grpctest.Fault{Err: status.Error(codes.Unavailable, "unavailable")})
+
+		_, err := client.GetBlockByHeight(ctx, 0)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+
+		_, err = client.GetBlockHeaderByHeight(ctx, 0)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Latency", func(t *testing.T) {
+		defer server.ClearFaults()
+		server.SetFault(grpctest.AllMethods, grpctest.Fault{Latency: 50 * time.Millisecond})
+
+		start := time.Now()
+		require.NoError(t, client.Ping(ctx))
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		err := client.Ping(timeoutCtx)
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	})
+
+	t.Run("Stream error", func(t *testing.T) {
+		defer server.ClearFaults()
+		server.SetFault("SubscribeBlockHeadersFromStartHeight", grpctest.Fault{
+			Err:           status.Error(codes.Unavailable, "shutting down"),
+			AfterMessages: 2,
+		})
+
+		headers, errs, err := client.SubscribeBlockHeadersFromStartHeight(ctx, 0, flow.BlockStatusSealed)
+		require.NoError(t, err)
+
+		for height := uint64(0); height < 2; height++ {
+			header := <-headers
+			assert.Equal(t, height, header.Height)
+		}
+		err = <-errs
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
+
+func TestServer_WithAddress(t *testing.T) {
+	chain := fake.NewClient()
+	defer chain.Close()
+
+	server, err := grpctest.NewServer(chain, grpctest.WithAddress("127.0.0.1:0"))
+	require.NoError(t, err)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient(server.Address(), server.DialOptions()...)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	res, err := access.NewAccessAPIClient(conn).GetNetworkParameters(context.Background(), &access.GetNetworkParametersRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, string(flow.Emulator), res.GetChainId())
+}
@@ -0,0 +1,632 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpctest
+
+import (
+	"context"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/onflow/flow/protobuf/go/flow/executiondata"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/onflow/flow-go-sdk"
+	base "github.com/onflow/flow-go-sdk/access"
+	"github.com/onflow/flow-go-sdk/access/grpc/convert"
+)
+
+// Service implements the Access API and the Execution Data API with the data of a source client.
+//
+// Results of the source are converted to the API messages, and errors of the source are returned as is, so sources
+// should return gRPC status errors.
+type Service struct {
+	access.UnimplementedAccessAPIServer
+	executiondata.UnimplementedExecutionDataAPIServer
+
+	source base.Client
+}
+
+var _ access.AccessAPIServer = (*Service)(nil)
+var _ executiondata.ExecutionDataAPIServer = (*Service)(nil)
+
+// NewService returns a service serving the data of the source.
+func NewService(source base.Client) *Service {
+	return &Service{source: source}
+}
+
+func conversionError(entity string, err error) error {
+	return status.Errorf(codes.Internal, "failed to convert %s: %v", entity, err)
+}
+
+func requestError(entity string, err error) error {
+	return status.Errorf(codes.InvalidArgument, "invalid %s: %v", entity, err)
+}
+
+func (s *Service) Ping(ctx context.Context, _ *access.PingRequest) (*access.PingResponse, error) {
+	if err := s.source.Ping(ctx); err != nil {
+		return nil, err
+	}
+	return &access.PingResponse{}, nil
+}
+
+func (s *Service) GetNodeVersionInfo(
+	ctx context.Context,
+	_ *access.GetNodeVersionInfoRequest,
+) (*access.GetNodeVersionInfoResponse, error) {
+	info, err := s.source.GetNodeVersionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &entities.NodeVersionInfo{
+		Semver:               info.Semver,
+		Commit:               info.Commit,
+		SporkId:              convert.IdentifierToMessage(info.SporkId),
+		ProtocolVersion:      info.ProtocolVersion,
+		SporkRootBlockHeight: info.SporkRootBlockHeight,
+		NodeRootBlockHeight:  info.NodeRootBlockHeight,
+	}
+	if info.CompatibleRange != nil {
+		m.CompatibleRange = &entities.CompatibleRange{
+			StartHeight: info.CompatibleRange.StartHeight,
+			EndHeight:   info.CompatibleRange.EndHeight,
+		}
+	}
+	return &access.GetNodeVersionInfoResponse{Info: m}, nil
+}
+
+func (s *Service) GetNetworkParameters(
+	ctx context.Context,
+	_ *access.GetNetworkParametersRequest,
+) (*access.GetNetworkParametersResponse, error) {
+	params, err := s.source.GetNetworkParameters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &access.GetNetworkParametersResponse{ChainId: string(params.ChainID)}, nil
+}
+
+func blockHeaderResponse(header *flow.BlockHeader, err error) (*access.BlockHeaderResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := convert.BlockHeaderToMessage(*header)
+	if err != nil {
+		return nil, conversionError("block header", err)
+	}
+	return &access.BlockHeaderResponse{Block: m, BlockStatus: entities.BlockStatus(header.Status)}, nil
+}
+
+func (s *Service) GetLatestBlockHeader(
+	ctx context.Context,
+	req *access.GetLatestBlockHeaderRequest,
+) (*access.BlockHeaderResponse, error) {
+	return blockHeaderResponse(s.source.GetLatestBlockHeader(ctx, req.GetIsSealed()))
+}
+
+func (s *Service) GetBlockHeaderByID(
+	ctx context.Context,
+	req *access.GetBlockHeaderByIDRequest,
+) (*access.BlockHeaderResponse, error) {
+	return blockHeaderResponse(s.source.GetBlockHeaderByID(ctx, convert.MessageToIdentifier(req.GetId())))
+}
+
+func (s *Service) GetBlockHeaderByHeight(
+	ctx context.Context,
+	req *access.GetBlockHeaderByHeightRequest,
+) (*access.BlockHeaderResponse, error) {
+	return blockHeaderResponse(s.source.GetBlockHeaderByHeight(ctx, req.GetHeight()))
+}
+
+func blockResponse(block *flow.Block, err error) (*access.BlockResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := convert.BlockToMessage(*block)
+	if err != nil {
+		return nil, conversionError("block", err)
+	}
+	return &access.BlockResponse{Block: m, BlockStatus: entities.BlockStatus(block.Status)}, nil
+}
+
+func (s *Service) GetLatestBlock(ctx context.Context, req *access.GetLatestBlockRequest) (*access.BlockResponse, error) {
+	return blockResponse(s.source.GetLatestBlock(ctx, req.GetIsSealed()))
+}
+
+func (s *Service) GetBlockByID(ctx context.Context, req *access.GetBlockByIDRequest) (*access.BlockResponse, error) {
+	return blockResponse(s.source.GetBlockByID(ctx, convert.MessageToIdentifier(req.GetId())))
+}
+
+func (s *Service) GetBlockByHeight(
+	ctx context.Context,
+	req *access.GetBlockByHeightRequest,
+) (*access.BlockResponse, error) {
+	return blockResponse(s.source.GetBlockByHeight(ctx, req.GetHeight()))
+}
+
+func (s *Service) GetCollectionByID(
+	ctx context.Context,
+	req *access.GetCollectionByIDRequest,
+) (*access.CollectionResponse, error) {
+	collection, err := s.source.GetCollectionByID(ctx, convert.MessageToIdentifier(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	return &access.CollectionResponse{Collection: convert.CollectionToMessage(*collection)}, nil
+}
+
+func (s *Service) GetFullCollectionByID(
+	ctx context.Context,
+	req *access.GetFullCollectionByIDRequest,
+) (*access.FullCollectionResponse, error) {
+	collection, err := s.source.GetFullCollectionByID(ctx, convert.MessageToIdentifier(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := convert.FullCollectionToTransactionsMessage(*collection)
+	if err != nil {
+		return nil, conversionError("collection", err)
+	}
+	return &access.FullCollectionResponse{Transactions: transactions}, nil
+}
+
+func (s *Service) SendTransaction(
+	ctx context.Context,
+	req *access.SendTransactionRequest,
+) (*access.SendTransactionResponse, error) {
+	tx, err := convert.MessageToTransaction(req.GetTransaction())
+	if err != nil {
+		return nil, requestError("transaction", err)
+	}
+
+	if err := s.source.SendTransaction(ctx, tx); err != nil {
+		return nil, err
+	}
+	return &access.SendTransactionResponse{Id: convert.IdentifierToMessage(tx.ID())}, nil
+}
+
+func transactionResponse(tx *flow.Transaction, err error) (*access.TransactionResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := convert.TransactionToMessage(*tx)
+	if err != nil {
+		return nil, conversionError("transaction", err)
+	}
+	return &access.TransactionResponse{Transaction: m}, nil
+}
+
+func transactionResultResponse(
+	result *flow.TransactionResult,
+	encoding flow.EventEncodingVersion,
+	err error,
+) (*access.TransactionResultResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := convert.TransactionResultToMessage(*result, encoding)
+	if err != nil {
+		return nil, conversionError("transaction result", err)
+	}
+	return m, nil
+}
+
+func (s *Service) GetTransaction(
+	ctx context.Context,
+	req *access.GetTransactionRequest,
+) (*access.TransactionResponse, error) {
+	return transactionResponse(s.source.GetTransaction(ctx, convert.MessageToIdentifier(req.GetId())))
+}
+
+func (s *Service) GetTransactionResult(
+	ctx context.Context,
+	req *access.GetTransactionRequest,
+) (*access.TransactionResultResponse, error) {
+	result, err := s.source.GetTransactionResult(ctx, convert.MessageToIdentifier(req.GetId()))
+	return transactionResultResponse(result, req.GetEventEncodingVersion(), err)
+}
+
+func (s *Service) GetTransactionResultByIndex(
+	ctx context.Context,
+	req *access.GetTransactionByIndexRequest,
+) (*access.TransactionResultResponse, error) {
+	blockID := convert.MessageToIdentifier(req.GetBlockId())
+	result, err := s.source.GetTransactionResultByIndex(ctx, blockID, req.GetIndex())
+	return transactionResultResponse(result, req.GetEventEncodingVersion(), err)
+}
+
+func (s *Service) GetTransactionResultsByBlockID(
+	ctx context.Context,
+	req *access.GetTransactionsByBlockIDRequest,
+) (*access.TransactionResultsResponse, error) {
+	results, err := s.source.GetTransactionResultsByBlockID(ctx, convert.MessageToIdentifier(req.GetBlockId()))
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*access.TransactionResultResponse, len(results))
+	for i, result := range results {
+		messages[i], err = transactionResultResponse(result, req.GetEventEncodingVersion(), nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &access.TransactionResultsResponse{TransactionResults: messages}, nil
+}
+
+func (s *Service) GetTransactionsByBlockID(
+	ctx context.Context,
+	req *access.GetTransactionsByBlockIDRequest,
+) (*access.TransactionsResponse, error) {
+	txs, err := s.source.GetTransactionsByBlockID(ctx, convert.MessageToIdentifier(req.GetBlockId()))
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*entities.Transaction, len(txs))
+	for i, tx := range txs {
+		messages[i], err = convert.TransactionToMessage(*tx)
+		if err != nil {
+			return nil, conversionError("transaction", err)
+		}
+	}
+	return &access.TransactionsResponse{Transactions: messages}, nil
+}
+
+func (s *Service) GetSystemTransaction(
+	ctx context.Context,
+	req *access.GetSystemTransactionRequest,
+) (*access.TransactionResponse, error) {
+	blockID := convert.MessageToIdentifier(req.GetBlockId())
+	if len(req.GetId()) == 0 {
+		return transactionResponse(s.source.GetSystemTransaction(ctx, blockID))
+	}
+	return transactionResponse(s.source.GetSystemTransactionWithID(ctx, blockID, convert.MessageToIdentifier(req.GetId())))
+}
+
+func (s *Service) GetSystemTransactionResult(
+	ctx context.Context,
+	req *access.GetSystemTransactionResultRequest,
+) (*access.TransactionResultResponse, error) {
+	blockID := convert.MessageToIdentifier(req.GetBlockId())
+
+	var result *flow.TransactionResult
+	var err error
+	if len(req.GetId()) == 0 {
+		result, err = s.source.GetSystemTransactionResult(ctx, blockID)
+	} else {
+		result, err = s.source.GetSystemTransactionResultWithID(ctx, blockID, convert.MessageToIdentifier(req.GetId()))
+	}
+	return transactionResultResponse(result, req.GetEventEncodingVersion(), err)
+}
+
+func (s *Service) GetScheduledTransaction(
+	ctx context.Context,
+	req *access.GetScheduledTransactionRequest,
+) (*access.TransactionResponse, error) {
+	return transactionResponse(s.source.GetScheduledTransaction(ctx, req.GetId()))
+}
+
+func (s *Service) GetScheduledTransactionResult(
+	ctx context.Context,
+	req *access.GetScheduledTransactionResultRequest,
+) (*access.TransactionResultResponse, error) {
+	result, err := s.source.GetScheduledTransactionResult(ctx, req.GetId())
+	return transactionResultResponse(result, req.GetEventEncodingVersion(), err)
+}
+
+func (s *Service) GetAccount(ctx context.Context, req *access.GetAccountRequest) (*access.GetAccountResponse, error) {
+	account, err := s.source.GetAccount(ctx, flow.BytesToAddress(req.GetAddress()))
+	if err != nil {
+		return nil, err
+	}
+	return &access.GetAccountResponse{Account: convert.AccountToMessage(*account)}, nil
+}
+
+func accountResponse(account *flow.Account, err error) (*access.AccountResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &access.AccountResponse{Account: convert.AccountToMessage(*account)}, nil
+}
+
+func (s *Service) GetAccountAtLatestBlock(
+	ctx context.Context,
+	req *access.GetAccountAtLatestBlockRequest,
+) (*access.AccountResponse, error) {
+	return accountResponse(s.source.GetAccountAtLatestBlock(ctx, flow.BytesToAddress(req.GetAddress())))
+}
+
+func (s *Service) GetAccountAtBlockHeight(
+	ctx context.Context,
+	req *access.GetAccountAtBlockHeightRequest,
+) (*access.AccountResponse, error) {
+	address := flow.BytesToAddress(req.GetAddress())
+	return accountResponse(s.source.GetAccountAtBlockHeight(ctx, address, req.GetBlockHeight()))
+}
+
+func balanceResponse(balance uint64, err error) (*access.AccountBalanceResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &access.AccountBalanceResponse{Balance: balance}, nil
+}
+
+func (s *Service) GetAccountBalanceAtLatestBlock(
+	ctx context.Context,
+	req *access.GetAccountBalanceAtLatestBlockRequest,
+) (*access.AccountBalanceResponse, error) {
+	return balanceResponse(s.source.GetAccountBalanceAtLatestBlock(ctx, flow.BytesToAddress(req.GetAddress())))
+}
+
+func (s *Service) GetAccountBalanceAtBlockHeight(
+	ctx context.Context,
+	req *access.GetAccountBalanceAtBlockHeightRequest,
+) (*access.AccountBalanceResponse, error) {
+	address := flow.BytesToAddress(req.GetAddress())
+	return balanceResponse(s.source.GetAccountBalanceAtBlockHeight(ctx, address, req.GetBlockHeight()))
+}
+
+func accountKeyResponse(key *flow.AccountKey, err error) (*access.AccountKeyResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &access.AccountKeyResponse{AccountKey: convert.AccountKeyToMessage(key)}, nil
+}
+
+func (s *Service) GetAccountKeyAtLatestBlock(
+	ctx context.Context,
+	req *access.GetAccountKeyAtLatestBlockRequest,
+) (*access.AccountKeyResponse, error) {
+	address := flow.BytesToAddress(req.GetAddress())
+	return accountKeyResponse(s.source.GetAccountKeyAtLatestBlock(ctx, address, req.GetIndex()))
+}
+
+func (s *Service) GetAccountKeyAtBlockHeight(
+	ctx context.Context,
+	req *access.GetAccountKeyAtBlockHeightRequest,
+) (*access.AccountKeyResponse, error) {
+	address := flow.BytesToAddress(req.GetAddress())
+	return accountKeyResponse(s.source.GetAccountKeyAtBlockHeight(ctx, address, req.GetIndex(), req.GetBlockHeight()))
+}
+
+func accountKeysResponse(keys []*flow.AccountKey, err error) (*access.AccountKeysResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*entities.AccountKey, len(keys))
+	for i, key := range keys {
+		messages[i] = convert.AccountKeyToMessage(key)
+	}
+	return &access.AccountKeysResponse{AccountKeys: messages}, nil
+}
+
+func (s *Service) GetAccountKeysAtLatestBlock(
+	ctx context.Context,
+	req *access.GetAccountKeysAtLatestBlockRequest,
+) (*access.AccountKeysResponse, error) {
+	return accountKeysResponse(s.source.GetAccountKeysAtLatestBlock(ctx, flow.BytesToAddress(req.GetAddress())))
+}
+
+func (s *Service) GetAccountKeysAtBlockHeight(
+	ctx context.Context,
+	req *access.GetAccountKeysAtBlockHeightRequest,
+) (*access.AccountKeysResponse, error) {
+	address := flow.BytesToAddress(req.GetAddress())
+	return accountKeysResponse(s.source.GetAccountKeysAtBlockHeight(ctx, address, req.GetBlockHeight()))
+}
+
+func scriptArguments(arguments [][]byte) ([]cadence.Value, error) {
+	values := make([]cadence.Value, len(arguments))
+	for i, argument := range arguments {
+		value, err := convert.MessageToCadenceValue(argument, nil)
+		if err != nil {
+			return nil, requestError("script argument", err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func scriptResponse(value cadence.Value, err error) (*access.ExecuteScriptResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := convert.CadenceValueToMessage(value, flow.EventEncodingVersionJSONCDC)
+	if err != nil {
+		return nil, conversionError("script result", err)
+	}
+	return &access.ExecuteScriptResponse{Value: payload}, nil
+}
+
+func (s *Service) ExecuteScriptAtLatestBlock(
+	ctx context.Context,
+	req *access.ExecuteScriptAtLatestBlockRequest,
+) (*access.ExecuteScriptResponse, error) {
+	arguments, err := scriptArguments(req.GetArguments())
+	if err != nil {
+		return nil, err
+	}
+	return scriptResponse(s.source.ExecuteScriptAtLatestBlock(ctx, req.GetScript(), arguments))
+}
+
+func (s *Service) ExecuteScriptAtBlockID(
+	ctx context.Context,
+	req *access.ExecuteScriptAtBlockIDRequest,
+) (*access.ExecuteScriptResponse, error) {
+	arguments, err := scriptArguments(req.GetArguments())
+	if err != nil {
+		return nil, err
+	}
+
+	blockID := convert.MessageToIdentifier(req.GetBlockId())
+	return scriptResponse(s.source.ExecuteScriptAtBlockID(ctx, blockID, req.GetScript(), arguments))
+}
+
+func (s *Service) ExecuteScriptAtBlockHeight(
+	ctx context.Context,
+	req *access.ExecuteScriptAtBlockHeightRequest,
+) (*access.ExecuteScriptResponse, error) {
+	arguments, err := scriptArguments(req.GetArguments())
+	if err != nil {
+		return nil, err
+	}
+	return scriptResponse(s.source.ExecuteScriptAtBlockHeight(ctx, req.GetBlockHeight(), req.GetScript(), arguments))
+}
+
+func eventMessages(events []flow.Event, encoding flow.EventEncodingVersion) ([]*entities.Event, error) {
+	messages := make([]*entities.Event, len(events))
+	for i, event := range events {
+		m, err := convert.EventToMessage(event, encoding)
+		if err != nil {
+			return nil, conversionError("event", err)
+		}
+		messages[i] = m
+	}
+	return messages, nil
+}
+
+func eventsResponse(
+	blocks []flow.BlockEvents,
+	encoding flow.EventEncodingVersion,
+	err error,
+) (*access.EventsResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*access.EventsResponse_Result, len(blocks))
+	for i, block := range blocks {
+		events, err := eventMessages(block.Events, encoding)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = &access.EventsResponse_Result{
+			BlockId:        convert.IdentifierToMessage(block.BlockID),
+			BlockHeight:    block.Height,
+			Events:         events,
+			BlockTimestamp: timestamppb.New(block.BlockTimestamp),
+		}
+	}
+	return &access.EventsResponse{Results: results}, nil
+}
+
+func (s *Service) GetEventsForHeightRange(
+	ctx context.Context,
+	req *access.GetEventsForHeightRangeRequest,
+) (*access.EventsResponse, error) {
+	blocks, err := s.source.GetEventsForHeightRange(ctx, req.GetType(), req.GetStartHeight(), req.GetEndHeight())
+	return eventsResponse(blocks, req.GetEventEncodingVersion(), err)
+}
+
+func (s *Service) GetEventsForBlockIDs(
+	ctx context.Context,
+	req *access.GetEventsForBlockIDsRequest,
+) (*access.EventsResponse, error) {
+	blockIDs := convert.MessagesToIdentifiers(req.GetBlockIds())
+	blocks, err := s.source.GetEventsForBlockIDs(ctx, req.GetType(), blockIDs)
+	return eventsResponse(blocks, req.GetEventEncodingVersion(), err)
+}
+
+func snapshotResponse(snapshot []byte, err error) (*access.ProtocolStateSnapshotResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &access.ProtocolStateSnapshotResponse{SerializedSnapshot: snapshot}, nil
+}
+
+func (s *Service) GetLatestProtocolStateSnapshot(
+	ctx context.Context,
+	_ *access.GetLatestProtocolStateSnapshotRequest,
+) (*access.ProtocolStateSnapshotResponse, error) {
+	return snapshotResponse(s.source.GetLatestProtocolStateSnapshot(ctx))
+}
+
+func (s *Service) GetProtocolStateSnapshotByBlockID(
+	ctx context.Context,
+	req *access.GetProtocolStateSnapshotByBlockIDRequest,
+) (*access.ProtocolStateSnapshotResponse, error) {
+	blockID := convert.MessageToIdentifier(req.GetBlockId())
+	return snapshotResponse(s.source.GetProtocolStateSnapshotByBlockID(ctx, blockID))
+}
+
+func (s *Service) GetProtocolStateSnapshotByHeight(
+	ctx context.Context,
+	req *access.GetProtocolStateSnapshotByHeightRequest,
+) (*access.ProtocolStateSnapshotResponse, error) {
+	return snapshotResponse(s.source.GetProtocolStateSnapshotByHeight(ctx, req.GetBlockHeight()))
+}
+
+func (s *Service) GetExecutionResultForBlockID(
+	ctx context.Context,
+	req *access.GetExecutionResultForBlockIDRequest,
+) (*access.ExecutionResultForBlockIDResponse, error) {
+	result, err := s.source.GetExecutionResultForBlockID(ctx, convert.MessageToIdentifier(req.GetBlockId()))
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := convert.ExecutionResultToMessage(*result)
+	if err != nil {
+		return nil, conversionError("execution result", err)
+	}
+	return &access.ExecutionResultForBlockIDResponse{ExecutionResult: m}, nil
+}
+
+func (s *Service) GetExecutionResultByID(
+	ctx context.Context,
+	req *access.GetExecutionResultByIDRequest,
+) (*access.ExecutionResultByIDResponse, error) {
+	result, err := s.source.GetExecutionResultByID(ctx, convert.MessageToIdentifier(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := convert.ExecutionResultToMessage(*result)
+	if err != nil {
+		return nil, conversionError("execution result", err)
+	}
+	return &access.ExecutionResultByIDResponse{ExecutionResult: m}, nil
+}
+
+func (s *Service) GetExecutionDataByBlockID(
+	ctx context.Context,
+	req *executiondata.GetExecutionDataByBlockIDRequest,
+) (*executiondata.GetExecutionDataByBlockIDResponse, error) {
+	execData, err := s.source.GetExecutionDataByBlockID(ctx, convert.MessageToIdentifier(req.GetBlockId()))
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := convert.BlockExecutionDataToMessage(execData)
+	if err != nil {
+		return nil, conversionError("execution data", err)
+	}
+	return &executiondata.GetExecutionDataByBlockIDResponse{BlockExecutionData: m}, nil
+}
@@ -0,0 +1,418 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpctest
+
+import (
+	"context"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/onflow/flow/protobuf/go/flow/executiondata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/onflow/flow-go-sdk"
+	base "github.com/onflow/flow-go-sdk/access"
+	"github.com/onflow/flow-go-sdk/access/grpc/convert"
+)
+
+// stream subscribes to the source, and sends the converted messages of the subscription on the stream until the
+// subscription or the stream ends. Messages are converted with their index in the stream.
+func stream[T any, M any](
+	server grpc.ServerStream,
+	subscribe func(ctx context.Context) (<-chan T, <-chan error, error),
+	toMessage func(value T, index uint64) (M, error),
+	send func(M) error,
+) error {
+	ctx, cancel := context.WithCancel(server.Context())
+	defer cancel()
+
+	sub, errs, err := subscribe(ctx)
+	if err != nil {
+		return err
+	}
+
+	var index uint64
+	for sub != nil || errs != nil {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+
+		case value, ok := <-sub:
+			if !ok {
+				sub = nil
+				continue
+			}
+
+			m, err := toMessage(value, index)
+			if err != nil {
+				return err
+			}
+			if err := send(m); err != nil {
+				return err
+			}
+			index++
+
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// latestHeight returns the height of the latest sealed block of the source.
+func (s *Service) latestHeight(ctx context.Context) (uint64, error) {
+	header, err := s.source.GetLatestBlockHeader(ctx, true)
+	if err != nil {
+		return 0, err
+	}
+	return header.Height, nil
+}
+
+func blockMessage(block *flow.Block, _ uint64) (*access.SubscribeBlocksResponse, error) {
+	m, err := convert.BlockToMessage(*block)
+	if err != nil {
+		return nil, conversionError("block", err)
+	}
+	return &access.SubscribeBlocksResponse{Block: m}, nil
+}
+
+func (s *Service) SubscribeBlocksFromStartBlockID(
+	req *access.SubscribeBlocksFromStartBlockIDRequest,
+	server access.AccessAPI_SubscribeBlocksFromStartBlockIDServer,
+) error {
+	return stream(server, func(ctx context.Context) (<-chan *flow.Block, <-chan error, error) {
+		startBlockID := convert.MessageToIdentifier(req.GetStartBlockId())
+		return s.source.SubscribeBlocksFromStartBlockID(ctx, startBlockID, flow.BlockStatus(req.GetBlockStatus()))
+	}, blockMessage, server.Send)
+}
+
+func (s *Service) SubscribeBlocksFromStartHeight(
+	req *access.SubscribeBlocksFromStartHeightRequest,
+	server access.AccessAPI_SubscribeBlocksFromStartHeightServer,
+) error {
+	return stream(server, func(ctx context.Context) (<-chan *flow.Block, <-chan error, error) {
+		startHeight := req.GetStartBlockHeight()
+		return s.source.SubscribeBlocksFromStartHeight(ctx, startHeight, flow.BlockStatus(req.GetBlockStatus()))
+	}, blockMessage, server.Send)
+}
+
+func (s *Service) SubscribeBlocksFromLatest(
+	req *access.SubscribeBlocksFromLatestRequest,
+	server access.AccessAPI_SubscribeBlocksFromLatestServer,
+) error {
+	return stream(server, func(ctx context.Context) (<-chan *flow.Block, <-chan error, error) {
+		return s.source.SubscribeBlocksFromLatest(ctx, flow.BlockStatus(req.GetBlockStatus()))
+	}, blockMessage, server.Send)
+}
+
+func blockHeaderMessage(header *flow.BlockHeader, _ uint64) (*access.SubscribeBlockHeadersResponse, error) {
+	m, err := convert.BlockHeaderToMessage(*header)
+	if err != nil {
+		return nil, conversionError("block header", err)
+	}
+	return &access.SubscribeBlockHeadersResponse{Header: m}, nil
+}
+
+func (s *Service) SubscribeBlockHeadersFromStartBlockID(
+	req *access.SubscribeBlockHeadersFromStartBlockIDRequest,
+	server access.AccessAPI_SubscribeBlockHeadersFromStartBlockIDServer,
+) error {
+	return stream(server, func(ctx context.Context) (<-chan *flow.BlockHeader, <-chan error, error) {
+		startBlockID := convert.MessageToIdentifier(req.GetStartBlockId())
+		return s.source.SubscribeBlockHeadersFromStartBlockID(ctx, startBlockID, flow.BlockStatus(req.GetBlockStatus()))
+	}, blockHeaderMessage, server.Send)
+}
+
+func (s *Service) SubscribeBlockHeadersFromStartHeight(
+	req *access.SubscribeBlockHeadersFromStartHeightRequest,
+	server access.AccessAPI_SubscribeBlockHeadersFromStartHeightServer,
+) error {
+	return stream(server, func(ctx context.Context) (<-chan *flow.BlockHeader, <-chan error, error) {
+		startHeight := req.GetStartBlockHeight()
+		return s.source.SubscribeBlockHeadersFromStartHeight(ctx, startHeight, flow.BlockStatus(req.GetBlockStatus()))
+	}, blockHeaderMessage, server.Send)
+}
+
+func (s *Service) SubscribeBlockHeadersFromLatest(
+	req *access.SubscribeBlockHeadersFromLatestRequest,
+	server access.AccessAPI_SubscribeBlockHeadersFromLatestServer,
+) error {
+	return stream(server, func(ctx context.Context) (<-chan *flow.BlockHeader, <-chan error, error) {
+		return s.source.SubscribeBlockHeadersFromLatest(ctx, flow.BlockStatus(req.GetBlockStatus()))
+	}, blockHeaderMessage, server.Send)
+}
+
+func blockDigestMessage(digest *flow.BlockDigest, _ uint64) (*access.SubscribeBlockDigestsResponse, error) {
+	return convert.BlockDigestToMessage(*digest), nil
+}
+
+func (s *Service) SubscribeBlockDigestsFromStartBlockID(
+	req *access.SubscribeBlockDigestsFromStartBlockIDRequest,
+	server access.AccessAPI_SubscribeBlockDigestsFromStartBlockIDServer,
+) error {
+	return stream(server, func(ctx context.Context) (<-chan *flow.BlockDigest, <-chan error, error) {
+		startBlockID := convert.MessageToIdentifier(req.GetStartBlockId())
+		return s.source.SubscribeBlockDigestsFromStartBlockID(ctx, startBlockID, flow.BlockStatus(req.GetBlockStatus()))
+	}, blockDigestMessage, server.Send)
+}
+
+func (s *Service) SubscribeBlockDigestsFromStartHeight(
+	req *access.SubscribeBlockDigestsFromStartHeightRequest,
+	server access.AccessAPI_SubscribeBlockDigestsFromStartHeightServer,
+) error {
+	return stream(server, func(ctx context.Context) (<-chan *flow.BlockDigest, <-chan error, error) {
+		startHeight := req.GetStartBlockHeight()
+		return s.source.SubscribeBlockDigestsFromStartHeight(ctx, startHeight, flow.BlockStatus(req.GetBlockStatus()))
+	}, blockDigestMessage, server.Send)
+}
+
+func (s *Service) SubscribeBlockDigestsFromLatest(
+	req *access.SubscribeBlockDigestsFromLatestRequest,
+	server access.AccessAPI_SubscribeBlockDigestsFromLatestServer,
+) error {
+	return stream(server, func(ctx context.Context) (<-chan *flow.BlockDigest, <-chan error, error) {
+		return s.source.SubscribeBlockDigestsFromLatest(ctx, flow.BlockStatus(req.GetBlockStatus()))
+	}, blockDigestMessage, server.Send)
+}
+
+func (s *Service) SendAndSubscribeTransactionStatuses(
+	req *access.SendAndSubscribeTransactionStatusesRequest,
+	server access.AccessAPI_SendAndSubscribeTransactionStatusesServer,
+) error {
+	tx, err := convert.MessageToTransaction(req.GetTransaction())
+	if err != nil {
+		return requestError("transaction", err)
+	}
+
+	return stream(server, func(ctx context.Context) (<-chan *flow.TransactionResult, <-chan error, error) {
+		return s.source.SendAndSubscribeTransactionStatuses(ctx, tx)
+	}, func(result *flow.TransactionResult, index uint64) (*access.SendAndSubscribeTransactionStatusesResponse, error) {
+		m, err := transactionResultResponse(result, req.GetEventEncodingVersion(), nil)
+		if err != nil {
+			return nil, err
+		}
+		// Transaction status messages are indexed from 1.
+		return &access.SendAndSubscribeTransactionStatusesResponse{TransactionResults: m, MessageIndex: index + 1}, nil
+	}, server.Send)
+}
+
+func executionDataMessage(
+	response *flow.ExecutionDataStreamResponse,
+	_ uint64,
+) (*executiondata.SubscribeExecutionDataResponse, error) {
+	m, err := convert.BlockExecutionDataToMessage(response.ExecutionData)
+	if err != nil {
+		return nil, conversionError("execution data", err)
+	}
+	return &executiondata.SubscribeExecutionDataResponse{
+		BlockHeight:        response.Height,
+		BlockExecutionData: m,
+		BlockTimestamp:     timestamppb.New(response.BlockTimestamp),
+	}, nil
+}
+
+func (s *Service) subscribeExecutionDataFromStartBlockID(startBlockID []byte) func(ctx context.Context) (
+	<-chan *flow.ExecutionDataStreamResponse, <-chan error, error,
+) {
+	return func(ctx context.Context) (<-chan *flow.ExecutionDataStreamResponse, <-chan error, error) {
+		return s.source.SubscribeExecutionDataByBlockID(ctx, convert.MessageToIdentifier(startBlockID))
+	}
+}
+
+func (s *Service) subscribeExecutionDataFromStartHeight(startHeight uint64) func(ctx context.Context) (
+	<-chan *flow.ExecutionDataStreamResponse, <-chan error, error,
+) {
+	return func(ctx context.Context) (<-chan *flow.ExecutionDataStreamResponse, <-chan error, error) {
+		return s.source.SubscribeExecutionDataByBlockHeight(ctx, startHeight)
+	}
+}
+
+func (s *Service) SubscribeExecutionData(
+	req *executiondata.SubscribeExecutionDataRequest,
+	server executiondata.ExecutionDataAPI_SubscribeExecutionDataServer,
+) error {
+	subscribe := s.subscribeExecutionDataFromStartHeight(req.GetStartBlockHeight())
+	if len(req.GetStartBlockId()) > 0 {
+		subscribe = s.subscribeExecutionDataFromStartBlockID(req.GetStartBlockId())
+	}
+	return stream(server, subscribe, executionDataMessage, server.Send)
+}
+
+func (s *Service) SubscribeExecutionDataFromStartBlockID(
+	req *executiondata.SubscribeExecutionDataFromStartBlockIDRequest,
+	server executiondata.ExecutionDataAPI_SubscribeExecutionDataFromStartBlockIDServer,
+) error {
+	return stream(server, s.subscribeExecutionDataFromStartBlockID(req.GetStartBlockId()), executionDataMessage, server.Send)
+}
+
+func (s *Service) SubscribeExecutionDataFromStartBlockHeight(
+	req *executiondata.SubscribeExecutionDataFromStartBlockHeightRequest,
+	server executiondata.ExecutionDataAPI_SubscribeExecutionDataFromStartBlockHeightServer,
+) error {
+	return stream(server, s.subscribeExecutionDataFromStartHeight(req.GetStartBlockHeight()), executionDataMessage, server.Send)
+}
+
+func (s *Service) SubscribeExecutionDataFromLatest(
+	_ *executiondata.SubscribeExecutionDataFromLatestRequest,
+	server executiondata.ExecutionDataAPI_SubscribeExecutionDataFromLatestServer,
+) error {
+	height, err := s.latestHeight(server.Context())
+	if err != nil {
+		return err
+	}
+	return stream(server, s.subscribeExecutionDataFromStartHeight(height), executionDataMessage, server.Send)
+}
+
+// eventsStream streams the events of a subscription starting at the block ID, or else at the height.
+func (s *Service) eventsStream(
+	server grpc.ServerStream,
+	startBlockID []byte,
+	startHeight uint64,
+	filter *executiondata.EventFilter,
+	heartbeatInterval uint64,
+	encoding flow.EventEncodingVersion,
+	send func(*executiondata.SubscribeEventsResponse) error,
+) error {
+	eventFilter := flow.EventFilter{
+		EventTypes: filter.GetEventType(),
+		Addresses:  filter.GetAddress(),
+		Contracts:  filter.GetContract(),
+	}
+
+	var opts []base.SubscribeOption
+	if heartbeatInterval > 0 {
+		opts = append(opts, base.WithHeartbeatInterval(heartbeatInterval))
+	}
+
+	return stream(server, func(ctx context.Context) (<-chan flow.BlockEvents, <-chan error, error) {
+		if len(startBlockID) > 0 {
+			return s.source.SubscribeEventsByBlockID(ctx, convert.MessageToIdentifier(startBlockID), eventFilter, opts...)
+		}
+		return s.source.SubscribeEventsByBlockHeight(ctx, startHeight, eventFilter, opts...)
+	}, func(block flow.BlockEvents, index uint64) (*executiondata.SubscribeEventsResponse, error) {
+		events, err := eventMessages(block.Events, encoding)
+		if err != nil {
+			return nil, err
+		}
+		return &executiondata.SubscribeEventsResponse{
+			BlockId:        convert.IdentifierToMessage(block.BlockID),
+			BlockHeight:    block.Height,
+			Events:         events,
+			BlockTimestamp: timestamppb.New(block.BlockTimestamp),
+			MessageIndex:   index,
+		}, nil
+	}, send)
+}
+
+func (s *Service) SubscribeEvents(
+	req *executiondata.SubscribeEventsRequest,
+	server executiondata.ExecutionDataAPI_SubscribeEventsServer,
+) error {
+	return s.eventsStream(server, req.GetStartBlockId(), req.GetStartBlockHeight(), req.GetFilter(),
+		req.GetHeartbeatInterval(), req.GetEventEncodingVersion(), server.Send)
+}
+
+func (s *Service) SubscribeEventsFromStartBlockID(
+	req *executiondata.SubscribeEventsFromStartBlockIDRequest,
+	server executiondata.ExecutionDataAPI_SubscribeEventsFromStartBlockIDServer,
+) error {
+	return s.eventsStream(server, req.GetStartBlockId(), 0, req.GetFilter(),
+		req.GetHeartbeatInterval(), req.GetEventEncodingVersion(), server.Send)
+}
+
+func (s *Service) SubscribeEventsFromStartHeight(
+	req *executiondata.SubscribeEventsFromStartHeightRequest,
+	server executiondata.ExecutionDataAPI_SubscribeEventsFromStartHeightServer,
+) error {
+	return s.eventsStream(server, nil, req.GetStartBlockHeight(), req.GetFilter(),
+		req.GetHeartbeatInterval(), req.GetEventEncodingVersion(), server.Send)
+}
+
+func (s *Service) SubscribeEventsFromLatest(
+	req *executiondata.SubscribeEventsFromLatestRequest,
+	server executiondata.ExecutionDataAPI_SubscribeEventsFromLatestServer,
+) error {
+	height, err := s.latestHeight(server.Context())
+	if err != nil {
+		return err
+	}
+	return s.eventsStream(server, nil, height, req.GetFilter(),
+		req.GetHeartbeatInterval(), req.GetEventEncodingVersion(), server.Send)
+}
+
+func accountStatusFilter(filter *executiondata.StatusFilter) flow.AccountStatusFilter {
+	return flow.AccountStatusFilter{
+		EventFilter: flow.EventFilter{
+			EventTypes: filter.GetEventType(),
+			Addresses:  filter.GetAddress(),
+		},
+	}
+}
+
+// accountStatusMessage returns the message of an account status, indexed by the server like access nodes do.
+func accountStatusMessage(
+	encoding entities.EventEncodingVersion,
+) func(*flow.AccountStatus, uint64) (*executiondata.SubscribeAccountStatusesResponse, error) {
+	return func(accountStatus *flow.AccountStatus, index uint64) (*executiondata.SubscribeAccountStatusesResponse, error) {
+		m, err := convert.AccountStatusToMessage(*accountStatus, encoding)
+		if err != nil {
+			return nil, conversionError("account status", err)
+		}
+		m.MessageIndex = index
+		return m, nil
+	}
+}
+
+func (s *Service) SubscribeAccountStatusesFromStartBlockID(
+	req *executiondata.SubscribeAccountStatusesFromStartBlockIDRequest,
+	server executiondata.ExecutionDataAPI_SubscribeAccountStatusesFromStartBlockIDServer,
+) error {
+	return stream(server, func(ctx context.Context) (<-chan *flow.AccountStatus, <-chan error, error) {
+		startBlockID := convert.MessageToIdentifier(req.GetStartBlockId())
+		return s.source.SubscribeAccountStatusesFromStartBlockID(ctx, startBlockID, accountStatusFilter(req.GetFilter()))
+	}, accountStatusMessage(req.GetEventEncodingVersion()), server.Send)
+}
+
+func (s *Service) SubscribeAccountStatusesFromStartHeight(
+	req *executiondata.SubscribeAccountStatusesFromStartHeightRequest,
+	server executiondata.ExecutionDataAPI_SubscribeAccountStatusesFromStartHeightServer,
+) error {
+	return stream(server, func(ctx context.Context) (<-chan *flow.AccountStatus, <-chan error, error) {
+		startHeight := req.GetStartBlockHeight()
+		return s.source.SubscribeAccountStatusesFromStartHeight(ctx, startHeight, accountStatusFilter(req.GetFilter()))
+	}, accountStatusMessage(req.GetEventEncodingVersion()), server.Send)
+}
+
+func (s *Service) SubscribeAccountStatusesFromLatestBlock(
+	req *executiondata.SubscribeAccountStatusesFromLatestBlockRequest,
+	server executiondata.ExecutionDataAPI_SubscribeAccountStatusesFromLatestBlockServer,
+) error {
+	return stream(server, func(ctx context.Context) (<-chan *flow.AccountStatus, <-chan error, error) {
+		return s.source.SubscribeAccountStatusesFromLatestBlock(ctx, accountStatusFilter(req.GetFilter()))
+	}, accountStatusMessage(req.GetEventEncodingVersion()), server.Send)
+}
@@ -0,0 +1,166 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/onflow/flow-go-sdk/access/logging"
+)
+
+// WithLogger logs all Access API calls with the logger.
+//
+// Every call is logged with its method, gRPC status code, duration and request ID, which is sent to the access node
+// in the x-request-id metadata. Subscriptions are logged when the stream ends.
+func WithLogger(logger *logging.Logger) ClientOption {
+	return func(opts *options) {
+		opts.logger = logger
+	}
+}
+
+// loggingDialOptions returns the dial options installing the logging interceptors.
+func loggingDialOptions(logger *logging.Logger) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(unaryLoggingInterceptor(logger)),
+		grpc.WithChainStreamInterceptor(streamLoggingInterceptor(logger)),
+	}
+}
+
+func unaryLoggingInterceptor(logger *logging.Logger) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		call := logger.Start(ctx, methodName(method), jsonBody(req))
+		ctx = withRequestID(ctx, call.RequestID())
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		response := jsonBody(reply)
+		if err != nil {
+			response = nil
+		}
+		call.End(response, err, slog.String("code", status.Code(err).String()))
+		return err
+	}
+}
+
+func streamLoggingInterceptor(logger *logging.Logger) grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		call := logger.Start(ctx, methodName(method), nil)
+		ctx = withRequestID(ctx, call.RequestID())
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			call.End(nil, err, slog.String("code", status.Code(err).String()))
+			return nil, err
+		}
+
+		return &loggingStream{
+			ClientStream: stream,
+			call:         call,
+		}, nil
+	}
+}
+
+// loggingStream logs the messages of a stream, and logs the stream when it ends.
+type loggingStream struct {
+	grpc.ClientStream
+	call  *logging.Call
+	ended bool
+}
+
+func (s *loggingStream) SendMsg(m any) error {
+	s.call.Request(jsonBody(m))
+
+	err := s.ClientStream.SendMsg(m)
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.end(err)
+	}
+	return err
+}
+
+func (s *loggingStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			s.end(nil)
+		} else {
+			s.end(err)
+		}
+		return err
+	}
+
+	s.call.Message(jsonBody(m))
+	return nil
+}
+
+func (s *loggingStream) end(err error) {
+	if s.ended {
+		return
+	}
+	s.ended = true
+
+	code := codes.OK
+	if err != nil {
+		code = status.Code(err)
+	}
+	s.call.End(nil, err, slog.String("code", code.String()))
+}
+
+// withRequestID sends the request ID of a call to the access node.
+func withRequestID(ctx context.Context, requestID string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, strings.ToLower(logging.RequestIDHeader), requestID)
+}
+
+// jsonBody returns a function encoding a message as JSON for logging.
+func jsonBody(message any) func() []byte {
+	return func() []byte {
+		msg, ok := protoMessage(message)
+		if !ok {
+			return nil
+		}
+		body, err := protojson.Marshal(msg)
+		if err != nil {
+			return nil
+		}
+		return body
+	}
+}
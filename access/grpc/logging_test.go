@@ -0,0 +1,150 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package grpc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/onflow/flow-go-sdk"
+	accessgrpc "github.com/onflow/flow-go-sdk/access/grpc"
+	"github.com/onflow/flow-go-sdk/access/logging"
+)
+
+func TestWithLogger(t *testing.T) {
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	var requestIDs []string
+	recordRequestID := func(ctx context.Context) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		mu.Lock()
+		defer mu.Unlock()
+		requestIDs = append(requestIDs, md.Get("x-request-id")...)
+	}
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(func(
+			ctx context.Context,
+			req any,
+			_ *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler,
+		) (any, error) {
+			recordRequestID(ctx)
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(
+			srv any,
+			stream grpc.ServerStream,
+			_ *grpc.StreamServerInfo,
+			handler grpc.StreamHandler,
+		) error {
+			recordRequestID(stream.Context())
+			return handler(srv, stream)
+		}),
+	)
+	access.RegisterAccessAPIServer(server, &telemetryTestServer{t: t})
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client, err := accessgrpc.NewClient(
+		"passthrough:///bufnet",
+		accessgrpc.WithGRPCDialOptions(grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		})),
+		accessgrpc.WithLogger(logging.New(logger, logging.WithBodies(64))),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.GetBlockHeaderByHeight(ctx, 42)
+	require.NoError(t, err)
+
+	_, err = client.GetBlockHeaderByHeight(ctx, 101)
+	require.Error(t, err)
+
+	headers, errs, err := client.SubscribeBlockHeadersFromStartHeight(ctx, 40, flow.BlockStatusSealed)
+	require.NoError(t, err)
+	for range 3 {
+		<-headers
+	}
+	require.Error(t, <-errs)
+
+	var calls, messages []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		if record["msg"] == "access API message" {
+			messages = append(messages, record)
+		} else {
+			calls = append(calls, record)
+		}
+	}
+	require.Len(t, calls, 3)
+	require.Len(t, messages, 3)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, requestIDs, 3)
+
+	t.Run("Unary calls", func(t *testing.T) {
+		call := calls[0]
+		assert.Equal(t, "DEBUG", call["level"])
+		assert.Equal(t, "flow.access.AccessAPI/GetBlockHeaderByHeight", call["method"])
+		assert.Equal(t, "OK", call["code"])
+		assert.Equal(t, requestIDs[0], call["request_id"])
+		assert.Equal(t, `{"height":"42"}`, call["request"])
+		assert.Contains(t, call["response"], "...(truncated")
+
+		failed := calls[1]
+		assert.Equal(t, "ERROR", failed["level"])
+		assert.Equal(t, "NotFound", failed["code"])
+		assert.Contains(t, failed["error"], "block not found")
+		assert.NotContains(t, failed, "response")
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		call := calls[2]
+		assert.Equal(t, "flow.access.AccessAPI/SubscribeBlockHeadersFromStartHeight", call["method"])
+		assert.Equal(t, "Unavailable", call["code"])
+		assert.Equal(t, float64(3), call["messages"])
+		assert.Equal(t, requestIDs[2], call["request_id"])
+		assert.Contains(t, call["request"], `"startBlockHeight":"40"`)
+
+		for _, message := range messages {
+			assert.Equal(t, requestIDs[2], message["request_id"])
+		}
+	})
+}
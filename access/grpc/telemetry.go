@@ -0,0 +1,323 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/onflow/flow-go-sdk/access/internal/telemetry"
+)
+
+// untracedKey marks the context of calls made by the telemetry itself, which are not traced.
+type untracedKey struct{}
+
+type telemetryOptions struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// WithOpenTelemetry enables OpenTelemetry spans and metrics for all Access API calls.
+//
+// Every call creates a span with the method, the status code, the request and response sizes, and the block height,
+// block ID or transaction ID of the request if any. Subscriptions add a span event per received message, and the span
+// ends when the stream ends. The metrics are the call durations, the errors by status code, the received subscription
+// messages, and the subscription lag, i.e. the number of blocks subscription messages are behind the latest finalized
+// block of the access node. The latest block height is sampled with GetLatestBlockHeader while subscriptions receive
+// messages, at most once per 10 seconds, and these calls are not traced.
+//
+// The global providers are used if the providers are nil.
+func WithOpenTelemetry(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) ClientOption {
+	return func(opts *options) {
+		opts.telemetry = &telemetryOptions{
+			tracerProvider: tracerProvider,
+			meterProvider:  meterProvider,
+		}
+	}
+}
+
+// telemetryDialOptions returns the dial options installing the telemetry interceptors.
+func telemetryDialOptions(opts *telemetryOptions) ([]grpc.DialOption, error) {
+	instrumentation, err := telemetry.New("grpc", opts.tracerProvider, opts.meterProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(unaryTelemetryInterceptor(instrumentation)),
+		grpc.WithChainStreamInterceptor(streamTelemetryInterceptor(instrumentation)),
+	}, nil
+}
+
+func unaryTelemetryInterceptor(instrumentation *telemetry.Instrumentation) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if ctx.Value(untracedKey{}) != nil {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		name := methodName(method)
+		ctx, call := instrumentation.Start(ctx, name, trace.SpanKindClient, requestAttributes(name, req)...)
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			call.SetAttributes(responseAttributes(reply)...)
+			if height, ok := messageHeight(reply); ok {
+				instrumentation.ObserveHeight(height)
+			}
+		}
+
+		call.End(ctx, status.Code(err).String(), err)
+		return err
+	}
+}
+
+func streamTelemetryInterceptor(instrumentation *telemetry.Instrumentation) grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		name := methodName(method)
+		ctx, call := instrumentation.Start(ctx, name, trace.SpanKindClient)
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			call.End(ctx, status.Code(err).String(), err)
+			return nil, err
+		}
+
+		return &telemetryStream{
+			ClientStream:    stream,
+			ctx:             ctx,
+			cc:              cc,
+			name:            name,
+			instrumentation: instrumentation,
+			call:            call,
+		}, nil
+	}
+}
+
+// telemetryStream records the messages of a stream, and ends the span of the stream when it ends.
+type telemetryStream struct {
+	grpc.ClientStream
+	ctx             context.Context
+	cc              *grpc.ClientConn
+	name            string
+	instrumentation *telemetry.Instrumentation
+	call            *telemetry.Call
+	ended           bool
+}
+
+func (s *telemetryStream) SendMsg(m any) error {
+	s.call.SetAttributes(requestAttributes(s.name, m)...)
+
+	err := s.ClientStream.SendMsg(m)
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.end(err)
+	}
+	return err
+}
+
+func (s *telemetryStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			s.end(nil)
+		} else {
+			s.end(err)
+		}
+		return err
+	}
+
+	size := 0
+	if msg, ok := protoMessage(m); ok {
+		size = proto.Size(msg)
+	}
+	height, hasHeight := messageHeight(m)
+	if hasHeight {
+		s.instrumentation.SampleHead(s.ctx, s.latestHeight)
+	}
+	s.call.Message(s.ctx, size, height, hasHeight)
+
+	return nil
+}
+
+// latestHeight returns the height of the latest finalized block of the access node, without tracing the call.
+func (s *telemetryStream) latestHeight(ctx context.Context) (uint64, error) {
+	var res access.BlockHeaderResponse
+	err := s.cc.Invoke(
+		context.WithValue(ctx, untracedKey{}, true),
+		access.AccessAPI_GetLatestBlockHeader_FullMethodName,
+		&access.GetLatestBlockHeaderRequest{IsSealed: false},
+		&res,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.GetBlock().GetHeight(), nil
+}
+
+func (s *telemetryStream) end(err error) {
+	if s.ended {
+		return
+	}
+	s.ended = true
+
+	code := codes.OK
+	if err != nil {
+		code = status.Code(err)
+	}
+	s.call.End(s.ctx, code.String(), err)
+}
+
+// methodName returns the name of a full gRPC method name, e.g. "flow.access.AccessAPI/GetBlockByHeight".
+func methodName(method string) string {
+	return strings.TrimPrefix(method, "/")
+}
+
+// requestAttributes returns the block height, block ID, transaction ID or collection ID of a request, and its size.
+func requestAttributes(method string, req any) []attribute.KeyValue {
+	msg, ok := protoMessage(req)
+	if !ok {
+		return nil
+	}
+
+	attrs := []attribute.KeyValue{telemetry.RequestSizeKey.Int(proto.Size(msg))}
+
+	m := msg.ProtoReflect()
+	if height, ok := uintField(m, "height", "block_height", "start_block_height"); ok {
+		attrs = append(attrs, telemetry.BlockHeightKey.Int64(int64(height)))
+	}
+	if id, ok := bytesField(m, "block_id", "start_block_id"); ok {
+		attrs = append(attrs, telemetry.BlockIDKey.String(id))
+	}
+	if id, ok := bytesField(m, "id", "transaction_id"); ok {
+		switch {
+		case strings.Contains(method, "Transaction"):
+			attrs = append(attrs, telemetry.TransactionIDKey.String(id))
+		case strings.Contains(method, "Collection"):
+			attrs = append(attrs, telemetry.CollectionIDKey.String(id))
+		case strings.Contains(method, "Block"):
+			attrs = append(attrs, telemetry.BlockIDKey.String(id))
+		}
+	}
+
+	return attrs
+}
+
+// responseAttributes returns the size of a response, and the ID of a sent transaction.
+func responseAttributes(reply any) []attribute.KeyValue {
+	msg, ok := protoMessage(reply)
+	if !ok {
+		return nil
+	}
+
+	attrs := []attribute.KeyValue{telemetry.ResponseSizeKey.Int(proto.Size(msg))}
+
+	m := msg.ProtoReflect()
+	if m.Descriptor().Name() == "SendTransactionResponse" {
+		if id, ok := bytesField(m, "id"); ok {
+			attrs = append(attrs, telemetry.TransactionIDKey.String(id))
+		}
+	}
+
+	return attrs
+}
+
+// messageHeight returns the block height of a response message, found in its fields or in its block or header.
+func messageHeight(message any) (uint64, bool) {
+	msg, ok := protoMessage(message)
+	if !ok {
+		return 0, false
+	}
+
+	m := msg.ProtoReflect()
+	if height, ok := uintField(m, "block_height", "height"); ok {
+		return height, true
+	}
+
+	for _, name := range []protoreflect.Name{"block", "header"} {
+		field := m.Descriptor().Fields().ByName(name)
+		if field == nil || field.Message() == nil || !m.Has(field) {
+			continue
+		}
+		if height, ok := uintField(m.Get(field).Message(), "height"); ok {
+			return height, true
+		}
+	}
+
+	return 0, false
+}
+
+// protoMessage returns a message as a protobuf message supporting reflection.
+// The Access API messages are generated with the legacy protobuf API.
+func protoMessage(message any) (proto.Message, bool) {
+	switch msg := message.(type) {
+	case proto.Message:
+		return msg, true
+	case protoadapt.MessageV1:
+		return protoadapt.MessageV2Of(msg), true
+	default:
+		return nil, false
+	}
+}
+
+func uintField(m protoreflect.Message, names ...protoreflect.Name) (uint64, bool) {
+	for _, name := range names {
+		field := m.Descriptor().Fields().ByName(name)
+		if field != nil && field.Kind() == protoreflect.Uint64Kind && m.Has(field) {
+			return m.Get(field).Uint(), true
+		}
+	}
+	return 0, false
+}
+
+func bytesField(m protoreflect.Message, names ...protoreflect.Name) (string, bool) {
+	for _, name := range names {
+		field := m.Descriptor().Fields().ByName(name)
+		if field != nil && field.Kind() == protoreflect.BytesKind && m.Has(field) {
+			return hex.EncodeToString(m.Get(field).Bytes()), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,226 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/onflow/flow-go-sdk"
+	accessgrpc "github.com/onflow/flow-go-sdk/access/grpc"
+	"github.com/onflow/flow-go-sdk/access/grpc/convert"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+// latestHeight is the height of the latest block of the telemetry test server.
+const latestHeight = 50
+
+// telemetryTestServer serves block headers up to a height, and subscriptions of block headers.
+type telemetryTestServer struct {
+	access.UnimplementedAccessAPIServer
+	t       *testing.T
+	headers []flow.BlockHeader
+}
+
+func (s *telemetryTestServer) header(height uint64) *access.BlockHeaderResponse {
+	header := test.BlockHeaderGenerator().New()
+	header.Height = height
+	message, err := convert.BlockHeaderToMessage(header)
+	require.NoError(s.t, err)
+	return &access.BlockHeaderResponse{Block: message}
+}
+
+func (s *telemetryTestServer) GetBlockHeaderByHeight(
+	_ context.Context,
+	req *access.GetBlockHeaderByHeightRequest,
+) (*access.BlockHeaderResponse, error) {
+	if req.GetHeight() > 100 {
+		return nil, status.Error(codes.NotFound, "block not found")
+	}
+	return s.header(req.GetHeight()), nil
+}
+
+func (s *telemetryTestServer) GetLatestBlockHeader(
+	_ context.Context,
+	_ *access.GetLatestBlockHeaderRequest,
+) (*access.BlockHeaderResponse, error) {
+	return s.header(latestHeight), nil
+}
+
+func (s *telemetryTestServer) SubscribeBlockHeadersFromStartHeight(
+	req *access.SubscribeBlockHeadersFromStartHeightRequest,
+	stream access.AccessAPI_SubscribeBlockHeadersFromStartHeightServer,
+) error {
+	for height := req.GetStartBlockHeight(); height < req.GetStartBlockHeight()+3; height++ {
+		if err := stream.Send(&access.SubscribeBlockHeadersResponse{Header: s.header(height).Block}); err != nil {
+			return err
+		}
+	}
+	return status.Error(codes.Unavailable, "shutting down")
+}
+
+// newTelemetryTestClient returns a client of a test server, recording its spans and metrics.
+func newTelemetryTestClient(t *testing.T) (*accessgrpc.Client, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	access.RegisterAccessAPIServer(server, &telemetryTestServer{t: t})
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	spans := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	client, err := accessgrpc.NewClient(
+		"passthrough:///bufnet",
+		accessgrpc.WithGRPCDialOptions(grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		})),
+		accessgrpc.WithOpenTelemetry(tracerProvider, meterProvider),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	return client, spans, reader
+}
+
+// collectMetrics returns the data of the recorded metrics by name.
+func collectMetrics(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Aggregation {
+	var data metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &data))
+
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+	return metrics
+}
+
+func TestWithOpenTelemetry(t *testing.T) {
+	ctx := context.Background()
+
+	client, spans, reader := newTelemetryTestClient(t)
+
+	_, err := client.GetBlockHeaderByHeight(ctx, 42)
+	require.NoError(t, err)
+
+	_, err = client.GetBlockHeaderByHeight(ctx, 101)
+	require.Error(t, err)
+
+	headers, errs, err := client.SubscribeBlockHeadersFromStartHeight(ctx, 40, flow.BlockStatusSealed)
+	require.NoError(t, err)
+	for range 3 {
+		<-headers
+	}
+	require.Error(t, <-errs)
+
+	ended := spans.Ended()
+	require.Len(t, ended, 3)
+
+	t.Run("Unary spans", func(t *testing.T) {
+		span := ended[0]
+		assert.Equal(t, "flow.access.AccessAPI/GetBlockHeaderByHeight", span.Name())
+		assertAttribute(t, span.Attributes(), "flow.block.height", attribute.Int64Value(42))
+		assertAttribute(t, span.Attributes(), "rpc.status_code", attribute.StringValue("OK"))
+		assertAttribute(t, span.Attributes(), "rpc.system", attribute.StringValue("grpc"))
+
+		failed := ended[1]
+		assertAttribute(t, failed.Attributes(), "rpc.status_code", attribute.StringValue("NotFound"))
+	})
+
+	t.Run("Stream span", func(t *testing.T) {
+		span := ended[2]
+		assert.Equal(t, "flow.access.AccessAPI/SubscribeBlockHeadersFromStartHeight", span.Name())
+		assertAttribute(t, span.Attributes(), "flow.block.height", attribute.Int64Value(40))
+		assertAttribute(t, span.Attributes(), "rpc.status_code", attribute.StringValue("Unavailable"))
+
+		require.Len(t, span.Events(), 4) // 3 messages and the error
+		assertAttribute(t, span.Events()[0].Attributes, "flow.block.height", attribute.Int64Value(40))
+	})
+
+	t.Run("Metrics", func(t *testing.T) {
+		metrics := collectMetrics(t, reader)
+
+		errors := metrics["flow.access.client.errors"].(metricdata.Sum[int64])
+		require.Len(t, errors.DataPoints, 2)
+
+		messages := metrics["flow.access.client.subscription.messages"].(metricdata.Sum[int64])
+		require.Len(t, messages.DataPoints, 1)
+		assert.Equal(t, int64(3), messages.DataPoints[0].Value)
+
+		// the messages are 10, 9 and 8 blocks behind the latest block 50
+		lag := metrics["flow.access.client.subscription.lag"].(metricdata.Histogram[int64])
+		require.Len(t, lag.DataPoints, 1)
+		assert.Equal(t, uint64(3), lag.DataPoints[0].Count)
+		assert.Equal(t, int64(27), lag.DataPoints[0].Sum)
+
+		duration := metrics["flow.access.client.duration"].(metricdata.Histogram[float64])
+		assert.Len(t, duration.DataPoints, 3)
+	})
+}
+
+func TestWithOpenTelemetry_SubscriptionLag(t *testing.T) {
+	// the client only subscribes, so it sees no block height other than the ones of the messages
+	client, spans, reader := newTelemetryTestClient(t)
+
+	headers, errs, err := client.SubscribeBlockHeadersFromStartHeight(context.Background(), 45, flow.BlockStatusFinalized)
+	require.NoError(t, err)
+	for range 3 {
+		<-headers
+	}
+	require.Error(t, <-errs)
+
+	// the sample of the latest block is not traced
+	require.Len(t, spans.Ended(), 1)
+
+	// the messages are 5, 4 and 3 blocks behind the latest block 50
+	lag := collectMetrics(t, reader)["flow.access.client.subscription.lag"].(metricdata.Histogram[int64])
+	require.Len(t, lag.DataPoints, 1)
+	assert.Equal(t, uint64(3), lag.DataPoints[0].Count)
+	assert.Equal(t, int64(12), lag.DataPoints[0].Sum)
+	maxLag, _ := lag.DataPoints[0].Max.Value()
+	assert.Equal(t, int64(5), maxLag)
+}
+
+func assertAttribute(t *testing.T, attrs []attribute.KeyValue, key attribute.Key, value attribute.Value) {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			assert.Equal(t, value, attr.Value, "attribute %s", key)
+			return
+		}
+	}
+	assert.Fail(t, "missing attribute", "attribute %s", key)
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 
 	"github.com/onflow/flow-go-sdk/access"
+	"github.com/onflow/flow-go-sdk/access/logging"
 
 	"github.com/onflow/cadence"
 	jsoncdc "github.com/onflow/cadence/encoding/json"
@@ -42,6 +43,8 @@ type ClientOption func(*options)
 
 type options struct {
 	jsonOptions []jsoncdc.Option
+	telemetry   *telemetryOptions
+	logger      *logging.Logger
 }
 
 func DefaultClientOptions() *options {
@@ -74,6 +77,18 @@ func NewClient(host string, opts ...ClientOption) (*Client, error) {
 
 	client.SetJSONOptions(cfg.jsonOptions)
 
+	if cfg.telemetry != nil {
+		if err := client.enableTelemetry(cfg.telemetry); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.logger != nil {
+		if err := client.enableLogging(cfg.logger); err != nil {
+			return nil, err
+		}
+	}
+
 	return &Client{client}, nil
 }
 
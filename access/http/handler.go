@@ -24,12 +24,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
-	"time"
 
 	"github.com/onflow/flow-go-sdk/access/http/models"
+	"github.com/onflow/flow-go-sdk/access/logging"
 
 	"github.com/pkg/errors"
 )
@@ -51,10 +52,10 @@ func (h HTTPError) Error() string {
 type httpHandler struct {
 	client *http.Client
 	base   string
-	debug  bool
+	logger *logging.Logger
 }
 
-func newHandler(host string, debug bool) (*httpHandler, error) {
+func newHandler(host string) (*httpHandler, error) {
 	_, err := url.Parse(host)
 	if err != nil {
 		return nil, err
@@ -63,7 +64,6 @@ func newHandler(host string, debug bool) (*httpHandler, error) {
 	return &httpHandler{
 		client: http.DefaultClient,
 		base:   host,
-		debug:  debug,
 	}, nil
 }
 
@@ -79,28 +79,27 @@ func (h *httpHandler) mustBuildURL(path string, opts ...queryOpts) *url.URL {
 	return u
 }
 
-func (h *httpHandler) get(_ context.Context, url *url.URL, model interface{}) error {
-	if h.debug {
-		fmt.Printf("\n-> GET %s t=%d", url.String(), time.Now().Unix())
+func (h *httpHandler) get(ctx context.Context, url *url.URL, model interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+	if err != nil {
+		return err
 	}
+	call := h.startLog(req, nil)
 
-	// todo use a .Do() method and use the context
-	res, err := h.client.Get(url.String())
+	res, err := h.client.Do(req)
 	if err != nil {
+		h.endLog(call, req, nil, nil, err)
 		return err
 	}
 	defer res.Body.Close()
 
 	body, err := ioutil.ReadAll(res.Body)
+	h.endLog(call, req, res, body, err)
 	if err != nil {
 		return err
 	}
 
 	if res.StatusCode >= http.StatusBadRequest {
-		if h.debug {
-			fmt.Printf("\n<- FAILED GET %s t=%d status=%d - %s", url.String(), res.StatusCode, time.Now().Unix(), body)
-		}
-
 		var httpErr HTTPError
 		err = json.Unmarshal(body, &httpErr)
 		if err != nil {
@@ -111,10 +110,6 @@ func (h *httpHandler) get(_ context.Context, url *url.URL, model interface{}) er
 		return httpErr
 	}
 
-	if h.debug {
-		fmt.Printf("\n<- GET %s t=%d - %s", url.String(), time.Now().Unix(), body)
-	}
-
 	err = json.Unmarshal(body, &model)
 	if err != nil {
 		return errors.Wrap(err, "JSON decoding failed")
@@ -123,31 +118,28 @@ func (h *httpHandler) get(_ context.Context, url *url.URL, model interface{}) er
 	return nil
 }
 
-func (h *httpHandler) post(_ context.Context, url *url.URL, body []byte, model interface{}) error {
-	if h.debug {
-		fmt.Printf("\n-> POST %s t=%d - %s", url.String(), time.Now().Unix(), string(body))
+func (h *httpHandler) post(ctx context.Context, url *url.URL, body []byte, model interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
 	}
+	req.Header.Set("Content-Type", "application/json")
+	call := h.startLog(req, body)
 
-	res, err := h.client.Post(
-		url.String(),
-		"application/json",
-		bytes.NewReader(body),
-	)
+	res, err := h.client.Do(req)
 	if err != nil {
+		h.endLog(call, req, nil, nil, err)
 		return errors.Wrap(err, fmt.Sprintf("HTTP POST %s failed", url.String()))
 	}
 	defer res.Body.Close()
 
 	responseBody, err := ioutil.ReadAll(res.Body)
+	h.endLog(call, req, res, responseBody, err)
 	if err != nil {
 		return err
 	}
 
 	if res.StatusCode >= http.StatusBadRequest {
-		if h.debug {
-			fmt.Printf("\n<- POST FAILED %s, status=%d, response: %s", url.String(), res.StatusCode, responseBody)
-		}
-
 		var httpErr HTTPError
 		err = json.Unmarshal(responseBody, &httpErr)
 		if err != nil {
@@ -158,10 +150,6 @@ func (h *httpHandler) post(_ context.Context, url *url.URL, body []byte, model i
 		return httpErr
 	}
 
-	if h.debug {
-		fmt.Printf("\n<- POST %s t=%d - %s", url.String(), time.Now().Unix(), string(body))
-	}
-
 	err = json.Unmarshal(responseBody, &model)
 	if err != nil {
 		return errors.Wrap(err, "JSON decoding failed")
@@ -170,6 +158,33 @@ func (h *httpHandler) post(_ context.Context, url *url.URL, body []byte, model i
 	return nil
 }
 
+// startLog starts logging a request if logging is enabled, and sends the request ID to the access node.
+func (h *httpHandler) startLog(req *http.Request, body []byte) *logging.Call {
+	if h.logger == nil {
+		return nil
+	}
+
+	call := h.logger.Start(req.Context(), req.Method, func() []byte { return body })
+	req.Header.Set(logging.RequestIDHeader, call.RequestID())
+	return call
+}
+
+// endLog logs a request with its response if logging is enabled.
+func (h *httpHandler) endLog(call *logging.Call, req *http.Request, res *http.Response, body []byte, err error) {
+	if call == nil {
+		return
+	}
+
+	attrs := []slog.Attr{slog.String("url", req.URL.String())}
+	if res != nil {
+		attrs = append(attrs, slog.Int("status", res.StatusCode))
+		if err == nil && res.StatusCode >= http.StatusBadRequest {
+			err = fmt.Errorf("HTTP status %d", res.StatusCode)
+		}
+	}
+	call.End(func() []byte { return body }, err, attrs...)
+}
+
 func (h *httpHandler) getNetworkParameters(ctx context.Context, opts ...queryOpts) (*models.NetworkParameters, error) {
 	var networkParameters models.NetworkParameters
 	err := h.get(ctx, h.mustBuildURL("/network/parameters", opts...), &networkParameters)
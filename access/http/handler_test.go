@@ -59,7 +59,6 @@ func handlerTest(f func(ctx context.Context, t *testing.T, handler httpHandler,
 		h := httpHandler{
 			client: server.Client(),
 			base:   server.URL,
-			debug:  false,
 		}
 
 		f(context.Background(), t, h, testReq)
@@ -155,7 +155,7 @@ func (b *HeightQuery) singleHeightDefined() bool {
 // Use this client if you need advance access to the HTTP API. If you
 // don't require special methods use the Client instead.
 func NewBaseClient(host string) (*BaseClient, error) {
-	handler, err := newHandler(host, false)
+	handler, err := newHandler(host)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,46 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk/access/logging"
+)
+
+// WithLogger logs all Access API requests with the logger.
+//
+// Every request is logged with its method, URL, HTTP status, duration and request ID, which is sent to the access
+// node in the X-Request-ID header.
+func WithLogger(logger *logging.Logger) ClientOption {
+	return func(opts *options) {
+		opts.logger = logger
+	}
+}
+
+// enableLogging logs the requests of the client.
+func (c *BaseClient) enableLogging(logger *logging.Logger) error {
+	h, ok := c.handler.(*httpHandler)
+	if !ok {
+		return fmt.Errorf("logging is not supported by handler %T", c.handler)
+	}
+
+	h.logger = logger
+	return nil
+}
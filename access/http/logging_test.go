@@ -0,0 +1,102 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access/logging"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestWithLogger(t *testing.T) {
+	ctx := context.Background()
+
+	var requestIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestIDs = append(requestIDs, r.Header.Get(logging.RequestIDHeader))
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/transactions":
+			_, _ = w.Write([]byte(`{"id": "01"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code": 404, "message": "not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client, err := NewClient(server.URL+"/v1", WithLogger(logging.New(logger, logging.WithBodies(4096))))
+	require.NoError(t, err)
+
+	tx := test.TransactionGenerator().New()
+	require.NoError(t, client.SendTransaction(ctx, *tx))
+
+	_, err = client.GetTransaction(ctx, flow.Identifier{0x01})
+	require.Error(t, err)
+
+	var logged []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		logged = append(logged, record)
+	}
+	require.Len(t, logged, 2)
+	require.Len(t, requestIDs, 2)
+
+	t.Run("Request", func(t *testing.T) {
+		record := logged[0]
+		assert.Equal(t, "DEBUG", record["level"])
+		assert.Equal(t, "POST", record["method"])
+		assert.Equal(t, server.URL+"/v1/transactions", record["url"])
+		assert.Equal(t, float64(http.StatusOK), record["status"])
+		assert.Equal(t, requestIDs[0], record["request_id"])
+		assert.Equal(t, `{"id":"01"}`, record["response"])
+
+		request := record["request"].(string)
+		assert.Contains(t, request, tx.ReferenceBlockID.Hex())
+		assert.Contains(t, request, `"arguments":"[REDACTED]"`)
+		assert.Contains(t, request, `"signature":"[REDACTED]"`)
+		assert.NotContains(t, request, base64.StdEncoding.EncodeToString(tx.EnvelopeSignatures[0].Signature))
+	})
+
+	t.Run("Failed request", func(t *testing.T) {
+		record := logged[1]
+		assert.Equal(t, "ERROR", record["level"])
+		assert.Equal(t, "GET", record["method"])
+		assert.Equal(t, float64(http.StatusNotFound), record["status"])
+		assert.Equal(t, "HTTP status 404", record["error"])
+		assert.Equal(t, requestIDs[1], record["request_id"])
+		assert.Equal(t, `{"code":404,"message":"not found"}`, record["response"])
+	})
+}
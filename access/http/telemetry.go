@@ -0,0 +1,206 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/onflow/flow-go-sdk/access/internal/telemetry"
+)
+
+// transportErrorCode is the status code of requests which failed without a response.
+const transportErrorCode = "ERROR"
+
+type telemetryOptions struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// WithOpenTelemetry enables OpenTelemetry spans and metrics for all Access API calls.
+//
+// Every request creates a span with the route, e.g. "GET /blocks/{id}", the status code, the request and response
+// sizes, and the block height, block ID or transaction ID of the request if any. The metrics are the request
+// durations and the errors by status code.
+//
+// The global providers are used if the providers are nil.
+func WithOpenTelemetry(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) ClientOption {
+	return func(opts *options) {
+		opts.telemetry = &telemetryOptions{
+			tracerProvider: tracerProvider,
+			meterProvider:  meterProvider,
+		}
+	}
+}
+
+// enableTelemetry instruments the requests of the client.
+func (c *BaseClient) enableTelemetry(opts *telemetryOptions) error {
+	h, ok := c.handler.(*httpHandler)
+	if !ok {
+		return fmt.Errorf("telemetry is not supported by handler %T", c.handler)
+	}
+
+	base, err := url.Parse(h.base)
+	if err != nil {
+		return err
+	}
+
+	transport := h.client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	instrumented, err := newTelemetryTransport(transport, base.Path, opts)
+	if err != nil {
+		return err
+	}
+
+	client := *h.client
+	client.Transport = instrumented
+	h.client = &client
+
+	return nil
+}
+
+// telemetryTransport is an http.RoundTripper creating a span for every request.
+type telemetryTransport struct {
+	base            http.RoundTripper
+	basePath        string
+	instrumentation *telemetry.Instrumentation
+}
+
+func newTelemetryTransport(base http.RoundTripper, basePath string, opts *telemetryOptions) (*telemetryTransport, error) {
+	instrumentation, err := telemetry.New("http", opts.tracerProvider, opts.meterProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	return &telemetryTransport{
+		base:            base,
+		basePath:        strings.TrimSuffix(basePath, "/"),
+		instrumentation: instrumentation,
+	}, nil
+}
+
+func (t *telemetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resource, id := t.route(req.URL.Path)
+
+	name := fmt.Sprintf("%s /%s", req.Method, resource)
+	if id != "" {
+		name += "/{id}"
+	}
+
+	attrs := requestAttributes(resource, id, req)
+	ctx, call := t.instrumentation.Start(req.Context(), name, trace.SpanKindClient, attrs...)
+
+	res, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		call.End(ctx, transportErrorCode, err)
+		return nil, err
+	}
+
+	var statusErr error
+	if res.StatusCode >= http.StatusBadRequest {
+		statusErr = fmt.Errorf("HTTP status %d", res.StatusCode)
+	}
+
+	res.Body = &telemetryBody{
+		ReadCloser: res.Body,
+		end: func(size int) {
+			call.SetAttributes(telemetry.ResponseSizeKey.Int(size))
+			call.End(ctx, strconv.Itoa(res.StatusCode), statusErr)
+		},
+	}
+
+	return res, nil
+}
+
+// route returns the resource of a request path, e.g. "blocks", and the ID following it if any.
+func (t *telemetryTransport) route(path string) (resource string, id string) {
+	path = strings.TrimPrefix(path, t.basePath)
+	path = strings.Trim(path, "/")
+
+	resource, id, _ = strings.Cut(path, "/")
+	if resource == "network" {
+		return path, ""
+	}
+	return resource, id
+}
+
+// requestAttributes returns the block height, block ID, transaction ID or collection ID of a request, and its size.
+func requestAttributes(resource string, id string, req *http.Request) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if req.ContentLength > 0 {
+		attrs = append(attrs, telemetry.RequestSizeKey.Int64(req.ContentLength))
+	}
+
+	if id != "" {
+		switch resource {
+		case "blocks":
+			attrs = append(attrs, telemetry.BlockIDKey.String(id))
+		case "transactions", "transaction_results":
+			attrs = append(attrs, telemetry.TransactionIDKey.String(id))
+		case "collections":
+			attrs = append(attrs, telemetry.CollectionIDKey.String(id))
+		}
+	}
+
+	query := req.URL.Query()
+	if height := query.Get("height"); height != "" {
+		if h, err := strconv.ParseUint(height, 10, 64); err == nil {
+			attrs = append(attrs, telemetry.BlockHeightKey.Int64(int64(h)))
+		}
+	}
+	if blockID := query.Get("block_id"); blockID != "" {
+		attrs = append(attrs, telemetry.BlockIDKey.String(blockID))
+	}
+
+	return attrs
+}
+
+// telemetryBody counts the bytes read from a response body, and ends the span of the request when it is closed.
+type telemetryBody struct {
+	io.ReadCloser
+	size int
+	once sync.Once
+	end  func(size int)
+}
+
+func (b *telemetryBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.size += n
+	return n, err
+}
+
+func (b *telemetryBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() {
+		b.end(b.size)
+	})
+	return err
+}
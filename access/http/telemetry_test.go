@@ -0,0 +1,94 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+func TestWithOpenTelemetry(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/network/parameters":
+			_, _ = w.Write([]byte(`{"chain_id": "flow-emulator"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code": 404, "message": "not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	client, err := NewClient(server.URL+"/v1", WithOpenTelemetry(tracerProvider, meterProvider))
+	require.NoError(t, err)
+
+	params, err := client.GetNetworkParameters(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, flow.Emulator, params.ChainID)
+
+	_, err = client.GetTransaction(ctx, flow.Identifier{0x01})
+	require.Error(t, err)
+
+	ended := spans.Ended()
+	require.Len(t, ended, 2)
+
+	assert.Equal(t, "GET /network/parameters", ended[0].Name())
+	assert.Contains(t, ended[0].Attributes(), attribute.String("rpc.status_code", "200"))
+	assert.Contains(t, ended[0].Attributes(), attribute.Int("flow.response.size", 29))
+
+	assert.Equal(t, "GET /transactions/{id}", ended[1].Name())
+	assert.Contains(t, ended[1].Attributes(), attribute.String("rpc.status_code", "404"))
+	assert.Contains(t, ended[1].Attributes(), attribute.String("flow.transaction.id", flow.Identifier{0x01}.String()))
+
+	var data metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &data))
+
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+
+	errors := metrics["flow.access.client.errors"].(metricdata.Sum[int64])
+	require.Len(t, errors.DataPoints, 1)
+	assert.Equal(t, int64(1), errors.DataPoints[0].Value)
+
+	duration := metrics["flow.access.client.duration"].(metricdata.Histogram[float64])
+	assert.Len(t, duration.DataPoints, 2)
+}
@@ -0,0 +1,230 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package telemetry provides the OpenTelemetry spans and metrics shared by the access clients.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the spans and metrics.
+const ScopeName = "github.com/onflow/flow-go-sdk/access"
+
+// Attribute keys of spans and metrics.
+const (
+	SystemKey        = attribute.Key("rpc.system")
+	MethodKey        = attribute.Key("rpc.method")
+	StatusCodeKey    = attribute.Key("rpc.status_code")
+	BlockHeightKey   = attribute.Key("flow.block.height")
+	BlockIDKey       = attribute.Key("flow.block.id")
+	TransactionIDKey = attribute.Key("flow.transaction.id")
+	CollectionIDKey  = attribute.Key("flow.collection.id")
+	RequestSizeKey   = attribute.Key("flow.request.size")
+	ResponseSizeKey  = attribute.Key("flow.response.size")
+	MessageSizeKey   = attribute.Key("flow.message.size")
+)
+
+// Names of the metrics.
+const (
+	DurationMetric        = "flow.access.client.duration"
+	ErrorsMetric          = "flow.access.client.errors"
+	MessagesMetric        = "flow.access.client.subscription.messages"
+	SubscriptionLagMetric = "flow.access.client.subscription.lag"
+)
+
+// MessageEvent is the name of the span events of subscription messages.
+const MessageEvent = "message"
+
+// HeadSampleInterval is the minimum interval between two samples of the latest block height of the access node.
+const HeadSampleInterval = 10 * time.Second
+
+// Instrumentation creates spans and records metrics of Access API calls.
+type Instrumentation struct {
+	system   string
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+	errors   metric.Int64Counter
+	messages metric.Int64Counter
+	lag      metric.Int64Histogram
+	// head is the highest block height seen in any response, or sampled from the access node.
+	head atomic.Uint64
+	// sampled is the time of the last sample of the latest block height, in Unix nanoseconds.
+	sampled atomic.Int64
+}
+
+// New returns the instrumentation of a client of the system, e.g. "grpc".
+//
+// The global providers are used if the providers are nil.
+func New(system string, tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) (*Instrumentation, error) {
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	if meterProvider == nil {
+		meterProvider = otel.GetMeterProvider()
+	}
+
+	meter := meterProvider.Meter(ScopeName)
+
+	duration, err := meter.Float64Histogram(
+		DurationMetric,
+		metric.WithDescription("Duration of Access API calls."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metric %s: %w", DurationMetric, err)
+	}
+	errs, err := meter.Int64Counter(ErrorsMetric, metric.WithDescription("Number of failed Access API calls."))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metric %s: %w", ErrorsMetric, err)
+	}
+	messages, err := meter.Int64Counter(MessagesMetric, metric.WithDescription("Number of messages received by subscriptions."))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metric %s: %w", MessagesMetric, err)
+	}
+	lag, err := meter.Int64Histogram(
+		SubscriptionLagMetric,
+		metric.WithDescription("Number of blocks subscription messages are behind the latest block of the access node."),
+		metric.WithUnit("{block}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metric %s: %w", SubscriptionLagMetric, err)
+	}
+
+	return &Instrumentation{
+		system:   system,
+		tracer:   tracerProvider.Tracer(ScopeName),
+		duration: duration,
+		errors:   errs,
+		messages: messages,
+		lag:      lag,
+	}, nil
+}
+
+// Call is an Access API call in progress.
+type Call struct {
+	instrumentation *Instrumentation
+	span            trace.Span
+	method          string
+	start           time.Time
+}
+
+// Start starts the span of a call to the method.
+func (i *Instrumentation) Start(
+	ctx context.Context,
+	method string,
+	kind trace.SpanKind,
+	attrs ...attribute.KeyValue,
+) (context.Context, *Call) {
+	attrs = append(attrs, SystemKey.String(i.system), MethodKey.String(method))
+	ctx, span := i.tracer.Start(ctx, method, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+
+	return ctx, &Call{
+		instrumentation: i,
+		span:            span,
+		method:          method,
+		start:           time.Now(),
+	}
+}
+
+// SetAttributes sets attributes of the span of the call.
+func (c *Call) SetAttributes(attrs ...attribute.KeyValue) {
+	c.span.SetAttributes(attrs...)
+}
+
+// Message records a message received by a subscription, with the block height of the message if known.
+//
+// The lag of the message is measured against the highest block height seen or sampled with SampleHead.
+func (c *Call) Message(ctx context.Context, size int, height uint64, hasHeight bool) {
+	i := c.instrumentation
+	methodAttr := metric.WithAttributes(SystemKey.String(i.system), MethodKey.String(c.method))
+
+	attrs := []attribute.KeyValue{MessageSizeKey.Int(size)}
+	if hasHeight {
+		attrs = append(attrs, BlockHeightKey.Int64(int64(height)))
+
+		head := i.ObserveHeight(height)
+		i.lag.Record(ctx, int64(head-height), methodAttr)
+	}
+
+	c.span.AddEvent(MessageEvent, trace.WithAttributes(attrs...))
+	i.messages.Add(ctx, 1, methodAttr)
+}
+
+// End ends the span of the call, and records its duration and status code.
+func (c *Call) End(ctx context.Context, statusCode string, err error) {
+	i := c.instrumentation
+	attrs := metric.WithAttributes(
+		SystemKey.String(i.system),
+		MethodKey.String(c.method),
+		StatusCodeKey.String(statusCode),
+	)
+
+	c.span.SetAttributes(StatusCodeKey.String(statusCode))
+	if err != nil {
+		c.span.RecordError(err)
+		c.span.SetStatus(codes.Error, err.Error())
+		i.errors.Add(ctx, 1, attrs)
+	}
+	c.span.End()
+
+	i.duration.Record(ctx, time.Since(c.start).Seconds(), attrs)
+}
+
+// SampleHead observes the latest block height of the access node returned by sample, unless the height
+// was sampled less than HeadSampleInterval ago. Concurrent calls sample the height once.
+//
+// Failed samples are ignored, so the lag is then measured against the highest block height seen.
+func (i *Instrumentation) SampleHead(ctx context.Context, sample func(context.Context) (uint64, error)) {
+	now := time.Now().UnixNano()
+	last := i.sampled.Load()
+	if last != 0 && now-last < int64(HeadSampleInterval) {
+		return
+	}
+	if !i.sampled.CompareAndSwap(last, now) {
+		return
+	}
+
+	height, err := sample(ctx)
+	if err != nil {
+		return
+	}
+	i.ObserveHeight(height)
+}
+
+// ObserveHeight records a block height seen in a response, and returns the highest block height seen.
+func (i *Instrumentation) ObserveHeight(height uint64) uint64 {
+	for {
+		head := i.head.Load()
+		if height <= head {
+			return head
+		}
+		if i.head.CompareAndSwap(head, height) {
+			return height
+		}
+	}
+}
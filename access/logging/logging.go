@@ -0,0 +1,253 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package logging logs Access API calls of the access clients with log/slog.
+//
+// Every call is logged with a request ID, which is also sent to the access node, the method, the duration
+// and the error, if any:
+//
+//	client, err := grpc.NewClient(grpc.MainnetHost, grpc.WithLogger(logging.New(slog.Default(),
+//		logging.WithLevel(slog.LevelInfo),
+//		logging.WithBodies(4096),
+//	)))
+//
+// Request and response bodies are only logged if enabled with WithBodies. Signatures and transaction arguments
+// are redacted from bodies, and bodies are truncated to a maximum size.
+package logging
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// RequestIDHeader is the header, or gRPC metadata key, of the request ID sent to the access node.
+const RequestIDHeader = "X-Request-ID"
+
+// Redacted replaces the values of redacted fields.
+const Redacted = "[REDACTED]"
+
+// DefaultRedactedFields are the fields redacted from bodies by default: signatures, signature extension data
+// and transaction arguments.
+var DefaultRedactedFields = []string{"signature", "extension_data", "arguments"}
+
+// Logger logs Access API calls.
+type Logger struct {
+	logger     *slog.Logger
+	level      slog.Level
+	errorLevel slog.Level
+	maxBody    int
+	redacted   map[string]bool
+}
+
+// An Option configures a Logger.
+type Option func(*Logger)
+
+// WithLevel sets the level of successful calls, which defaults to slog.LevelDebug.
+func WithLevel(level slog.Level) Option {
+	return func(l *Logger) {
+		l.level = level
+	}
+}
+
+// WithErrorLevel sets the level of failed calls, which defaults to slog.LevelError.
+func WithErrorLevel(level slog.Level) Option {
+	return func(l *Logger) {
+		l.errorLevel = level
+	}
+}
+
+// WithBodies enables logging of request and response bodies, truncated to the maximum size in bytes.
+func WithBodies(maxSize int) Option {
+	return func(l *Logger) {
+		l.maxBody = maxSize
+	}
+}
+
+// WithRedactedFields redacts the fields from bodies, in addition to DefaultRedactedFields.
+//
+// Field names are matched case-insensitively and ignoring underscores, so "extension_data" matches
+// the "extensionData" field of gRPC messages.
+func WithRedactedFields(fields ...string) Option {
+	return func(l *Logger) {
+		for _, field := range fields {
+			l.redacted[normalizeField(field)] = true
+		}
+	}
+}
+
+// New returns a logger of Access API calls.
+func New(logger *slog.Logger, opts ...Option) *Logger {
+	l := &Logger{
+		logger:     logger,
+		level:      slog.LevelDebug,
+		errorLevel: slog.LevelError,
+		redacted:   make(map[string]bool),
+	}
+	for _, field := range DefaultRedactedFields {
+		l.redacted[normalizeField(field)] = true
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Call is an Access API call in progress.
+type Call struct {
+	logger    *Logger
+	ctx       context.Context
+	method    string
+	requestID string
+	start     time.Time
+	request   string
+	messages  int
+}
+
+// Start starts logging a call to the method, with the JSON encoded request body if any.
+//
+// Bodies are only encoded if they are logged, so they are passed as functions.
+func (l *Logger) Start(ctx context.Context, method string, body func() []byte) *Call {
+	call := &Call{
+		logger:    l,
+		ctx:       ctx,
+		method:    method,
+		requestID: newRequestID(),
+		start:     time.Now(),
+	}
+	call.Request(body)
+	return call
+}
+
+// RequestID returns the request ID of the call.
+func (c *Call) RequestID() string {
+	return c.requestID
+}
+
+// Request records the JSON encoded request body of the call, e.g. the request sent on a stream.
+func (c *Call) Request(body func() []byte) {
+	if c.logger.maxBody > 0 && body != nil {
+		c.request = c.logger.Body(body())
+	}
+}
+
+// Message logs a message received by a subscription, with its JSON encoded body.
+// Messages are only logged if bodies are logged.
+func (c *Call) Message(body func() []byte) {
+	c.messages++
+
+	l := c.logger
+	if l.maxBody == 0 || body == nil || !l.logger.Enabled(c.ctx, l.level) {
+		return
+	}
+
+	l.logger.LogAttrs(c.ctx, l.level, "access API message",
+		slog.String("request_id", c.requestID),
+		slog.String("method", c.method),
+		slog.Int("message", c.messages),
+		slog.String("body", l.Body(body())),
+	)
+}
+
+// End logs the call with the JSON encoded response body if any, the error of the call, and additional attributes.
+func (c *Call) End(body func() []byte, err error, attrs ...slog.Attr) {
+	l := c.logger
+
+	level := l.level
+	if err != nil {
+		level = l.errorLevel
+	}
+	if !l.logger.Enabled(c.ctx, level) {
+		return
+	}
+
+	all := []slog.Attr{
+		slog.String("request_id", c.requestID),
+		slog.String("method", c.method),
+		slog.Duration("duration", time.Since(c.start)),
+	}
+	all = append(all, attrs...)
+	if c.messages > 0 {
+		all = append(all, slog.Int("messages", c.messages))
+	}
+	if c.request != "" {
+		all = append(all, slog.String("request", c.request))
+	}
+	if l.maxBody > 0 && body != nil {
+		all = append(all, slog.String("response", l.Body(body())))
+	}
+
+	msg := "access API call"
+	if err != nil {
+		msg = "access API call failed"
+		all = append(all, slog.String("error", err.Error()))
+	}
+	l.logger.LogAttrs(c.ctx, level, msg, all...)
+}
+
+// Body returns a JSON body for logging, with the redacted fields replaced and truncated to the maximum size.
+// Bodies which are not JSON are only truncated.
+func (l *Logger) Body(body []byte) string {
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err == nil {
+		if redacted, err := json.Marshal(l.redact(value)); err == nil {
+			body = redacted
+		}
+	}
+
+	if l.maxBody > 0 && len(body) > l.maxBody {
+		return fmt.Sprintf("%s...(truncated %d bytes)", body[:l.maxBody], len(body)-l.maxBody)
+	}
+	return string(body)
+}
+
+func (l *Logger) redact(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if l.redacted[normalizeField(key)] {
+				v[key] = Redacted
+			} else {
+				v[key] = l.redact(field)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = l.redact(item)
+		}
+	}
+	return value
+}
+
+func normalizeField(field string) string {
+	return strings.ToLower(strings.ReplaceAll(field, "_", ""))
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
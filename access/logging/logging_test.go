@@ -0,0 +1,139 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk/access/logging"
+)
+
+// records returns the records logged as JSON to the buffer.
+func records(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func body(s string) func() []byte {
+	return func() []byte { return []byte(s) }
+}
+
+func TestLogger_Body(t *testing.T) {
+	t.Run("Redacted", func(t *testing.T) {
+		logger := logging.New(slog.Default())
+
+		redacted := logger.Body([]byte(`{
+			"script": "transaction {}",
+			"arguments": ["eyJ0eXBlIjoiSW50In0="],
+			"envelope_signatures": [{"address": "01", "key_index": "0", "signature": "c2ln", "extension_data": "AQ=="}]
+		}`))
+
+		assert.JSONEq(t, `{
+			"script": "transaction {}",
+			"arguments": "[REDACTED]",
+			"envelope_signatures": [{"address": "01", "key_index": "0", "signature": "[REDACTED]", "extension_data": "[REDACTED]"}]
+		}`, redacted)
+	})
+
+	t.Run("Redacted fields of gRPC messages", func(t *testing.T) {
+		logger := logging.New(slog.Default(), logging.WithRedactedFields("proposal_key"))
+
+		redacted := logger.Body([]byte(`{"proposalKey": {"keyId": 1}, "payloadSignatures": [{"signature": "c2ln", "extensionData": "AQ=="}]}`))
+
+		assert.JSONEq(t, `{"proposalKey": "[REDACTED]", "payloadSignatures": [{"signature": "[REDACTED]", "extensionData": "[REDACTED]"}]}`, redacted)
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		logger := logging.New(slog.Default(), logging.WithBodies(10))
+
+		assert.Equal(t, `{"id":"012...(truncated 3 bytes)`, logger.Body([]byte(`{"id": "0123"}`)))
+		assert.Equal(t, "not found", logger.Body([]byte("not found")))
+	})
+}
+
+func TestLogger_Call(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Levels", func(t *testing.T) {
+		var buf bytes.Buffer
+		handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+		logger := logging.New(slog.New(handler))
+
+		logger.Start(ctx, "GetLatestBlock", nil).End(nil, nil)
+		logger.Start(ctx, "GetAccount", nil).End(nil, errors.New("not found"))
+
+		logged := records(t, &buf)
+		require.Len(t, logged, 1)
+		assert.Equal(t, "ERROR", logged[0]["level"])
+		assert.Equal(t, "access API call failed", logged[0]["msg"])
+		assert.Equal(t, "GetAccount", logged[0]["method"])
+		assert.Equal(t, "not found", logged[0]["error"])
+		assert.Len(t, logged[0]["request_id"], 16)
+		assert.NotContains(t, logged[0], "request")
+	})
+
+	t.Run("Custom levels", func(t *testing.T) {
+		var buf bytes.Buffer
+		handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+		logger := logging.New(slog.New(handler), logging.WithLevel(slog.LevelInfo), logging.WithErrorLevel(slog.LevelWarn))
+
+		logger.Start(ctx, "GetLatestBlock", nil).End(nil, nil)
+		logger.Start(ctx, "GetAccount", nil).End(nil, errors.New("not found"))
+
+		logged := records(t, &buf)
+		require.Len(t, logged, 2)
+		assert.Equal(t, "INFO", logged[0]["level"])
+		assert.Equal(t, "WARN", logged[1]["level"])
+	})
+
+	t.Run("Bodies", func(t *testing.T) {
+		var buf bytes.Buffer
+		handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+		logger := logging.New(slog.New(handler), logging.WithBodies(1024))
+
+		call := logger.Start(ctx, "SubscribeEvents", body(`{"startHeight": "1"}`))
+		call.Message(body(`{"events": []}`))
+		call.Message(body(`{"events": []}`))
+		call.End(nil, nil)
+
+		logged := records(t, &buf)
+		require.Len(t, logged, 3)
+		assert.Equal(t, "access API message", logged[0]["msg"])
+		assert.Equal(t, `{"events":[]}`, logged[0]["body"])
+		assert.Equal(t, call.RequestID(), logged[1]["request_id"])
+		assert.Equal(t, `{"startHeight":"1"}`, logged[2]["request"])
+		assert.Equal(t, float64(2), logged[2]["messages"])
+	})
+}
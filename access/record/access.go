@@ -0,0 +1,911 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package record
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/onflow/flow/protobuf/go/flow/executiondata"
+	"google.golang.org/protobuf/protoadapt"
+
+	"github.com/onflow/flow-go-sdk"
+	base "github.com/onflow/flow-go-sdk/access"
+	"github.com/onflow/flow-go-sdk/access/grpc/convert"
+)
+
+// unary records or replays a call of the method with the request.
+func unary[T any](
+	c *Client,
+	method string,
+	request protoadapt.MessageV1,
+	codec codec[T],
+	invoke func() (T, error),
+) (T, error) {
+	var empty T
+
+	req, err := marshal(request)
+	if err != nil {
+		return empty, fmt.Errorf("record: failed to encode %s request: %w", method, err)
+	}
+
+	if c.recording() {
+		call := &Call{Method: method, Request: req}
+
+		res, err := invoke()
+		if err != nil {
+			call.Error = newError(err)
+			c.record(call)
+			return res, err
+		}
+
+		call.Response, err = codec.encode(res)
+		if err != nil {
+			return empty, fmt.Errorf("record: failed to encode %s response: %w", method, err)
+		}
+		c.record(call)
+		return res, nil
+	}
+
+	call, err := c.next(method, req)
+	if err != nil {
+		return empty, err
+	}
+	if call.Error != nil {
+		return empty, call.Error
+	}
+
+	res, err := codec.decode(call.Response)
+	if err != nil {
+		return empty, fmt.Errorf("record: failed to decode %s response: %w", method, err)
+	}
+	return res, nil
+}
+
+// subscribe records or replays a subscription of the method with the request.
+func subscribe[T any](
+	ctx context.Context,
+	c *Client,
+	method string,
+	request protoadapt.MessageV1,
+	codec codec[T],
+	invoke func() (<-chan T, <-chan error, error),
+) (<-chan T, <-chan error, error) {
+	req, err := marshal(request)
+	if err != nil {
+		return nil, nil, fmt.Errorf("record: failed to encode %s request: %w", method, err)
+	}
+
+	if c.recording() {
+		call := &Call{Method: method, Request: req}
+
+		sub, errs, err := invoke()
+		if err != nil {
+			call.Error = newError(err)
+			c.record(call)
+			return nil, nil, err
+		}
+
+		c.record(call)
+		return recordStream(ctx, c, call, codec, sub, errs)
+	}
+
+	call, err := c.next(method, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if call.Error != nil {
+		return nil, nil, call.Error
+	}
+
+	return replayStream(ctx, call, codec)
+}
+
+// recordStream forwards the messages and errors of a subscription, and records them.
+func recordStream[T any](
+	ctx context.Context,
+	c *Client,
+	call *Call,
+	codec codec[T],
+	sub <-chan T,
+	errs <-chan error,
+) (<-chan T, <-chan error, error) {
+	forwardedSub := make(chan T)
+	forwardedErrs := make(chan error)
+
+	go func() {
+		defer close(forwardedSub)
+		defer close(forwardedErrs)
+
+		for sub != nil || errs != nil {
+			select {
+			case <-ctx.Done():
+				return
+
+			case message, ok := <-sub:
+				if !ok {
+					sub = nil
+					continue
+				}
+
+				data, err := codec.encode(message)
+				if err != nil {
+					err = fmt.Errorf("record: failed to encode %s message: %w", call.Method, err)
+					c.update(func() { call.StreamError = newError(err) })
+					select {
+					case <-ctx.Done():
+					case forwardedErrs <- err:
+					}
+					return
+				}
+				c.update(func() { call.Messages = append(call.Messages, data) })
+
+				select {
+				case <-ctx.Done():
+					return
+				case forwardedSub <- message:
+				}
+
+			case err, ok := <-errs:
+				if !ok {
+					errs = nil
+					continue
+				}
+
+				c.update(func() { call.StreamError = newError(err) })
+				select {
+				case <-ctx.Done():
+					return
+				case forwardedErrs <- err:
+				}
+			}
+		}
+	}()
+
+	return forwardedSub, forwardedErrs, nil
+}
+
+// replayStream sends the recorded messages of a subscription, then the error ending the stream if any, and closes
+// the channels once the messages are sent or the context is done.
+func replayStream[T any](
+	ctx context.Context,
+	call *Call,
+	codec codec[T],
+) (<-chan T, <-chan error, error) {
+	sub := make(chan T)
+	errs := make(chan error)
+
+	sendErr := func(err error) {
+		select {
+		case <-ctx.Done():
+		case errs <- err:
+		}
+	}
+
+	go func() {
+		defer close(sub)
+		defer close(errs)
+
+		for _, data := range call.Messages {
+			message, err := codec.decode(data)
+			if err != nil {
+				sendErr(fmt.Errorf("record: failed to decode %s message: %w", call.Method, err))
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case sub <- message:
+			}
+		}
+
+		if call.StreamError != nil {
+			sendErr(call.StreamError)
+		}
+	}()
+
+	return sub, errs, nil
+}
+
+func transactionMessage(tx flow.Transaction) (*entities.Transaction, error) {
+	m, err := convert.TransactionToMessage(tx)
+	if err != nil {
+		return nil, fmt.Errorf("record: failed to convert transaction: %w", err)
+	}
+	return m, nil
+}
+
+func argumentMessages(arguments []cadence.Value) ([][]byte, error) {
+	m, err := convert.CadenceValuesToMessages(arguments, encoding)
+	if err != nil {
+		return nil, fmt.Errorf("record: failed to convert script arguments: %w", err)
+	}
+	return m, nil
+}
+
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := unary(c, "Ping", &access.PingRequest{}, noResponse, func() (struct{}, error) {
+		return struct{}{}, c.client.Ping(ctx)
+	})
+	return err
+}
+
+func (c *Client) GetNetworkParameters(ctx context.Context) (*flow.NetworkParameters, error) {
+	return unary(c, "GetNetworkParameters", &access.GetNetworkParametersRequest{}, networkParametersCodec,
+		func() (*flow.NetworkParameters, error) {
+			return c.client.GetNetworkParameters(ctx)
+		})
+}
+
+func (c *Client) GetNodeVersionInfo(ctx context.Context) (*flow.NodeVersionInfo, error) {
+	return unary(c, "GetNodeVersionInfo", &access.GetNodeVersionInfoRequest{}, nodeVersionInfoCodec,
+		func() (*flow.NodeVersionInfo, error) {
+			return c.client.GetNodeVersionInfo(ctx)
+		})
+}
+
+func (c *Client) GetLatestBlockHeader(ctx context.Context, isSealed bool) (*flow.BlockHeader, error) {
+	request := &access.GetLatestBlockHeaderRequest{IsSealed: isSealed}
+	return unary(c, "GetLatestBlockHeader", request, blockHeaderCodec, func() (*flow.BlockHeader, error) {
+		return c.client.GetLatestBlockHeader(ctx, isSealed)
+	})
+}
+
+func (c *Client) GetBlockHeaderByID(ctx context.Context, blockID flow.Identifier) (*flow.BlockHeader, error) {
+	request := &access.GetBlockHeaderByIDRequest{Id: convert.IdentifierToMessage(blockID)}
+	return unary(c, "GetBlockHeaderByID", request, blockHeaderCodec, func() (*flow.BlockHeader, error) {
+		return c.client.GetBlockHeaderByID(ctx, blockID)
+	})
+}
+
+func (c *Client) GetBlockHeaderByHeight(ctx context.Context, height uint64) (*flow.BlockHeader, error) {
+	request := &access.GetBlockHeaderByHeightRequest{Height: height}
+	return unary(c, "GetBlockHeaderByHeight", request, blockHeaderCodec, func() (*flow.BlockHeader, error) {
+		return c.client.GetBlockHeaderByHeight(ctx, height)
+	})
+}
+
+func (c *Client) GetLatestBlock(ctx context.Context, isSealed bool) (*flow.Block, error) {
+	request := &access.GetLatestBlockRequest{IsSealed: isSealed}
+	return unary(c, "GetLatestBlock", request, blockCodec, func() (*flow.Block, error) {
+		return c.client.GetLatestBlock(ctx, isSealed)
+	})
+}
+
+func (c *Client) GetBlockByID(ctx context.Context, blockID flow.Identifier) (*flow.Block, error) {
+	request := &access.GetBlockByIDRequest{Id: convert.IdentifierToMessage(blockID)}
+	return unary(c, "GetBlockByID", request, blockCodec, func() (*flow.Block, error) {
+		return c.client.GetBlockByID(ctx, blockID)
+	})
+}
+
+func (c *Client) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
+	request := &access.GetBlockByHeightRequest{Height: height}
+	return unary(c, "GetBlockByHeight", request, blockCodec, func() (*flow.Block, error) {
+		return c.client.GetBlockByHeight(ctx, height)
+	})
+}
+
+func (c *Client) GetCollection(ctx context.Context, colID flow.Identifier) (*flow.Collection, error) {
+	request := &access.GetCollectionByIDRequest{Id: convert.IdentifierToMessage(colID)}
+	return unary(c, "GetCollection", request, collectionCodec, func() (*flow.Collection, error) {
+		return c.client.GetCollection(ctx, colID)
+	})
+}
+
+func (c *Client) GetCollectionByID(ctx context.Context, id flow.Identifier) (*flow.Collection, error) {
+	request := &access.GetCollectionByIDRequest{Id: convert.IdentifierToMessage(id)}
+	return unary(c, "GetCollectionByID", request, collectionCodec, func() (*flow.Collection, error) {
+		return c.client.GetCollectionByID(ctx, id)
+	})
+}
+
+func (c *Client) GetFullCollectionByID(ctx context.Context, id flow.Identifier) (*flow.FullCollection, error) {
+	request := &access.GetFullCollectionByIDRequest{Id: convert.IdentifierToMessage(id)}
+	return unary(c, "GetFullCollectionByID", request, fullCollectionCodec, func() (*flow.FullCollection, error) {
+		return c.client.GetFullCollectionByID(ctx, id)
+	})
+}
+
+func (c *Client) SendTransaction(ctx context.Context, tx flow.Transaction) error {
+	txMsg, err := transactionMessage(tx)
+	if err != nil {
+		return err
+	}
+
+	request := &access.SendTransactionRequest{Transaction: txMsg}
+	_, err = unary(c, "SendTransaction", request, noResponse, func() (struct{}, error) {
+		return struct{}{}, c.client.SendTransaction(ctx, tx)
+	})
+	return err
+}
+
+func (c *Client) GetTransaction(ctx context.Context, txID flow.Identifier) (*flow.Transaction, error) {
+	request := &access.GetTransactionRequest{Id: convert.IdentifierToMessage(txID)}
+	return unary(c, "GetTransaction", request, transactionCodec, func() (*flow.Transaction, error) {
+		return c.client.GetTransaction(ctx, txID)
+	})
+}
+
+func (c *Client) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	request := &access.GetTransactionsByBlockIDRequest{BlockId: convert.IdentifierToMessage(blockID)}
+	return unary(c, "GetTransactionsByBlockID", request, transactionsCodec, func() ([]*flow.Transaction, error) {
+		return c.client.GetTransactionsByBlockID(ctx, blockID)
+	})
+}
+
+func (c *Client) GetTransactionResult(ctx context.Context, txID flow.Identifier) (*flow.TransactionResult, error) {
+	request := &access.GetTransactionRequest{Id: convert.IdentifierToMessage(txID)}
+	return unary(c, "GetTransactionResult", request, transactionResultCodec, func() (*flow.TransactionResult, error) {
+		return c.client.GetTransactionResult(ctx, txID)
+	})
+}
+
+func (c *Client) GetTransactionResultByIndex(
+	ctx context.Context,
+	blockID flow.Identifier,
+	index uint32,
+) (*flow.TransactionResult, error) {
+	request := &access.GetTransactionByIndexRequest{BlockId: convert.IdentifierToMessage(blockID), Index: index}
+	return unary(c, "GetTransactionResultByIndex", request, transactionResultCodec,
+		func() (*flow.TransactionResult, error) {
+			return c.client.GetTransactionResultByIndex(ctx, blockID, index)
+		})
+}
+
+func (c *Client) GetTransactionResultsByBlockID(
+	ctx context.Context,
+	blockID flow.Identifier,
+) ([]*flow.TransactionResult, error) {
+	request := &access.GetTransactionsByBlockIDRequest{BlockId: convert.IdentifierToMessage(blockID)}
+	return unary(c, "GetTransactionResultsByBlockID", request, transactionResultsCodec,
+		func() ([]*flow.TransactionResult, error) {
+			return c.client.GetTransactionResultsByBlockID(ctx, blockID)
+		})
+}
+
+func (c *Client) GetScheduledTransaction(ctx context.Context, scheduledTxID uint64) (*flow.Transaction, error) {
+	request := &access.GetScheduledTransactionRequest{Id: scheduledTxID}
+	return unary(c, "GetScheduledTransaction", request, transactionCodec, func() (*flow.Transaction, error) {
+		return c.client.GetScheduledTransaction(ctx, scheduledTxID)
+	})
+}
+
+func (c *Client) GetScheduledTransactionResult(
+	ctx context.Context,
+	scheduledTxID uint64,
+) (*flow.TransactionResult, error) {
+	request := &access.GetScheduledTransactionResultRequest{Id: scheduledTxID}
+	return unary(c, "GetScheduledTransactionResult", request, transactionResultCodec,
+		func() (*flow.TransactionResult, error) {
+			return c.client.GetScheduledTransactionResult(ctx, scheduledTxID)
+		})
+}
+
+func (c *Client) GetSystemTransaction(ctx context.Context, blockID flow.Identifier) (*flow.Transaction, error) {
+	request := &access.GetSystemTransactionRequest{BlockId: convert.IdentifierToMessage(blockID)}
+	return unary(c, "GetSystemTransaction", request, transactionCodec, func() (*flow.Transaction, error) {
+		return c.client.GetSystemTransaction(ctx, blockID)
+	})
+}
+
+func (c *Client) GetSystemTransactionWithID(
+	ctx context.Context,
+	blockID flow.Identifier,
+	systemTxID flow.Identifier,
+) (*flow.Transaction, error) {
+	request := &access.GetSystemTransactionRequest{
+		BlockId: convert.IdentifierToMessage(blockID),
+		Id:      convert.IdentifierToMessage(systemTxID),
+	}
+	return unary(c, "GetSystemTransactionWithID", request, transactionCodec, func() (*flow.Transaction, error) {
+		return c.client.GetSystemTransactionWithID(ctx, blockID, systemTxID)
+	})
+}
+
+func (c *Client) GetSystemTransactionResult(
+	ctx context.Context,
+	blockID flow.Identifier,
+) (*flow.TransactionResult, error) {
+	request := &access.GetSystemTransactionResultRequest{BlockId: convert.IdentifierToMessage(blockID)}
+	return unary(c, "GetSystemTransactionResult", request, transactionResultCodec,
+		func() (*flow.TransactionResult, error) {
+			return c.client.GetSystemTransactionResult(ctx, blockID)
+		})
+}
+
+func (c *Client) GetSystemTransactionResultWithID(
+	ctx context.Context,
+	blockID flow.Identifier,
+	systemTxID flow.Identifier,
+) (*flow.TransactionResult, error) {
+	request := &access.GetSystemTransactionResultRequest{
+		BlockId: convert.IdentifierToMessage(blockID),
+		Id:      convert.IdentifierToMessage(systemTxID),
+	}
+	return unary(c, "GetSystemTransactionResultWithID", request, transactionResultCodec,
+		func() (*flow.TransactionResult, error) {
+			return c.client.GetSystemTransactionResultWithID(ctx, blockID, systemTxID)
+		})
+}
+
+func (c *Client) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	request := &access.GetAccountRequest{Address: address.Bytes()}
+	return unary(c, "GetAccount", request, accountCodec, func() (*flow.Account, error) {
+		return c.client.GetAccount(ctx, address)
+	})
+}
+
+func (c *Client) GetAccountAtLatestBlock(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	request := &access.GetAccountAtLatestBlockRequest{Address: address.Bytes()}
+	return unary(c, "GetAccountAtLatestBlock", request, accountCodec, func() (*flow.Account, error) {
+		return c.client.GetAccountAtLatestBlock(ctx, address)
+	})
+}
+
+func (c *Client) GetAccountAtBlockHeight(
+	ctx context.Context,
+	address flow.Address,
+	blockHeight uint64,
+) (*flow.Account, error) {
+	request := &access.GetAccountAtBlockHeightRequest{Address: address.Bytes(), BlockHeight: blockHeight}
+	return unary(c, "GetAccountAtBlockHeight", request, accountCodec, func() (*flow.Account, error) {
+		return c.client.GetAccountAtBlockHeight(ctx, address, blockHeight)
+	})
+}
+
+func (c *Client) GetAccountBalanceAtLatestBlock(ctx context.Context, address flow.Address) (uint64, error) {
+	request := &access.GetAccountBalanceAtLatestBlockRequest{Address: address.Bytes()}
+	return unary(c, "GetAccountBalanceAtLatestBlock", request, balanceCodec, func() (uint64, error) {
+		return c.client.GetAccountBalanceAtLatestBlock(ctx, address)
+	})
+}
+
+func (c *Client) GetAccountBalanceAtBlockHeight(
+	ctx context.Context,
+	address flow.Address,
+	blockHeight uint64,
+) (uint64, error) {
+	request := &access.GetAccountBalanceAtBlockHeightRequest{Address: address.Bytes(), BlockHeight: blockHeight}
+	return unary(c, "GetAccountBalanceAtBlockHeight", request, balanceCodec, func() (uint64, error) {
+		return c.client.GetAccountBalanceAtBlockHeight(ctx, address, blockHeight)
+	})
+}
+
+func (c *Client) GetAccountKeyAtLatestBlock(
+	ctx context.Context,
+	address flow.Address,
+	keyIndex uint32,
+) (*flow.AccountKey, error) {
+	request := &access.GetAccountKeyAtLatestBlockRequest{Address: address.Bytes(), Index: keyIndex}
+	return unary(c, "GetAccountKeyAtLatestBlock", request, accountKeyCodec, func() (*flow.AccountKey, error) {
+		return c.client.GetAccountKeyAtLatestBlock(ctx, address, keyIndex)
+	})
+}
+
+func (c *Client) GetAccountKeyAtBlockHeight(
+	ctx context.Context,
+	address flow.Address,
+	keyIndex uint32,
+	height uint64,
+) (*flow.AccountKey, error) {
+	request := &access.GetAccountKeyAtBlockHeightRequest{Address: address.Bytes(), Index: keyIndex, BlockHeight: height}
+	return unary(c, "GetAccountKeyAtBlockHeight", request, accountKeyCodec, func() (*flow.AccountKey, error) {
+		return c.client.GetAccountKeyAtBlockHeight(ctx, address, keyIndex, height)
+	})
+}
+
+func (c *Client) GetAccountKeysAtLatestBlock(ctx context.Context, address flow.Address) ([]*flow.AccountKey, error) {
+	request := &access.GetAccountKeysAtLatestBlockRequest{Address: address.Bytes()}
+	return unary(c, "GetAccountKeysAtLatestBlock", request, accountKeysCodec, func() ([]*flow.AccountKey, error) {
+		return c.client.GetAccountKeysAtLatestBlock(ctx, address)
+	})
+}
+
+func (c *Client) GetAccountKeysAtBlockHeight(
+	ctx context.Context,
+	address flow.Address,
+	height uint64,
+) ([]*flow.AccountKey, error) {
+	request := &access.GetAccountKeysAtBlockHeightRequest{Address: address.Bytes(), BlockHeight: height}
+	return unary(c, "GetAccountKeysAtBlockHeight", request, accountKeysCodec, func() ([]*flow.AccountKey, error) {
+		return c.client.GetAccountKeysAtBlockHeight(ctx, address, height)
+	})
+}
+
+func (c *Client) ExecuteScriptAtLatestBlock(
+	ctx context.Context,
+	script []byte,
+	arguments []cadence.Value,
+) (cadence.Value, error) {
+	args, err := argumentMessages(arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &access.ExecuteScriptAtLatestBlockRequest{Script: script, Arguments: args}
+	return unary(c, "ExecuteScriptAtLatestBlock", request, cadenceValueCodec, func() (cadence.Value, error) {
+		return c.client.ExecuteScriptAtLatestBlock(ctx, script, arguments)
+	})
+}
+
+func (c *Client) ExecuteScriptAtBlockID(
+	ctx context.Context,
+	blockID flow.Identifier,
+	script []byte,
+	arguments []cadence.Value,
+) (cadence.Value, error) {
+	args, err := argumentMessages(arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &access.ExecuteScriptAtBlockIDRequest{
+		BlockId:   convert.IdentifierToMessage(blockID),
+		Script:    script,
+		Arguments: args,
+	}
+	return unary(c, "ExecuteScriptAtBlockID", request, cadenceValueCodec, func() (cadence.Value, error) {
+		return c.client.ExecuteScriptAtBlockID(ctx, blockID, script, arguments)
+	})
+}
+
+func (c *Client) ExecuteScriptAtBlockHeight(
+	ctx context.Context,
+	height uint64,
+	script []byte,
+	arguments []cadence.Value,
+) (cadence.Value, error) {
+	args, err := argumentMessages(arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &access.ExecuteScriptAtBlockHeightRequest{BlockHeight: height, Script: script, Arguments: args}
+	return unary(c, "ExecuteScriptAtBlockHeight", request, cadenceValueCodec, func() (cadence.Value, error) {
+		return c.client.ExecuteScriptAtBlockHeight(ctx, height, script, arguments)
+	})
+}
+
+func (c *Client) GetEventsForHeightRange(
+	ctx context.Context,
+	eventType string,
+	startHeight uint64,
+	endHeight uint64,
+) ([]flow.BlockEvents, error) {
+	request := &access.GetEventsForHeightRangeRequest{Type: eventType, StartHeight: startHeight, EndHeight: endHeight}
+	return unary(c, "GetEventsForHeightRange", request, blockEventsCodec, func() ([]flow.BlockEvents, error) {
+		return c.client.GetEventsForHeightRange(ctx, eventType, startHeight, endHeight)
+	})
+}
+
+func (c *Client) GetEventsForBlockIDs(
+	ctx context.Context,
+	eventType string,
+	blockIDs []flow.Identifier,
+) ([]flow.BlockEvents, error) {
+	request := &access.GetEventsForBlockIDsRequest{Type: eventType, BlockIds: convert.IdentifiersToMessages(blockIDs)}
+	return unary(c, "GetEventsForBlockIDs", request, blockEventsCodec, func() ([]flow.BlockEvents, error) {
+		return c.client.GetEventsForBlockIDs(ctx, eventType, blockIDs)
+	})
+}
+
+func (c *Client) GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error) {
+	request := &access.GetLatestProtocolStateSnapshotRequest{}
+	return unary(c, "GetLatestProtocolStateSnapshot", request, snapshotCodec, func() ([]byte, error) {
+		return c.client.GetLatestProtocolStateSnapshot(ctx)
+	})
+}
+
+func (c *Client) GetProtocolStateSnapshotByBlockID(ctx context.Context, blockID flow.Identifier) ([]byte, error) {
+	request := &access.GetProtocolStateSnapshotByBlockIDRequest{BlockId: convert.IdentifierToMessage(blockID)}
+	return unary(c, "GetProtocolStateSnapshotByBlockID", request, snapshotCodec, func() ([]byte, error) {
+		return c.client.GetProtocolStateSnapshotByBlockID(ctx, blockID)
+	})
+}
+
+func (c *Client) GetProtocolStateSnapshotByHeight(ctx context.Context, blockHeight uint64) ([]byte, error) {
+	request := &access.GetProtocolStateSnapshotByHeightRequest{BlockHeight: blockHeight}
+	return unary(c, "GetProtocolStateSnapshotByHeight", request, snapshotCodec, func() ([]byte, error) {
+		return c.client.GetProtocolStateSnapshotByHeight(ctx, blockHeight)
+	})
+}
+
+func (c *Client) GetExecutionResultByID(ctx context.Context, id flow.Identifier) (*flow.ExecutionResult, error) {
+	request := &access.GetExecutionResultByIDRequest{Id: convert.IdentifierToMessage(id)}
+	return unary(c, "GetExecutionResultByID", request, executionResultCodec, func() (*flow.ExecutionResult, error) {
+		return c.client.GetExecutionResultByID(ctx, id)
+	})
+}
+
+func (c *Client) GetExecutionResultForBlockID(
+	ctx context.Context,
+	blockID flow.Identifier,
+) (*flow.ExecutionResult, error) {
+	request := &access.GetExecutionResultForBlockIDRequest{BlockId: convert.IdentifierToMessage(blockID)}
+	return unary(c, "GetExecutionResultForBlockID", request, executionResultCodec,
+		func() (*flow.ExecutionResult, error) {
+			return c.client.GetExecutionResultForBlockID(ctx, blockID)
+		})
+}
+
+func (c *Client) GetExecutionDataByBlockID(ctx context.Context, blockID flow.Identifier) (*flow.ExecutionData, error) {
+	request := &executiondata.GetExecutionDataByBlockIDRequest{BlockId: convert.IdentifierToMessage(blockID)}
+	return unary(c, "GetExecutionDataByBlockID", request, executionDataCodec, func() (*flow.ExecutionData, error) {
+		return c.client.GetExecutionDataByBlockID(ctx, blockID)
+	})
+}
+
+func (c *Client) SubscribeExecutionDataByBlockID(
+	ctx context.Context,
+	startBlockID flow.Identifier,
+) (<-chan *flow.ExecutionDataStreamResponse, <-chan error, error) {
+	request := &executiondata.SubscribeExecutionDataRequest{StartBlockId: convert.IdentifierToMessage(startBlockID)}
+	return subscribe(ctx, c, "SubscribeExecutionDataByBlockID", request, executionDataResponseCodec,
+		func() (<-chan *flow.ExecutionDataStreamResponse, <-chan error, error) {
+			return c.client.SubscribeExecutionDataByBlockID(ctx, startBlockID)
+		})
+}
+
+func (c *Client) SubscribeExecutionDataByBlockHeight(
+	ctx context.Context,
+	startHeight uint64,
+) (<-chan *flow.ExecutionDataStreamResponse, <-chan error, error) {
+	request := &executiondata.SubscribeExecutionDataRequest{StartBlockHeight: startHeight}
+	return subscribe(ctx, c, "SubscribeExecutionDataByBlockHeight", request, executionDataResponseCodec,
+		func() (<-chan *flow.ExecutionDataStreamResponse, <-chan error, error) {
+			return c.client.SubscribeExecutionDataByBlockHeight(ctx, startHeight)
+		})
+}
+
+func (c *Client) SubscribeEventsByBlockID(
+	ctx context.Context,
+	startBlockID flow.Identifier,
+	filter flow.EventFilter,
+	opts ...base.SubscribeOption,
+) (<-chan flow.BlockEvents, <-chan error, error) {
+	request := eventsRequest(filter, opts)
+	request.StartBlockId = convert.IdentifierToMessage(startBlockID)
+	return subscribe(ctx, c, "SubscribeEventsByBlockID", request, blockEventsMessageCodec,
+		func() (<-chan flow.BlockEvents, <-chan error, error) {
+			return c.client.SubscribeEventsByBlockID(ctx, startBlockID, filter, opts...)
+		})
+}
+
+func (c *Client) SubscribeEventsByBlockHeight(
+	ctx context.Context,
+	startHeight uint64,
+	filter flow.EventFilter,
+	opts ...base.SubscribeOption,
+) (<-chan flow.BlockEvents, <-chan error, error) {
+	request := eventsRequest(filter, opts)
+	request.StartBlockHeight = startHeight
+	return subscribe(ctx, c, "SubscribeEventsByBlockHeight", request, blockEventsMessageCodec,
+		func() (<-chan flow.BlockEvents, <-chan error, error) {
+			return c.client.SubscribeEventsByBlockHeight(ctx, startHeight, filter, opts...)
+		})
+}
+
+// eventsRequest returns the request of an events subscription with the filter and heartbeat interval.
+func eventsRequest(filter flow.EventFilter, opts []base.SubscribeOption) *executiondata.SubscribeEventsRequest {
+	conf := base.DefaultSubscribeConfig()
+	for _, apply := range opts {
+		apply(conf)
+	}
+
+	return &executiondata.SubscribeEventsRequest{
+		Filter: &executiondata.EventFilter{
+			EventType: filter.EventTypes,
+			Address:   filter.Addresses,
+			Contract:  filter.Contracts,
+		},
+		HeartbeatInterval: conf.HeartbeatInterval,
+	}
+}
+
+func (c *Client) SubscribeBlockDigestsFromStartBlockID(
+	ctx context.Context,
+	startBlockID flow.Identifier,
+	blockStatus flow.BlockStatus,
+) (<-chan *flow.BlockDigest, <-chan error, error) {
+	request := &access.SubscribeBlockDigestsFromStartBlockIDRequest{
+		StartBlockId: convert.IdentifierToMessage(startBlockID),
+		BlockStatus:  convert.BlockStatusToEntity(blockStatus),
+	}
+	return subscribe(ctx, c, "SubscribeBlockDigestsFromStartBlockID", request, blockDigestCodec,
+		func() (<-chan *flow.BlockDigest, <-chan error, error) {
+			return c.client.SubscribeBlockDigestsFromStartBlockID(ctx, startBlockID, blockStatus)
+		})
+}
+
+func (c *Client) SubscribeBlockDigestsFromStartHeight(
+	ctx context.Context,
+	startHeight uint64,
+	blockStatus flow.BlockStatus,
+) (<-chan *flow.BlockDigest, <-chan error, error) {
+	request := &access.SubscribeBlockDigestsFromStartHeightRequest{
+		StartBlockHeight: startHeight,
+		BlockStatus:      convert.BlockStatusToEntity(blockStatus),
+	}
+	return subscribe(ctx, c, "SubscribeBlockDigestsFromStartHeight", request, blockDigestCodec,
+		func() (<-chan *flow.BlockDigest, <-chan error, error) {
+			return c.client.SubscribeBlockDigestsFromStartHeight(ctx, startHeight, blockStatus)
+		})
+}
+
+func (c *Client) SubscribeBlockDigestsFromLatest(
+	ctx context.Context,
+	blockStatus flow.BlockStatus,
+) (<-chan *flow.BlockDigest, <-chan error, error) {
+	request := &access.SubscribeBlockDigestsFromLatestRequest{BlockStatus: convert.BlockStatusToEntity(blockStatus)}
+	return subscribe(ctx, c, "SubscribeBlockDigestsFromLatest", request, blockDigestCodec,
+		func() (<-chan *flow.BlockDigest, <-chan error, error) {
+			return c.client.SubscribeBlockDigestsFromLatest(ctx, blockStatus)
+		})
+}
+
+func (c *Client) SubscribeBlocksFromStartBlockID(
+	ctx context.Context,
+	startBlockID flow.Identifier,
+	blockStatus flow.BlockStatus,
+) (<-chan *flow.Block, <-chan error, error) {
+	request := &access.SubscribeBlocksFromStartBlockIDRequest{
+		StartBlockId: convert.IdentifierToMessage(startBlockID),
+		BlockStatus:  convert.BlockStatusToEntity(blockStatus),
+	}
+	return subscribe(ctx, c, "SubscribeBlocksFromStartBlockID", request, blockCodec,
+		func() (<-chan *flow.Block, <-chan error, error) {
+			return c.client.SubscribeBlocksFromStartBlockID(ctx, startBlockID, blockStatus)
+		})
+}
+
+func (c *Client) SubscribeBlocksFromStartHeight(
+	ctx context.Context,
+	startHeight uint64,
+	blockStatus flow.BlockStatus,
+) (<-chan *flow.Block, <-chan error, error) {
+	request := &access.SubscribeBlocksFromStartHeightRequest{
+		StartBlockHeight: startHeight,
+		BlockStatus:      convert.BlockStatusToEntity(blockStatus),
+	}
+	return subscribe(ctx, c, "SubscribeBlocksFromStartHeight", request, blockCodec,
+		func() (<-chan *flow.Block, <-chan error, error) {
+			return c.client.SubscribeBlocksFromStartHeight(ctx, startHeight, blockStatus)
+		})
+}
+
+func (c *Client) SubscribeBlocksFromLatest(
+	ctx context.Context,
+	blockStatus flow.BlockStatus,
+) (<-chan *flow.Block, <-chan error, error) {
+	request := &access.SubscribeBlocksFromLatestRequest{BlockStatus: convert.BlockStatusToEntity(blockStatus)}
+	return subscribe(ctx, c, "SubscribeBlocksFromLatest", request, blockCodec,
+		func() (<-chan *flow.Block, <-chan error, error) {
+			return c.client.SubscribeBlocksFromLatest(ctx, blockStatus)
+		})
+}
+
+func (c *Client) SubscribeBlockHeadersFromStartBlockID(
+	ctx context.Context,
+	startBlockID flow.Identifier,
+	blockStatus flow.BlockStatus,
+) (<-chan *flow.BlockHeader, <-chan error, error) {
+	request := &access.SubscribeBlockHeadersFromStartBlockIDRequest{
+		StartBlockId: convert.IdentifierToMessage(startBlockID),
+		BlockStatus:  convert.BlockStatusToEntity(blockStatus),
+	}
+	return subscribe(ctx, c, "SubscribeBlockHeadersFromStartBlockID", request, blockHeaderCodec,
+		func() (<-chan *flow.BlockHeader, <-chan error, error) {
+			return c.client.SubscribeBlockHeadersFromStartBlockID(ctx, startBlockID, blockStatus)
+		})
+}
+
+func (c *Client) SubscribeBlockHeadersFromStartHeight(
+	ctx context.Context,
+	startHeight uint64,
+	blockStatus flow.BlockStatus,
+) (<-chan *flow.BlockHeader, <-chan error, error) {
+	request := &access.SubscribeBlockHeadersFromStartHeightRequest{
+		StartBlockHeight: startHeight,
+		BlockStatus:      convert.BlockStatusToEntity(blockStatus),
+	}
+	return subscribe(ctx, c, "SubscribeBlockHeadersFromStartHeight", request, blockHeaderCodec,
+		func() (<-chan *flow.BlockHeader, <-chan error, error) {
+			return c.client.SubscribeBlockHeadersFromStartHeight(ctx, startHeight, blockStatus)
+		})
+}
+
+func (c *Client) SubscribeBlockHeadersFromLatest(
+	ctx context.Context,
+	blockStatus flow.BlockStatus,
+) (<-chan *flow.BlockHeader, <-chan error, error) {
+	request := &access.SubscribeBlockHeadersFromLatestRequest{BlockStatus: convert.BlockStatusToEntity(blockStatus)}
+	return subscribe(ctx, c, "SubscribeBlockHeadersFromLatest", request, blockHeaderCodec,
+		func() (<-chan *flow.BlockHeader, <-chan error, error) {
+			return c.client.SubscribeBlockHeadersFromLatest(ctx, blockStatus)
+		})
+}
+
+func (c *Client) SubscribeAccountStatusesFromStartHeight(
+	ctx context.Context,
+	startBlockHeight uint64,
+	filter flow.AccountStatusFilter,
+) (<-chan *flow.AccountStatus, <-chan error, error) {
+	request := &executiondata.SubscribeAccountStatusesFromStartHeightRequest{
+		StartBlockHeight: startBlockHeight,
+		Filter:           statusFilter(filter),
+	}
+	return subscribe(ctx, c, "SubscribeAccountStatusesFromStartHeight", request, accountStatusCodec,
+		func() (<-chan *flow.AccountStatus, <-chan error, error) {
+			return c.client.SubscribeAccountStatusesFromStartHeight(ctx, startBlockHeight, filter)
+		})
+}
+
+func (c *Client) SubscribeAccountStatusesFromStartBlockID(
+	ctx context.Context,
+	startBlockID flow.Identifier,
+	filter flow.AccountStatusFilter,
+) (<-chan *flow.AccountStatus, <-chan error, error) {
+	request := &executiondata.SubscribeAccountStatusesFromStartBlockIDRequest{
+		StartBlockId: convert.IdentifierToMessage(startBlockID),
+		Filter:       statusFilter(filter),
+	}
+	return subscribe(ctx, c, "SubscribeAccountStatusesFromStartBlockID", request, accountStatusCodec,
+		func() (<-chan *flow.AccountStatus, <-chan error, error) {
+			return c.client.SubscribeAccountStatusesFromStartBlockID(ctx, startBlockID, filter)
+		})
+}
+
+func (c *Client) SubscribeAccountStatusesFromLatestBlock(
+	ctx context.Context,
+	filter flow.AccountStatusFilter,
+) (<-chan *flow.AccountStatus, <-chan error, error) {
+	request := &executiondata.SubscribeAccountStatusesFromLatestBlockRequest{Filter: statusFilter(filter)}
+	return subscribe(ctx, c, "SubscribeAccountStatusesFromLatestBlock", request, accountStatusCodec,
+		func() (<-chan *flow.AccountStatus, <-chan error, error) {
+			return c.client.SubscribeAccountStatusesFromLatestBlock(ctx, filter)
+		})
+}
+
+func statusFilter(filter flow.AccountStatusFilter) *executiondata.StatusFilter {
+	return &executiondata.StatusFilter{
+		EventType: filter.EventTypes,
+		Address:   filter.Addresses,
+	}
+}
+
+func (c *Client) SendAndSubscribeTransactionStatuses(
+	ctx context.Context,
+	tx flow.Transaction,
+) (<-chan *flow.TransactionResult, <-chan error, error) {
+	txMsg, err := transactionMessage(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request := &access.SendAndSubscribeTransactionStatusesRequest{Transaction: txMsg}
+	return subscribe(ctx, c, "SendAndSubscribeTransactionStatuses", request, transactionResultCodec,
+		func() (<-chan *flow.TransactionResult, <-chan error, error) {
+			return c.client.SendAndSubscribeTransactionStatuses(ctx, tx)
+		})
+}
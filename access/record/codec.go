@@ -0,0 +1,436 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package record
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/onflow/flow/protobuf/go/flow/executiondata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access/grpc/convert"
+)
+
+// encoding is the encoding of the Cadence values and events in fixtures.
+const encoding = flow.EventEncodingVersionJSONCDC
+
+// A codec encodes a response or subscription message as the JSON encoding of an Access API protobuf message.
+type codec[T any] struct {
+	encode func(T) (json.RawMessage, error)
+	decode func(json.RawMessage) (T, error)
+}
+
+// messageCodec returns a codec converting values to and from the protobuf message M.
+func messageCodec[T any, M any, P interface {
+	*M
+	protoadapt.MessageV1
+}](
+	toMessage func(T) (P, error),
+	fromMessage func(P) (T, error),
+) codec[T] {
+	return codec[T]{
+		encode: func(value T) (json.RawMessage, error) {
+			m, err := toMessage(value)
+			if err != nil {
+				return nil, err
+			}
+			return marshal(m)
+		},
+		decode: func(data json.RawMessage) (T, error) {
+			m := P(new(M))
+			if err := protojson.Unmarshal(data, protoadapt.MessageV2Of(m)); err != nil {
+				var empty T
+				return empty, err
+			}
+			return fromMessage(m)
+		},
+	}
+}
+
+// marshal returns the compact JSON encoding of a message.
+func marshal(m protoadapt.MessageV1) (json.RawMessage, error) {
+	data, err := protojson.Marshal(protoadapt.MessageV2Of(m))
+	if err != nil {
+		return nil, err
+	}
+
+	// protojson randomizes whitespace, so the encoding is compacted to be stable.
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+var noResponse = codec[struct{}]{
+	encode: func(struct{}) (json.RawMessage, error) {
+		return nil, nil
+	},
+	decode: func(json.RawMessage) (struct{}, error) {
+		return struct{}{}, nil
+	},
+}
+
+var networkParametersCodec = messageCodec(
+	func(p *flow.NetworkParameters) (*access.GetNetworkParametersResponse, error) {
+		return &access.GetNetworkParametersResponse{ChainId: string(p.ChainID)}, nil
+	},
+	func(m *access.GetNetworkParametersResponse) (*flow.NetworkParameters, error) {
+		return &flow.NetworkParameters{ChainID: flow.ChainID(m.GetChainId())}, nil
+	},
+)
+
+var nodeVersionInfoCodec = messageCodec(
+	func(info *flow.NodeVersionInfo) (*entities.NodeVersionInfo, error) {
+		m := &entities.NodeVersionInfo{
+			Semver:               info.Semver,
+			Commit:               info.Commit,
+			SporkId:              convert.IdentifierToMessage(info.SporkId),
+			ProtocolVersion:      info.ProtocolVersion,
+			SporkRootBlockHeight: info.SporkRootBlockHeight,
+			NodeRootBlockHeight:  info.NodeRootBlockHeight,
+		}
+		if info.CompatibleRange != nil {
+			m.CompatibleRange = &entities.CompatibleRange{
+				StartHeight: info.CompatibleRange.StartHeight,
+				EndHeight:   info.CompatibleRange.EndHeight,
+			}
+		}
+		return m, nil
+	},
+	func(m *entities.NodeVersionInfo) (*flow.NodeVersionInfo, error) {
+		info := &flow.NodeVersionInfo{
+			Semver:               m.GetSemver(),
+			Commit:               m.GetCommit(),
+			SporkId:              convert.MessageToIdentifier(m.GetSporkId()),
+			ProtocolVersion:      m.GetProtocolVersion(),
+			SporkRootBlockHeight: m.GetSporkRootBlockHeight(),
+			NodeRootBlockHeight:  m.GetNodeRootBlockHeight(),
+		}
+		if m.CompatibleRange != nil {
+			info.CompatibleRange = &flow.CompatibleRange{
+				StartHeight: m.CompatibleRange.GetStartHeight(),
+				EndHeight:   m.CompatibleRange.GetEndHeight(),
+			}
+		}
+		return info, nil
+	},
+)
+
+var blockHeaderCodec = messageCodec(
+	func(header *flow.BlockHeader) (*access.BlockHeaderResponse, error) {
+		m, err := convert.BlockHeaderToMessage(*header)
+		return &access.BlockHeaderResponse{Block: m, BlockStatus: entities.BlockStatus(header.Status)}, err
+	},
+	func(m *access.BlockHeaderResponse) (*flow.BlockHeader, error) {
+		header, err := convert.MessageToBlockHeader(m.GetBlock())
+		if err != nil {
+			return nil, err
+		}
+		header.Status = flow.BlockStatus(m.GetBlockStatus())
+		return header, nil
+	},
+)
+
+var blockCodec = messageCodec(
+	func(block *flow.Block) (*access.BlockResponse, error) {
+		m, err := convert.BlockToMessage(*block)
+		return &access.BlockResponse{Block: m, BlockStatus: entities.BlockStatus(block.Status)}, err
+	},
+	func(m *access.BlockResponse) (*flow.Block, error) {
+		block, err := convert.MessageToBlock(m.GetBlock())
+		if err != nil {
+			return nil, err
+		}
+		block.BlockHeader.Status = flow.BlockStatus(m.GetBlockStatus())
+		return block, nil
+	},
+)
+
+var collectionCodec = messageCodec(
+	func(collection *flow.Collection) (*entities.Collection, error) {
+		return convert.CollectionToMessage(*collection), nil
+	},
+	func(m *entities.Collection) (*flow.Collection, error) {
+		collection, err := convert.MessageToCollection(m)
+		return &collection, err
+	},
+)
+
+var fullCollectionCodec = messageCodec(
+	func(collection *flow.FullCollection) (*access.FullCollectionResponse, error) {
+		transactions, err := convert.FullCollectionToTransactionsMessage(*collection)
+		return &access.FullCollectionResponse{Transactions: transactions}, err
+	},
+	func(m *access.FullCollectionResponse) (*flow.FullCollection, error) {
+		collection, err := convert.MessageToFullCollection(m.GetTransactions())
+		return &collection, err
+	},
+)
+
+var transactionCodec = messageCodec(
+	func(tx *flow.Transaction) (*entities.Transaction, error) {
+		return convert.TransactionToMessage(*tx)
+	},
+	func(m *entities.Transaction) (*flow.Transaction, error) {
+		tx, err := convert.MessageToTransaction(m)
+		return &tx, err
+	},
+)
+
+var transactionsCodec = messageCodec(
+	func(txs []*flow.Transaction) (*access.TransactionsResponse, error) {
+		messages := make([]*entities.Transaction, len(txs))
+		for i, tx := range txs {
+			m, err := convert.TransactionToMessage(*tx)
+			if err != nil {
+				return nil, err
+			}
+			messages[i] = m
+		}
+		return &access.TransactionsResponse{Transactions: messages}, nil
+	},
+	func(m *access.TransactionsResponse) ([]*flow.Transaction, error) {
+		txs := make([]*flow.Transaction, len(m.GetTransactions()))
+		for i, message := range m.GetTransactions() {
+			tx, err := convert.MessageToTransaction(message)
+			if err != nil {
+				return nil, err
+			}
+			txs[i] = &tx
+		}
+		return txs, nil
+	},
+)
+
+var transactionResultCodec = messageCodec(
+	func(result *flow.TransactionResult) (*access.TransactionResultResponse, error) {
+		return convert.TransactionResultToMessage(*result, encoding)
+	},
+	func(m *access.TransactionResultResponse) (*flow.TransactionResult, error) {
+		result, err := convert.MessageToTransactionResult(m, nil)
+		return &result, err
+	},
+)
+
+var transactionResultsCodec = messageCodec(
+	func(results []*flow.TransactionResult) (*access.TransactionResultsResponse, error) {
+		messages := make([]*access.TransactionResultResponse, len(results))
+		for i, result := range results {
+			m, err := convert.TransactionResultToMessage(*result, encoding)
+			if err != nil {
+				return nil, err
+			}
+			messages[i] = m
+		}
+		return &access.TransactionResultsResponse{TransactionResults: messages}, nil
+	},
+	func(m *access.TransactionResultsResponse) ([]*flow.TransactionResult, error) {
+		results := make([]*flow.TransactionResult, len(m.GetTransactionResults()))
+		for i, message := range m.GetTransactionResults() {
+			result, err := convert.MessageToTransactionResult(message, nil)
+			if err != nil {
+				return nil, err
+			}
+			results[i] = &result
+		}
+		return results, nil
+	},
+)
+
+var accountCodec = messageCodec(
+	func(account *flow.Account) (*entities.Account, error) {
+		return convert.AccountToMessage(*account), nil
+	},
+	func(m *entities.Account) (*flow.Account, error) {
+		account, err := convert.MessageToAccount(m)
+		return &account, err
+	},
+)
+
+var balanceCodec = messageCodec(
+	func(balance uint64) (*access.AccountBalanceResponse, error) {
+		return &access.AccountBalanceResponse{Balance: balance}, nil
+	},
+	func(m *access.AccountBalanceResponse) (uint64, error) {
+		return m.GetBalance(), nil
+	},
+)
+
+var accountKeyCodec = messageCodec(
+	func(key *flow.AccountKey) (*entities.AccountKey, error) {
+		return convert.AccountKeyToMessage(key), nil
+	},
+	convert.MessageToAccountKey,
+)
+
+var accountKeysCodec = messageCodec(
+	func(keys []*flow.AccountKey) (*access.AccountKeysResponse, error) {
+		messages := make([]*entities.AccountKey, len(keys))
+		for i, key := range keys {
+			messages[i] = convert.AccountKeyToMessage(key)
+		}
+		return &access.AccountKeysResponse{AccountKeys: messages}, nil
+	},
+	func(m *access.AccountKeysResponse) ([]*flow.AccountKey, error) {
+		return convert.MessageToAccountKeys(m.GetAccountKeys())
+	},
+)
+
+var cadenceValueCodec = messageCodec(
+	func(value cadence.Value) (*access.ExecuteScriptResponse, error) {
+		payload, err := convert.CadenceValueToMessage(value, encoding)
+		return &access.ExecuteScriptResponse{Value: payload}, err
+	},
+	func(m *access.ExecuteScriptResponse) (cadence.Value, error) {
+		return convert.MessageToCadenceValue(m.GetValue(), nil)
+	},
+)
+
+var blockEventsCodec = messageCodec(
+	func(blocks []flow.BlockEvents) (*access.EventsResponse, error) {
+		results := make([]*access.EventsResponse_Result, len(blocks))
+		for i, block := range blocks {
+			events, err := eventsToMessages(block.Events)
+			if err != nil {
+				return nil, err
+			}
+			results[i] = &access.EventsResponse_Result{
+				BlockId:        convert.IdentifierToMessage(block.BlockID),
+				BlockHeight:    block.Height,
+				Events:         events,
+				BlockTimestamp: timestamppb.New(block.BlockTimestamp),
+			}
+		}
+		return &access.EventsResponse{Results: results}, nil
+	},
+	func(m *access.EventsResponse) ([]flow.BlockEvents, error) {
+		blocks := make([]flow.BlockEvents, len(m.GetResults()))
+		for i, result := range m.GetResults() {
+			events, err := convert.MessagesToEvents(result.GetEvents(), nil)
+			if err != nil {
+				return nil, err
+			}
+			blocks[i] = flow.BlockEvents{
+				BlockID:        convert.MessageToIdentifier(result.GetBlockId()),
+				Height:         result.GetBlockHeight(),
+				BlockTimestamp: result.GetBlockTimestamp().AsTime(),
+				Events:         events,
+			}
+		}
+		return blocks, nil
+	},
+)
+
+var snapshotCodec = messageCodec(
+	func(snapshot []byte) (*access.ProtocolStateSnapshotResponse, error) {
+		return &access.ProtocolStateSnapshotResponse{SerializedSnapshot: snapshot}, nil
+	},
+	func(m *access.ProtocolStateSnapshotResponse) ([]byte, error) {
+		return m.GetSerializedSnapshot(), nil
+	},
+)
+
+var executionResultCodec = messageCodec(
+	func(result *flow.ExecutionResult) (*entities.ExecutionResult, error) {
+		return convert.ExecutionResultToMessage(*result)
+	},
+	convert.MessageToExecutionResult,
+)
+
+var executionDataCodec = messageCodec(
+	convert.BlockExecutionDataToMessage,
+	convert.MessageToBlockExecutionData,
+)
+
+var executionDataResponseCodec = messageCodec(
+	func(response *flow.ExecutionDataStreamResponse) (*executiondata.SubscribeExecutionDataResponse, error) {
+		execData, err := convert.BlockExecutionDataToMessage(response.ExecutionData)
+		return &executiondata.SubscribeExecutionDataResponse{
+			BlockHeight:        response.Height,
+			BlockExecutionData: execData,
+			BlockTimestamp:     timestamppb.New(response.BlockTimestamp),
+		}, err
+	},
+	func(m *executiondata.SubscribeExecutionDataResponse) (*flow.ExecutionDataStreamResponse, error) {
+		execData, err := convert.MessageToBlockExecutionData(m.GetBlockExecutionData())
+		return &flow.ExecutionDataStreamResponse{
+			Height:         m.GetBlockHeight(),
+			ExecutionData:  execData,
+			BlockTimestamp: m.GetBlockTimestamp().AsTime(),
+		}, err
+	},
+)
+
+var blockEventsMessageCodec = messageCodec(
+	func(block flow.BlockEvents) (*executiondata.SubscribeEventsResponse, error) {
+		events, err := eventsToMessages(block.Events)
+		return &executiondata.SubscribeEventsResponse{
+			BlockId:        convert.IdentifierToMessage(block.BlockID),
+			BlockHeight:    block.Height,
+			Events:         events,
+			BlockTimestamp: timestamppb.New(block.BlockTimestamp),
+		}, err
+	},
+	func(m *executiondata.SubscribeEventsResponse) (flow.BlockEvents, error) {
+		events, err := convert.MessagesToEvents(m.GetEvents(), nil)
+		return flow.BlockEvents{
+			BlockID:        convert.MessageToIdentifier(m.GetBlockId()),
+			Height:         m.GetBlockHeight(),
+			BlockTimestamp: m.GetBlockTimestamp().AsTime(),
+			Events:         events,
+		}, err
+	},
+)
+
+var blockDigestCodec = messageCodec(
+	func(digest *flow.BlockDigest) (*access.SubscribeBlockDigestsResponse, error) {
+		return convert.BlockDigestToMessage(*digest), nil
+	},
+	convert.MessageToBlockDigest,
+)
+
+var accountStatusCodec = messageCodec(
+	func(accountStatus *flow.AccountStatus) (*executiondata.SubscribeAccountStatusesResponse, error) {
+		return convert.AccountStatusToMessage(*accountStatus, encoding)
+	},
+	func(m *executiondata.SubscribeAccountStatusesResponse) (*flow.AccountStatus, error) {
+		accountStatus, err := convert.MessageToAccountStatus(m)
+		return &accountStatus, err
+	},
+)
+
+func eventsToMessages(events []flow.Event) ([]*entities.Event, error) {
+	messages := make([]*entities.Event, len(events))
+	for i, event := range events {
+		m, err := convert.EventToMessage(event, encoding)
+		if err != nil {
+			return nil, err
+		}
+		messages[i] = m
+	}
+	return messages, nil
+}
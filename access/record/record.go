@@ -0,0 +1,299 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package record records the Access API calls of a client to a fixture, and replays them, for deterministic tests of
+// code using the Access API.
+//
+// A recorder wraps a client, e.g. a client of a testnet access node, and records every call with its request and
+// response, and the messages and errors of subscriptions:
+//
+//	recorder := record.NewRecorder(client)
+//	runScenario(ctx, recorder)
+//	err := recorder.Fixture().Save("testdata/scenario.json")
+//
+// A replayer serves the recorded calls back, without any network access:
+//
+//	fixture, err := record.LoadFixture("testdata/scenario.json")
+//	replayer := record.NewReplayer(fixture)
+//	runScenario(ctx, replayer)
+//
+// Calls are matched by method and request. Calls with the same method and request are replayed in the recorded
+// order, and calls which were not recorded fail with ErrNoRecording. Requests and responses are stored as the JSON
+// encoding of their Access API protobuf messages, converted with the convert package.
+//
+// Transaction signatures are usually not deterministic, so transactions, and the transaction IDs derived from them,
+// only match if they are signed deterministically during replay, or if the fields are ignored with WithIgnoredFields.
+package record
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk/access"
+)
+
+// ErrNoRecording is returned by a replayer for calls which were not recorded.
+var ErrNoRecording = errors.New("record: no recorded call")
+
+// A Fixture is a list of recorded calls.
+type Fixture struct {
+	Calls []*Call `json:"calls"`
+}
+
+// A Call is a recorded Access API call.
+//
+// The request, response and messages are the JSON encoded Access API protobuf messages. Error is the error of the
+// call, and StreamError the error ending the stream of a subscription.
+type Call struct {
+	Method      string            `json:"method"`
+	Request     json.RawMessage   `json:"request"`
+	Response    json.RawMessage   `json:"response,omitempty"`
+	Messages    []json.RawMessage `json:"messages,omitempty"`
+	Error       *Error            `json:"error,omitempty"`
+	StreamError *Error            `json:"stream_error,omitempty"`
+}
+
+// An Error is a recorded error, with its gRPC status code.
+type Error struct {
+	Code    codes.Code `json:"code"`
+	Message string     `json:"message"`
+}
+
+func newError(err error) *Error {
+	return &Error{
+		Code:    status.Code(err),
+		Message: err.Error(),
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// GRPCStatus returns the gRPC status of the error.
+//
+// This function satisfies the interface defined in the status.FromError function.
+func (e *Error) GRPCStatus() *status.Status {
+	return status.New(e.Code, e.Message)
+}
+
+// LoadFixture loads a fixture from a JSON file.
+func LoadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("record: failed to read fixture: %w", err)
+	}
+
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("record: failed to decode fixture %s: %w", path, err)
+	}
+	return &fixture, nil
+}
+
+// Save saves the fixture to a JSON file.
+func (f *Fixture) Save(path string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("record: failed to encode fixture: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("record: failed to write fixture: %w", err)
+	}
+	return nil
+}
+
+// An Option configures a replayer.
+type Option func(*Client)
+
+// WithIgnoredFields ignores the request fields when matching calls, e.g. "envelope_signatures".
+//
+// Field names are matched case-insensitively and ignoring underscores, so "envelope_signatures" matches the
+// "envelopeSignatures" field of the JSON encoded requests, at any depth.
+func WithIgnoredFields(fields ...string) Option {
+	return func(c *Client) {
+		for _, field := range fields {
+			c.ignored[normalizeField(field)] = true
+		}
+	}
+}
+
+// Client is an access.Client recording or replaying calls.
+type Client struct {
+	mu      sync.Mutex
+	client  access.Client
+	fixture *Fixture
+	ignored map[string]bool
+	pending map[string][]*Call
+}
+
+var _ access.Client = (*Client)(nil)
+
+// NewRecorder returns a client recording the calls to the client.
+func NewRecorder(client access.Client) *Client {
+	return &Client{
+		client:  client,
+		fixture: &Fixture{},
+	}
+}
+
+// NewReplayer returns a client replaying the calls of the fixture.
+func NewReplayer(fixture *Fixture, opts ...Option) *Client {
+	c := &Client{
+		fixture: fixture,
+		ignored: make(map[string]bool),
+		pending: make(map[string][]*Call),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	for _, call := range fixture.Calls {
+		key := c.key(call.Method, call.Request)
+		c.pending[key] = append(c.pending[key], call)
+	}
+	return c
+}
+
+// Fixture returns a copy of the fixture of the calls recorded so far, or of the replayed fixture.
+func (c *Client) Fixture() *Fixture {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	calls := make([]*Call, len(c.fixture.Calls))
+	for i, call := range c.fixture.Calls {
+		copied := *call
+		copied.Messages = append([]json.RawMessage(nil), call.Messages...)
+		calls[i] = &copied
+	}
+	return &Fixture{Calls: calls}
+}
+
+// Remaining returns the recorded calls which were not replayed yet, in the recorded order.
+func (c *Client) Remaining() []*Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	replayed := make(map[*Call]bool)
+	for _, call := range c.fixture.Calls {
+		replayed[call] = true
+	}
+	for _, calls := range c.pending {
+		for _, call := range calls {
+			replayed[call] = false
+		}
+	}
+
+	var remaining []*Call
+	for _, call := range c.fixture.Calls {
+		if !replayed[call] {
+			remaining = append(remaining, call)
+		}
+	}
+	return remaining
+}
+
+// Close closes the recorded client.
+func (c *Client) Close() error {
+	if c.client == nil {
+		return nil
+	}
+	return c.client.Close()
+}
+
+func (c *Client) recording() bool {
+	return c.client != nil
+}
+
+// record appends a call to the fixture.
+func (c *Client) record(call *Call) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.fixture.Calls = append(c.fixture.Calls, call)
+}
+
+// update updates a recorded call, e.g. with the messages of a subscription.
+func (c *Client) update(update func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	update()
+}
+
+// next returns the next recorded call matching the method and request.
+func (c *Client) next(method string, request json.RawMessage) (*Call, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := c.key(method, request)
+	calls, ok := c.pending[key]
+	if !ok {
+		return nil, fmt.Errorf("%w for %s with request %s", ErrNoRecording, method, request)
+	}
+	if len(calls) == 0 {
+		return nil, fmt.Errorf("%w for %s with request %s: all recorded calls were replayed", ErrNoRecording, method, request)
+	}
+
+	c.pending[key] = calls[1:]
+	return calls[0], nil
+}
+
+// key returns the key matching a call, with the ignored fields removed from the request.
+func (c *Client) key(method string, request json.RawMessage) string {
+	var value any
+	if err := json.Unmarshal(request, &value); err != nil {
+		return method + " " + string(request)
+	}
+
+	canonical, err := json.Marshal(c.removeIgnored(value))
+	if err != nil {
+		return method + " " + string(request)
+	}
+	return method + " " + string(canonical)
+}
+
+func (c *Client) removeIgnored(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if c.ignored[normalizeField(key)] {
+				delete(v, key)
+			} else {
+				v[key] = c.removeIgnored(field)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = c.removeIgnored(item)
+		}
+	}
+	return value
+}
+
+func normalizeField(field string) string {
+	return strings.ToLower(strings.ReplaceAll(field, "_", ""))
+}
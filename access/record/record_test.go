@@ -0,0 +1,238 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package record_test
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+	"github.com/onflow/flow-go-sdk/access/fake"
+	"github.com/onflow/flow-go-sdk/access/record"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+var script = []byte("access(all) fun main(a: Int): Int { return a }")
+
+// scenario is the observed results of calls to a client.
+type scenario struct {
+	header      *flow.BlockHeader
+	account     *flow.Account
+	result      *flow.TransactionResult
+	eventValues []string
+	value       cadence.Value
+	block       *flow.Block
+	notFound    error
+	headers     []*flow.BlockHeader
+}
+
+// runScenario calls the client, committing blocks of the fake chain with commit.
+func runScenario(t *testing.T, client access.Client, tx *flow.Transaction, commit func(n int)) scenario {
+	ctx := context.Background()
+	var s scenario
+	var err error
+
+	s.header, err = client.GetLatestBlockHeader(ctx, true)
+	require.NoError(t, err)
+
+	require.NoError(t, client.SendTransaction(ctx, *tx))
+	commit(3)
+
+	s.result, err = client.GetTransactionResult(ctx, tx.ID())
+	require.NoError(t, err)
+	for _, event := range s.result.Events {
+		s.eventValues = append(s.eventValues, event.Value.String())
+	}
+
+	s.account, err = client.GetAccount(ctx, tx.Payer)
+	require.NoError(t, err)
+
+	s.value, err = client.ExecuteScriptAtLatestBlock(ctx, script, []cadence.Value{cadence.NewInt(42)})
+	require.NoError(t, err)
+
+	s.block, err = client.GetBlockByHeight(ctx, s.result.BlockHeight)
+	require.NoError(t, err)
+
+	_, s.notFound = client.GetBlockByHeight(ctx, 1000)
+
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	headers, errs, err := client.SubscribeBlockHeadersFromStartHeight(subCtx, 0, flow.BlockStatusFinalized)
+	require.NoError(t, err)
+	for len(s.headers) < 3 {
+		select {
+		case header := <-headers:
+			s.headers = append(s.headers, header)
+		case err := <-errs:
+			require.NoError(t, err)
+		}
+	}
+
+	return s
+}
+
+// newChain returns a fake chain with an account, and a transaction of the account.
+func newChain(t *testing.T) (*fake.Client, *flow.Transaction, crypto.Signer) {
+	ctx := context.Background()
+
+	events := test.EventGenerator(flow.EventEncodingVersionJSONCDC)
+	chain := fake.NewClient(fake.WithTransactionHandler(func(tx flow.Transaction) ([]flow.Event, error) {
+		return []flow.Event{events.New()}, nil
+	}))
+	chain.SetScriptResult(script, cadence.NewInt(42))
+
+	accountKey, signer := test.AccountKeyGenerator().NewWithSigner()
+	address := chain.CreateAccount(accountKey)
+
+	header, err := chain.GetLatestBlockHeader(ctx, true)
+	require.NoError(t, err)
+
+	tx := flow.NewTransaction().
+		SetScript([]byte("transaction { prepare(signer: &Account) {} }")).
+		SetReferenceBlockID(header.ID).
+		SetProposalKey(address, 0, accountKey.SequenceNumber).
+		SetPayer(address).
+		AddAuthorizer(address)
+	require.NoError(t, tx.SignEnvelope(address, 0, signer))
+
+	return chain, tx, signer
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	chain, tx, _ := newChain(t)
+	defer chain.Close()
+
+	recorder := record.NewRecorder(chain)
+	recorded := runScenario(t, recorder, tx, func(n int) { chain.CommitBlocks(n) })
+
+	assert.Equal(t, flow.TransactionStatusSealed, recorded.result.Status)
+	assert.Equal(t, codes.NotFound, status.Code(recorded.notFound))
+
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	require.NoError(t, recorder.Fixture().Save(path))
+
+	fixture, err := record.LoadFixture(path)
+	require.NoError(t, err)
+
+	replayer := record.NewReplayer(fixture)
+	replayed := runScenario(t, replayer, tx, func(int) {})
+
+	assert.Equal(t, recorded.header, replayed.header)
+	assert.Equal(t, recorded.account.Address, replayed.account.Address)
+	assert.Equal(t, recorded.account.Keys, replayed.account.Keys)
+	assert.Equal(t, recorded.eventValues, replayed.eventValues)
+	assert.Equal(t, recorded.value, replayed.value)
+	assert.Equal(t, recorded.block.BlockHeader, replayed.block.BlockHeader)
+	assert.Equal(t, recorded.block.CollectionGuarantees, replayed.block.CollectionGuarantees)
+	assert.Equal(t, recorded.headers, replayed.headers)
+
+	recorded.result.Events, replayed.result.Events = nil, nil
+	assert.Equal(t, recorded.result, replayed.result)
+
+	assert.Equal(t, recorded.notFound.Error(), replayed.notFound.Error())
+	assert.Equal(t, codes.NotFound, status.Code(replayed.notFound))
+
+	assert.Empty(t, replayer.Remaining())
+}
+
+func TestReplay_Unmatched(t *testing.T) {
+	ctx := context.Background()
+
+	chain, _, _ := newChain(t)
+	defer chain.Close()
+
+	recorder := record.NewRecorder(chain)
+	_, err := recorder.GetBlockByHeight(ctx, 0)
+	require.NoError(t, err)
+	_, err = recorder.GetBlockHeaderByHeight(ctx, 0)
+	require.NoError(t, err)
+
+	replayer := record.NewReplayer(recorder.Fixture())
+
+	_, err = replayer.GetBlockByHeight(ctx, 1)
+	assert.ErrorIs(t, err, record.ErrNoRecording)
+	assert.ErrorContains(t, err, "GetBlockByHeight")
+
+	_, err = replayer.GetBlockByHeight(ctx, 0)
+	require.NoError(t, err)
+
+	_, err = replayer.GetBlockByHeight(ctx, 0)
+	assert.ErrorIs(t, err, record.ErrNoRecording)
+	assert.ErrorContains(t, err, "all recorded calls were replayed")
+
+	remaining := replayer.Remaining()
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "GetBlockHeaderByHeight", remaining[0].Method)
+}
+
+func TestReplay_IgnoredFields(t *testing.T) {
+	ctx := context.Background()
+
+	chain, tx, signer := newChain(t)
+	defer chain.Close()
+
+	recorder := record.NewRecorder(chain)
+	require.NoError(t, recorder.SendTransaction(ctx, *tx))
+
+	resigned := *tx
+	resigned.EnvelopeSignatures = nil
+	require.NoError(t, resigned.SignEnvelope(tx.Payer, 0, signer))
+
+	err := record.NewReplayer(recorder.Fixture()).SendTransaction(ctx, resigned)
+	assert.ErrorIs(t, err, record.ErrNoRecording)
+
+	err = record.NewReplayer(recorder.Fixture(), record.WithIgnoredFields("envelope_signatures")).
+		SendTransaction(ctx, resigned)
+	assert.NoError(t, err)
+}
+
+func TestReplay_StreamError(t *testing.T) {
+	ctx := context.Background()
+
+	fixture := &record.Fixture{Calls: []*record.Call{{
+		Method:      "SubscribeBlockDigestsFromLatest",
+		Request:     []byte(`{"blockStatus":"BLOCK_SEALED"}`),
+		Messages:    []json.RawMessage{[]byte(`{"blockHeight":"7"}`)},
+		StreamError: &record.Error{Code: codes.Unavailable, Message: "error receiving block digest"},
+	}}}
+
+	replayer := record.NewReplayer(fixture)
+	digests, errs, err := replayer.SubscribeBlockDigestsFromLatest(ctx, flow.BlockStatusSealed)
+	require.NoError(t, err)
+
+	digest := <-digests
+	assert.Equal(t, uint64(7), digest.Height)
+
+	err = <-errs
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.EqualError(t, err, "error receiving block digest")
+
+	_, ok := <-digests
+	assert.False(t, ok)
+}
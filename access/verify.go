@@ -0,0 +1,100 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package access
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+type verifyOptions struct {
+	height *uint64
+	mode   flow.UserSignatureVerificationMode
+}
+
+// A VerifyOption configures signature verification with account keys fetched from an access node.
+type VerifyOption func(*verifyOptions)
+
+// AtBlockHeight verifies signatures against the account keys at the given block height,
+// instead of the latest sealed block.
+func AtBlockHeight(height uint64) VerifyOption {
+	return func(opts *verifyOptions) {
+		opts.height = &height
+	}
+}
+
+// WithVerificationMode sets the verification mode, which defaults to flow.VerifyAllSignatures.
+func WithVerificationMode(mode flow.UserSignatureVerificationMode) VerifyOption {
+	return func(opts *verifyOptions) {
+		opts.mode = mode
+	}
+}
+
+func newVerifyOptions(opts []VerifyOption) *verifyOptions {
+	options := &verifyOptions{mode: flow.VerifyAllSignatures}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// VerifyUserSignature verifies signatures of a user message against the keys of an account, without executing
+// a script. The signatures must reach flow.AccountKeyWeightThreshold, see flow.VerifyUserSignatures.
+//
+// The account keys are fetched at the latest sealed block, unless AtBlockHeight is given.
+func VerifyUserSignature(
+	ctx context.Context,
+	client Client,
+	address flow.Address,
+	message []byte,
+	signatures []flow.CompositeSignature,
+	opts ...VerifyOption,
+) error {
+	options := newVerifyOptions(opts)
+
+	keys, err := getAccountKeys(ctx, client, address, options)
+	if err != nil {
+		return err
+	}
+
+	return flow.VerifyUserSignatures(address, keys, message, signatures, options.mode)
+}
+
+func getAccountKeys(
+	ctx context.Context,
+	client Client,
+	address flow.Address,
+	options *verifyOptions,
+) ([]*flow.AccountKey, error) {
+	var (
+		keys []*flow.AccountKey
+		err  error
+	)
+	if options.height != nil {
+		keys, err = client.GetAccountKeysAtBlockHeight(ctx, address, *options.height)
+	} else {
+		keys, err = client.GetAccountKeysAtLatestBlock(ctx, address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot get keys of account %s: %w", address, err)
+	}
+	return keys, nil
+}
@@ -0,0 +1,87 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package access_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+	"github.com/onflow/flow-go-sdk/access/mocks"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestVerifyUserSignature(t *testing.T) {
+	ctx := context.Background()
+	address := test.AddressGenerator().New()
+	keys := test.AccountKeyGenerator()
+
+	key, signer := keys.NewWithSigner()
+	revokedKey, revokedSigner := keys.NewWithSigner()
+	revokedKey.Revoked = true
+
+	message := []byte("login")
+
+	signature, err := flow.SignUserMessage(signer, message)
+	require.NoError(t, err)
+	revokedSignature, err := flow.SignUserMessage(revokedSigner, message)
+	require.NoError(t, err)
+
+	signatures := []flow.CompositeSignature{
+		{Address: address, KeyIndex: revokedKey.Index, Signature: revokedSignature},
+		{Address: address, KeyIndex: key.Index, Signature: signature},
+	}
+
+	client := mocks.NewClient(t)
+	client.On("GetAccountKeysAtLatestBlock", mock.Anything, address).
+		Return([]*flow.AccountKey{key, revokedKey}, nil)
+	client.On("GetAccountKeysAtBlockHeight", mock.Anything, address, uint64(42)).
+		Return([]*flow.AccountKey{revokedKey}, nil)
+	client.On("GetAccountKeysAtBlockHeight", mock.Anything, address, uint64(43)).
+		Return(nil, errors.New("not found"))
+
+	t.Run("All signatures", func(t *testing.T) {
+		// the signature of the revoked key is skipped
+		err := access.VerifyUserSignature(ctx, client, address, message, signatures)
+		assert.NoError(t, err)
+
+		err = access.VerifyUserSignature(ctx, client, address, message, signatures[:1])
+		assert.ErrorIs(t, err, flow.ErrInsufficientKeyWeight)
+	})
+
+	t.Run("Any signature", func(t *testing.T) {
+		err := access.VerifyUserSignature(ctx, client, address, message, signatures,
+			access.WithVerificationMode(flow.VerifyAnySignatures))
+		assert.NoError(t, err)
+	})
+
+	t.Run("At block height", func(t *testing.T) {
+		err := access.VerifyUserSignature(ctx, client, address, message, signatures[1:], access.AtBlockHeight(42))
+		assert.ErrorIs(t, err, flow.ErrMissingAccountKey)
+
+		err = access.VerifyUserSignature(ctx, client, address, message, signatures, access.AtBlockHeight(43))
+		assert.ErrorContains(t, err, "not found")
+	})
+}
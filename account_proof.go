@@ -20,6 +20,7 @@ package flow
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -35,6 +36,8 @@ var (
 	ErrInvalidNonce = errors.New("invalid nonce")
 	// ErrInvalidAppID is returned when the account proof app ID passed to a function is invalid.
 	ErrInvalidAppID = errors.New("invalid app ID")
+	// ErrInvalidAccountProof is returned when an account proof cannot be parsed.
+	ErrInvalidAccountProof = errors.New("invalid account proof")
 )
 
 type canonicalAccountProof struct {
@@ -70,3 +73,100 @@ func EncodeAccountProofMessage(address Address, appID, nonceHex string) ([]byte,
 
 	return msg, nil
 }
+
+// accountProofServiceType is the FCL service type and data type of account proofs.
+const accountProofServiceType = "account-proof"
+
+// AccountProof is the data of an FCL account-proof service, i.e. signatures of an account over
+// the account proof message of an app ID and a nonce, see EncodeAccountProofMessage.
+type AccountProof struct {
+	Address    Address              `json:"address"`
+	Nonce      string               `json:"nonce"`
+	Signatures []CompositeSignature `json:"signatures"`
+}
+
+// accountProofPayload covers the JSON structures FCL wallets and clients return account proofs in.
+type accountProofPayload struct {
+	FType string `json:"f_type"`
+	FVsn  string `json:"f_vsn"`
+	Type  string `json:"type"`
+
+	// account proof data
+	Address    *Address             `json:"address"`
+	Nonce      string               `json:"nonce"`
+	Signatures []CompositeSignature `json:"signatures"`
+	Timestamp  json.RawMessage      `json:"timestamp"`
+
+	// service
+	Data *accountProofPayload `json:"data"`
+	// authentication response or current user
+	Services []accountProofPayload `json:"services"`
+}
+
+// ParseAccountProof parses an account proof returned by FCL.
+//
+// The data can be the data of the account-proof service, the account-proof service, or an object listing
+// the services of the user, e.g. an FCL authentication response, a polling response or the current user.
+// Account proofs of the deprecated version 1.0.0, which are signed over a timestamp instead of a nonce,
+// are not supported.
+func ParseAccountProof(data []byte) (*AccountProof, error) {
+	var payload accountProofPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAccountProof, err)
+	}
+
+	return payload.accountProof()
+}
+
+func (p *accountProofPayload) accountProof() (*AccountProof, error) {
+	switch {
+	case p.Type == accountProofServiceType && p.Data != nil:
+		return p.Data.accountProof()
+	case p.Data != nil && len(p.Services) == 0:
+		return p.Data.accountProof()
+	case len(p.Services) > 0:
+		for i := range p.Services {
+			if p.Services[i].Type == accountProofServiceType {
+				return p.Services[i].accountProof()
+			}
+		}
+		return nil, fmt.Errorf("%w: no account-proof service", ErrInvalidAccountProof)
+	}
+
+	if p.FType != "" && p.FType != accountProofServiceType {
+		return nil, fmt.Errorf("%w: unexpected type %q", ErrInvalidAccountProof, p.FType)
+	}
+	if p.Nonce == "" && len(p.Timestamp) > 0 {
+		return nil, fmt.Errorf("%w: unsupported version %s, a nonce is required", ErrInvalidAccountProof, p.FVsn)
+	}
+	if p.Address == nil {
+		return nil, fmt.Errorf("%w: missing address", ErrInvalidAccountProof)
+	}
+	if len(p.Signatures) == 0 {
+		return nil, fmt.Errorf("%w: missing signatures", ErrInvalidAccountProof)
+	}
+
+	return &AccountProof{
+		Address:    *p.Address,
+		Nonce:      p.Nonce,
+		Signatures: p.Signatures,
+	}, nil
+}
+
+// VerifyAccountProof verifies the signatures of an account proof offline, see VerifyUserSignatures.
+//
+// The accountKeys must be the keys of the proof address. The nonce is only checked for its format;
+// callers must check that the nonce was issued by the app and not used before.
+func VerifyAccountProof(
+	proof *AccountProof,
+	appID string,
+	accountKeys []*AccountKey,
+	mode UserSignatureVerificationMode,
+) error {
+	message, err := EncodeAccountProofMessage(proof.Address, appID, proof.Nonce)
+	if err != nil {
+		return err
+	}
+
+	return VerifyUserSignatures(proof.Address, accountKeys, message, proof.Signatures, mode)
+}
@@ -74,3 +74,52 @@ func TestEncodeAccountProofMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAccountProof(t *testing.T) {
+	const proofData = `{
+		"f_type": "account-proof",
+		"f_vsn": "2.0.0",
+		"address": "0xf8d6e0586b0a20c7",
+		"nonce": "3037366134636339643564623330316636626239323161663465346131393662",
+		"signatures": [{"f_type": "CompositeSignature", "f_vsn": "1.0.0", "addr": "0xf8d6e0586b0a20c7", "keyId": 0, "signature": "0a0b"}]
+	}`
+	const service = `{"f_type": "Service", "f_vsn": "1.0.0", "type": "account-proof", "method": "DATA", "uid": "wallet#account-proof", "data": ` + proofData + `}`
+	const authnResponse = `{"f_type": "AuthnResponse", "f_vsn": "1.0.0", "addr": "0xf8d6e0586b0a20c7", "services": [{"f_type": "Service", "type": "authn"}, ` + service + `]}`
+
+	expected := &AccountProof{
+		Address: HexToAddress("f8d6e0586b0a20c7"),
+		Nonce:   "3037366134636339643564623330316636626239323161663465346131393662",
+		Signatures: []CompositeSignature{{
+			Address:   HexToAddress("f8d6e0586b0a20c7"),
+			KeyIndex:  0,
+			Signature: []byte{0x0a, 0x0b},
+		}},
+	}
+
+	for name, data := range map[string]string{
+		"data":             proofData,
+		"service":          service,
+		"authn response":   authnResponse,
+		"polling response": `{"f_type": "PollingResponse", "status": "APPROVED", "data": ` + authnResponse + `}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			proof, err := ParseAccountProof([]byte(data))
+			assert.NoError(t, err)
+			assert.Equal(t, expected, proof)
+		})
+	}
+
+	for name, data := range map[string]string{
+		"invalid JSON":       `{`,
+		"no proof service":   `{"f_type": "AuthnResponse", "services": [{"f_type": "Service", "type": "authn"}]}`,
+		"missing signatures": `{"f_type": "account-proof", "address": "0x01", "nonce": "00"}`,
+		"missing address":    `{"f_type": "account-proof", "nonce": "00", "signatures": [{"addr": "0x01", "keyId": 0, "signature": "00"}]}`,
+		"version 1":          `{"f_type": "account-proof", "f_vsn": "1.0.0", "address": "0x01", "timestamp": 1, "signatures": [{"addr": "0x01", "keyId": 0, "signature": "00"}]}`,
+		"other type":         `{"f_type": "CompositeSignature", "addr": "0x01"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseAccountProof([]byte(data))
+			assert.ErrorIs(t, err, ErrInvalidAccountProof)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package codec maps Go values to Cadence values and back.
+//
+// Go structs are mapped to Cadence composites using the `cadence` struct tag:
+//
+//	type Listing struct {
+//		ID     uint64        `cadence:"id"`
+//		Seller flow.Address  `cadence:"seller"`
+//		Price  string        `cadence:"price,UFix64"`
+//		Buyer  *flow.Address `cadence:"buyer"`
+//		Notes  string        `cadence:"-"`
+//	}
+//
+// The first tag element is the Cadence field name. If the tag is omitted, the Go field name with a
+// lowercase first letter is used. A field tagged with "-" is ignored. The optional second tag element
+// is a Cadence type name that overrides the default mapping of a scalar value, for example to encode a
+// string or float64 as a UFix64, or a *big.Int as a UInt256. Integers tagged as UFix64 or Fix64 hold the
+// raw fixed-point value, i.e. 1.0 is 100000000.
+//
+// The default mapping is:
+//
+//   - bool: Bool
+//   - string: String
+//   - int, int8, ..., int64: Int, Int8, ..., Int64
+//   - uint, uint8, ..., uint64: UInt, UInt8, ..., UInt64
+//   - *big.Int, big.Int: Int
+//   - flow.Address: Address
+//   - pointers: optionals, where nil is encoded as an empty optional
+//   - slices and arrays: arrays
+//   - maps: dictionaries
+//   - structs: structs, which must implement TypeIdentifier to be encoded
+//   - cadence.Value: the value itself, e.g. cadence.UFix64 for fixed-point amounts
+package codec
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const tagName = "cadence"
+
+// TypeIdentifier is implemented by Go structs that are encoded as Cadence structs.
+//
+// CadenceTypeID returns the fully qualified Cadence type ID of the struct,
+// e.g. `A.f8d6e0586b0a20c7.Marketplace.Listing`.
+type TypeIdentifier interface {
+	CadenceTypeID() string
+}
+
+// fieldInfo describes how a Go struct field is mapped to a Cadence composite field.
+type fieldInfo struct {
+	index    int
+	name     string
+	typeName string
+}
+
+// structFields returns the mapped fields of a Go struct type.
+func structFields(t reflect.Type) []fieldInfo {
+	fields := make([]fieldInfo, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		info := fieldInfo{
+			index: i,
+			name:  lowerFirst(field.Name),
+		}
+
+		if tag, ok := field.Tag.Lookup(tagName); ok {
+			if tag == "-" {
+				continue
+			}
+
+			name, typeName, _ := strings.Cut(tag, ",")
+			if name != "" {
+				info.name = name
+			}
+			info.typeName = typeName
+		}
+
+		fields = append(fields, info)
+	}
+
+	return fields
+}
+
+func lowerFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[size:]
+}
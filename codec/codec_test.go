@@ -0,0 +1,301 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package codec_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access/mocks"
+	"github.com/onflow/flow-go-sdk/codec"
+)
+
+type listing struct {
+	ID       uint64          `cadence:"id"`
+	Seller   flow.Address    `cadence:"seller"`
+	Price    string          `cadence:"price,UFix64"`
+	Buyer    *flow.Address   `cadence:"buyer"`
+	Tags     []string        `cadence:"tags"`
+	Royalty  map[string]int8 `cadence:"royalty"`
+	Supply   *big.Int        `cadence:"supply,UInt256"`
+	Internal string          `cadence:"-"`
+	Active   bool
+}
+
+func (listing) CadenceTypeID() string {
+	return "A.0000000000000001.Market.Listing"
+}
+
+type tagged struct {
+	Float  float64 `cadence:"float,UFix64"`
+	Raw    uint64  `cadence:"raw,UFix64"`
+	Signed string  `cadence:"signed,Fix64"`
+	Small  int     `cadence:"small,UInt8"`
+	Path   string  `cadence:"path,StoragePath"`
+}
+
+func (tagged) CadenceTypeID() string {
+	return "A.0000000000000001.Test.Tagged"
+}
+
+func TestEncodeDecode_Struct(t *testing.T) {
+	seller := flow.HexToAddress("0x01")
+
+	in := listing{
+		ID:       42,
+		Seller:   seller,
+		Price:    "12.5",
+		Tags:     []string{"art", "rare"},
+		Royalty:  map[string]int8{"a": 1, "b": 2},
+		Supply:   big.NewInt(1000),
+		Internal: "ignored",
+		Active:   true,
+	}
+
+	value, err := codec.Encode(in)
+	require.NoError(t, err)
+
+	structValue, ok := value.(cadence.Struct)
+	require.True(t, ok)
+	assert.Equal(t, "A.0000000000000001.Market.Listing", structValue.Type().ID())
+
+	fields := cadence.FieldsMappedByName(structValue)
+	assert.Equal(t, cadence.NewUInt64(42), fields["id"])
+	assert.Equal(t, cadence.NewAddress(seller), fields["seller"])
+	assert.Equal(t, cadence.UFix64(12_50000000), fields["price"])
+	assert.Equal(t, cadence.NewOptional(nil), fields["buyer"])
+	assert.Equal(t, cadence.NewBool(true), fields["active"])
+	assert.NotContains(t, fields, "internal")
+
+	supply, err := cadence.NewUInt256FromBig(big.NewInt(1000))
+	require.NoError(t, err)
+	assert.Equal(t, supply, fields["supply"])
+
+	// the encoded value must be valid JSON-CDC
+	_, err = jsoncdc.Encode(value)
+	require.NoError(t, err)
+
+	out, err := codec.DecodeValue[listing](value)
+	require.NoError(t, err)
+
+	// fixed-point numbers are decoded with all decimal places, ignored fields are not decoded
+	in.Price = "12.50000000"
+	in.Internal = ""
+	assert.Equal(t, in, out)
+}
+
+func TestEncode(t *testing.T) {
+	t.Run("Scalars", func(t *testing.T) {
+		tests := []struct {
+			in       any
+			expected cadence.Value
+		}{
+			{true, cadence.NewBool(true)},
+			{"hello", cadence.String("hello")},
+			{int(-1), cadence.NewInt(-1)},
+			{int8(-1), cadence.NewInt8(-1)},
+			{int64(-1), cadence.NewInt64(-1)},
+			{uint(1), cadence.NewUInt(1)},
+			{uint8(1), cadence.NewUInt8(1)},
+			{uint64(1), cadence.NewUInt64(1)},
+			{big.NewInt(7), cadence.NewInt(7)},
+			{cadence.UFix64(1), cadence.UFix64(1)},
+			{flow.HexToAddress("0x02"), cadence.NewAddress(flow.HexToAddress("0x02"))},
+			{nil, cadence.NewOptional(nil)},
+		}
+
+		for _, test := range tests {
+			value, err := codec.Encode(test.in)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, value)
+		}
+	})
+
+	t.Run("Type tags", func(t *testing.T) {
+		value, err := codec.Encode(tagged{
+			Float:  1.5,
+			Raw:    1,
+			Signed: "-2.0",
+			Small:  255,
+			Path:   "/storage/vault",
+		})
+		require.NoError(t, err)
+
+		path, err := cadence.NewPath(common.PathDomainStorage, "vault")
+		require.NoError(t, err)
+
+		fields := cadence.FieldsMappedByName(value.(cadence.Struct))
+		assert.Equal(t, cadence.UFix64(1_50000000), fields["float"])
+		assert.Equal(t, cadence.UFix64(1), fields["raw"])
+		assert.Equal(t, cadence.Fix64(-2_00000000), fields["signed"])
+		assert.Equal(t, cadence.NewUInt8(255), fields["small"])
+		assert.Equal(t, path, fields["path"])
+
+		_, err = codec.Encode(tagged{Small: 256})
+		require.Error(t, err)
+	})
+
+	t.Run("Untyped struct", func(t *testing.T) {
+		_, err := codec.Encode(struct{ Value int }{1})
+		require.Error(t, err)
+	})
+
+	t.Run("Collections", func(t *testing.T) {
+		value, err := codec.Encode(map[string][]uint8{"b": {2}, "a": {1}})
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			cadence.NewDictionary([]cadence.KeyValuePair{
+				{Key: cadence.String("a"), Value: cadence.NewArray([]cadence.Value{cadence.NewUInt8(1)})},
+				{Key: cadence.String("b"), Value: cadence.NewArray([]cadence.Value{cadence.NewUInt8(2)})},
+			}),
+			value,
+		)
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := codec.Encode(make(chan int))
+		require.Error(t, err)
+
+		_, err = codec.Encode(1.5)
+		require.Error(t, err)
+	})
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("Fixed point", func(t *testing.T) {
+		amount := cadence.UFix64(12_50000000)
+
+		s, err := codec.DecodeValue[string](amount)
+		require.NoError(t, err)
+		assert.Equal(t, "12.50000000", s)
+
+		f, err := codec.DecodeValue[float64](amount)
+		require.NoError(t, err)
+		assert.Equal(t, 12.5, f)
+
+		raw, err := codec.DecodeValue[uint64](amount)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(12_50000000), raw)
+
+		typed, err := codec.DecodeValue[cadence.UFix64](amount)
+		require.NoError(t, err)
+		assert.Equal(t, amount, typed)
+	})
+
+	t.Run("Optionals", func(t *testing.T) {
+		p, err := codec.DecodeValue[*string](cadence.NewOptional(cadence.String("a")))
+		require.NoError(t, err)
+		require.NotNil(t, p)
+		assert.Equal(t, "a", *p)
+
+		p, err = codec.DecodeValue[*string](cadence.NewOptional(nil))
+		require.NoError(t, err)
+		assert.Nil(t, p)
+	})
+
+	t.Run("Overflow", func(t *testing.T) {
+		_, err := codec.DecodeValue[uint8](cadence.NewUInt64(256))
+		require.Error(t, err)
+
+		_, err = codec.DecodeValue[uint64](cadence.NewInt(-1))
+		require.Error(t, err)
+	})
+
+	t.Run("Type mismatch", func(t *testing.T) {
+		_, err := codec.DecodeValue[bool](cadence.String("true"))
+		require.Error(t, err)
+
+		err = codec.Decode(cadence.String("a"), "not a pointer")
+		require.Error(t, err)
+	})
+
+	t.Run("Natural values", func(t *testing.T) {
+		value, err := codec.DecodeValue[any](cadence.NewArray([]cadence.Value{
+			cadence.String("a"),
+			cadence.NewUInt64(1),
+			cadence.NewOptional(nil),
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, []any{"a", big.NewInt(1), nil}, value)
+	})
+
+	t.Run("Composite into map", func(t *testing.T) {
+		value := cadence.NewStruct([]cadence.Value{cadence.String("b")}).
+			WithType(cadence.NewStructType(nil, "S", []cadence.Field{{Identifier: "a", Type: cadence.StringType}}, nil))
+
+		m, err := codec.DecodeValue[map[string]string](value)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"a": "b"}, m)
+	})
+}
+
+func TestDecodeEvent(t *testing.T) {
+	type deposited struct {
+		Amount string        `cadence:"amount"`
+		To     *flow.Address `cadence:"to"`
+	}
+
+	to := flow.HexToAddress("0x03")
+	eventType := cadence.NewEventType(
+		common.AddressLocation{Address: common.Address(flow.HexToAddress("0x01")), Name: "FlowToken"},
+		"FlowToken.TokensDeposited",
+		[]cadence.Field{
+			{Identifier: "amount", Type: cadence.UFix64Type},
+			{Identifier: "to", Type: cadence.NewOptionalType(cadence.AddressType)},
+		},
+		nil,
+	)
+
+	event := flow.Event{
+		Type: eventType.ID(),
+		Value: cadence.NewEvent([]cadence.Value{
+			cadence.UFix64(1_00000000),
+			cadence.NewOptional(cadence.NewAddress(to)),
+		}).WithType(eventType),
+	}
+
+	decoded, err := codec.DecodeEvent[deposited](event)
+	require.NoError(t, err)
+	assert.Equal(t, "1.00000000", decoded.Amount)
+	assert.Equal(t, &to, decoded.To)
+}
+
+func TestExecuteScript(t *testing.T) {
+	client := mocks.NewClient(t)
+	script := []byte(`access(all) fun main(a: Address): UFix64 { return 1.0 }`)
+	address := flow.HexToAddress("0x01")
+
+	client.
+		On("ExecuteScriptAtLatestBlock", mock.Anything, script, []cadence.Value{cadence.NewAddress(address)}).
+		Return(cadence.UFix64(1_00000000), nil)
+
+	balance, err := codec.ExecuteScript[float64](context.Background(), client, script, address)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, balance)
+}
@@ -0,0 +1,410 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package codec
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// Decode converts a Cadence value into the Go value pointed to by v.
+//
+// Besides the inverse of the mapping used by Encode, the following conversions are supported:
+//
+//   - composites (structs, resources, events, contracts) into Go structs and map[string]any
+//   - enums into integers, using the raw value
+//   - fixed-point numbers into strings, float64, and integers holding the raw fixed-point value
+//   - addresses into strings, as hex with the 0x prefix
+//   - paths and types into strings
+//   - any value into an empty interface, using a natural Go representation
+//
+// Missing composite fields leave the corresponding Go field unchanged.
+func Decode(value cadence.Value, v any) error {
+	target := reflect.ValueOf(v)
+	if !target.IsValid() || target.Kind() != reflect.Pointer || target.IsNil() {
+		return errors.New("decode target must be a non-nil pointer")
+	}
+	return decodeValue(value, target.Elem())
+}
+
+// DecodeValue converts a Cadence value into a new Go value of type T.
+func DecodeValue[T any](value cadence.Value) (T, error) {
+	var result T
+	err := Decode(value, &result)
+	return result, err
+}
+
+type bigIntValue interface {
+	Big() *big.Int
+}
+
+func decodeValue(value cadence.Value, target reflect.Value) error {
+	if target.Type().Implements(cadenceValueType) && target.Kind() == reflect.Interface {
+		if value == nil {
+			target.SetZero()
+			return nil
+		}
+		target.Set(reflect.ValueOf(value))
+		return nil
+	}
+
+	if value != nil && reflect.TypeOf(value) == target.Type() {
+		target.Set(reflect.ValueOf(value))
+		return nil
+	}
+
+	if target.Kind() == reflect.Interface {
+		if target.NumMethod() > 0 {
+			return fmt.Errorf("cannot decode into non-empty interface %s", target.Type())
+		}
+		natural := naturalValue(value)
+		if natural == nil {
+			target.SetZero()
+		} else {
+			target.Set(reflect.ValueOf(natural))
+		}
+		return nil
+	}
+
+	if optional, ok := value.(cadence.Optional); ok {
+		if optional.Value == nil {
+			target.SetZero()
+			return nil
+		}
+		return decodeValue(optional.Value, target)
+	}
+
+	if target.Kind() == reflect.Pointer {
+		if value == nil {
+			target.SetZero()
+			return nil
+		}
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		return decodeValue(value, target.Elem())
+	}
+
+	if value == nil {
+		target.SetZero()
+		return nil
+	}
+
+	switch target.Type() {
+	case addressType:
+		address, ok := value.(cadence.Address)
+		if !ok {
+			return typeError(value, target)
+		}
+		target.Set(reflect.ValueOf(flow.BytesToAddress(address.Bytes())))
+		return nil
+
+	case bigIntType:
+		i, ok := valueToBig(value)
+		if !ok {
+			return typeError(value, target)
+		}
+		target.Set(reflect.ValueOf(*i))
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.Bool:
+		b, ok := value.(cadence.Bool)
+		if !ok {
+			return typeError(value, target)
+		}
+		target.SetBool(bool(b))
+		return nil
+
+	case reflect.String:
+		switch value := value.(type) {
+		case cadence.String:
+			target.SetString(string(value))
+		case cadence.Character:
+			target.SetString(string(value))
+		case cadence.Address:
+			target.SetString(flow.BytesToAddress(value.Bytes()).HexWithPrefix())
+		case cadence.TypeValue:
+			if value.StaticType == nil {
+				target.SetString("")
+			} else {
+				target.SetString(value.StaticType.ID())
+			}
+		case cadence.Path, cadence.UFix64, cadence.Fix64, cadence.UFix128, cadence.Fix128:
+			target.SetString(value.String())
+		default:
+			i, ok := valueToBig(value)
+			if !ok {
+				return typeError(value, target)
+			}
+			target.SetString(i.String())
+		}
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := valueToBig(value)
+		if !ok || !i.IsInt64() || target.OverflowInt(i.Int64()) {
+			return typeError(value, target)
+		}
+		target.SetInt(i.Int64())
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, ok := valueToBig(value)
+		if !ok || i.Sign() < 0 || !i.IsUint64() || target.OverflowUint(i.Uint64()) {
+			return typeError(value, target)
+		}
+		target.SetUint(i.Uint64())
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		var s string
+		switch value := value.(type) {
+		case cadence.UFix64, cadence.Fix64, cadence.UFix128, cadence.Fix128:
+			s = value.String()
+		default:
+			i, ok := valueToBig(value)
+			if !ok {
+				return typeError(value, target)
+			}
+			s = i.String()
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return typeError(value, target)
+		}
+		target.SetFloat(f)
+		return nil
+
+	case reflect.Slice:
+		array, ok := value.(cadence.Array)
+		if !ok {
+			return typeError(value, target)
+		}
+		slice := reflect.MakeSlice(target.Type(), len(array.Values), len(array.Values))
+		for i, element := range array.Values {
+			if err := decodeValue(element, slice.Index(i)); err != nil {
+				return fmt.Errorf("failed to decode element %d: %w", i, err)
+			}
+		}
+		target.Set(slice)
+		return nil
+
+	case reflect.Array:
+		array, ok := value.(cadence.Array)
+		if !ok || len(array.Values) != target.Len() {
+			return typeError(value, target)
+		}
+		for i, element := range array.Values {
+			if err := decodeValue(element, target.Index(i)); err != nil {
+				return fmt.Errorf("failed to decode element %d: %w", i, err)
+			}
+		}
+		return nil
+
+	case reflect.Map:
+		if composite, ok := value.(cadence.Composite); ok && target.Type().Key().Kind() == reflect.String {
+			return decodeCompositeMap(composite, target)
+		}
+
+		dictionary, ok := value.(cadence.Dictionary)
+		if !ok {
+			return typeError(value, target)
+		}
+		m := reflect.MakeMapWithSize(target.Type(), len(dictionary.Pairs))
+		for _, pair := range dictionary.Pairs {
+			key := reflect.New(target.Type().Key()).Elem()
+			if err := decodeValue(pair.Key, key); err != nil {
+				return fmt.Errorf("failed to decode dictionary key: %w", err)
+			}
+			element := reflect.New(target.Type().Elem()).Elem()
+			if err := decodeValue(pair.Value, element); err != nil {
+				return fmt.Errorf("failed to decode dictionary value for key %s: %w", pair.Key, err)
+			}
+			m.SetMapIndex(key, element)
+		}
+		target.Set(m)
+		return nil
+
+	case reflect.Struct:
+		composite, ok := value.(cadence.Composite)
+		if !ok {
+			return typeError(value, target)
+		}
+		return decodeStruct(composite, target)
+	}
+
+	return typeError(value, target)
+}
+
+func decodeStruct(composite cadence.Composite, target reflect.Value) error {
+	fields := cadence.FieldsMappedByName(composite)
+
+	for _, info := range structFields(target.Type()) {
+		value, ok := fields[info.name]
+		if !ok {
+			continue
+		}
+		if err := decodeValue(value, target.Field(info.index)); err != nil {
+			return fmt.Errorf("failed to decode field %s: %w", info.name, err)
+		}
+	}
+
+	return nil
+}
+
+func decodeCompositeMap(composite cadence.Composite, target reflect.Value) error {
+	fields := cadence.FieldsMappedByName(composite)
+
+	m := reflect.MakeMapWithSize(target.Type(), len(fields))
+	for name, value := range fields {
+		element := reflect.New(target.Type().Elem()).Elem()
+		if err := decodeValue(value, element); err != nil {
+			return fmt.Errorf("failed to decode field %s: %w", name, err)
+		}
+		m.SetMapIndex(reflect.ValueOf(name).Convert(target.Type().Key()), element)
+	}
+	target.Set(m)
+	return nil
+}
+
+// valueToBig returns the integer value of a Cadence integer, or the raw value of a fixed-point number or enum.
+func valueToBig(value cadence.Value) (*big.Int, bool) {
+	switch value := value.(type) {
+	case bigIntValue:
+		return value.Big(), true
+	case cadence.Int8:
+		return big.NewInt(int64(value)), true
+	case cadence.Int16:
+		return big.NewInt(int64(value)), true
+	case cadence.Int32:
+		return big.NewInt(int64(value)), true
+	case cadence.Int64:
+		return big.NewInt(int64(value)), true
+	case cadence.UInt8:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case cadence.UInt16:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case cadence.UInt32:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case cadence.UInt64:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case cadence.Word8:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case cadence.Word16:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case cadence.Word32:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case cadence.Word64:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case cadence.UFix64:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case cadence.Fix64:
+		return big.NewInt(int64(value)), true
+	case cadence.Enum:
+		rawValue := cadence.SearchFieldByName(value, "rawValue")
+		if rawValue == nil {
+			return nil, false
+		}
+		return valueToBig(rawValue)
+	}
+	return nil, false
+}
+
+// naturalValue converts a Cadence value into a natural Go representation, used when decoding into an empty interface.
+func naturalValue(value cadence.Value) any {
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case cadence.Optional:
+		return naturalValue(value.Value)
+	case cadence.Bool:
+		return bool(value)
+	case cadence.String:
+		return string(value)
+	case cadence.Character:
+		return string(value)
+	case cadence.Address:
+		return flow.BytesToAddress(value.Bytes())
+	case cadence.Path, cadence.UFix64, cadence.Fix64, cadence.UFix128, cadence.Fix128:
+		return value.String()
+	case cadence.TypeValue:
+		if value.StaticType == nil {
+			return ""
+		}
+		return value.StaticType.ID()
+	case cadence.Array:
+		values := make([]any, len(value.Values))
+		for i, element := range value.Values {
+			values[i] = naturalValue(element)
+		}
+		return values
+	case cadence.Dictionary:
+		m := make(map[any]any, len(value.Pairs))
+		for _, pair := range value.Pairs {
+			key := naturalValue(pair.Key)
+			switch k := key.(type) {
+			case *big.Int:
+				key = k.String()
+			default:
+				if key != nil && !reflect.TypeOf(key).Comparable() {
+					key = pair.Key.String()
+				}
+			}
+			m[key] = naturalValue(pair.Value)
+		}
+		return m
+	case cadence.Enum:
+		if i, ok := valueToBig(value); ok {
+			return i
+		}
+	case cadence.Composite:
+		fields := cadence.FieldsMappedByName(value)
+		m := make(map[string]any, len(fields))
+		for name, field := range fields {
+			m[name] = naturalValue(field)
+		}
+		return m
+	}
+
+	if i, ok := valueToBig(value); ok {
+		return i
+	}
+	return value
+}
+
+func typeError(value cadence.Value, target reflect.Value) error {
+	typeID := "<nil>"
+	if value != nil {
+		if t := value.Type(); t != nil {
+			typeID = t.ID()
+		} else {
+			typeID = fmt.Sprintf("%T", value)
+		}
+	}
+	return fmt.Errorf("cannot decode Cadence value of type %s into Go type %s", typeID, target.Type())
+}
@@ -0,0 +1,369 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package codec
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+var (
+	cadenceValueType   = reflect.TypeFor[cadence.Value]()
+	bigIntType         = reflect.TypeFor[big.Int]()
+	addressType        = reflect.TypeFor[flow.Address]()
+	typeIdentifierType = reflect.TypeFor[TypeIdentifier]()
+)
+
+// Encode converts a Go value to a Cadence value.
+//
+// See the package documentation for the mapping of Go types to Cadence types.
+func Encode(v any) (cadence.Value, error) {
+	if v == nil {
+		return cadence.NewOptional(nil), nil
+	}
+	return encodeValue(reflect.ValueOf(v), "")
+}
+
+// EncodeArguments converts a list of Go values to Cadence values, e.g. to pass them as script arguments.
+func EncodeArguments(args ...any) ([]cadence.Value, error) {
+	values := make([]cadence.Value, len(args))
+	for i, arg := range args {
+		value, err := Encode(arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode argument %d: %w", i, err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// AddArguments encodes the given Go values and adds them as arguments to the transaction.
+func AddArguments(tx *flow.Transaction, args ...any) error {
+	values, err := EncodeArguments(args...)
+	if err != nil {
+		return err
+	}
+
+	for _, value := range values {
+		if err := tx.AddArgument(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func encodeValue(v reflect.Value, typeName string) (cadence.Value, error) {
+	if !v.IsValid() {
+		return cadence.NewOptional(nil), nil
+	}
+
+	if typeName == "" && v.Type().Implements(cadenceValueType) {
+		if v.Kind() == reflect.Interface && v.IsNil() {
+			return cadence.NewOptional(nil), nil
+		}
+		return v.Interface().(cadence.Value), nil
+	}
+
+	switch v.Type() {
+	case addressType:
+		return cadence.NewAddress(v.Interface().(flow.Address)), nil
+	case bigIntType:
+		i := v.Interface().(big.Int)
+		return encodeBigInt(&i, typeName)
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return cadence.NewOptional(nil), nil
+		}
+		return encodeValue(v.Elem(), typeName)
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			return cadence.NewOptional(nil), nil
+		}
+		if v.Type().Elem() == bigIntType {
+			return encodeBigInt(v.Interface().(*big.Int), typeName)
+		}
+		value, err := encodeValue(v.Elem(), strings.TrimSuffix(typeName, "?"))
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewOptional(value), nil
+
+	case reflect.Bool:
+		return cadence.NewBool(v.Bool()), nil
+
+	case reflect.String:
+		return encodeString(v.String(), typeName)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if typeName != "" {
+			return encodeBigInt(big.NewInt(v.Int()), typeName)
+		}
+		switch v.Kind() {
+		case reflect.Int8:
+			return cadence.NewInt8(int8(v.Int())), nil
+		case reflect.Int16:
+			return cadence.NewInt16(int16(v.Int())), nil
+		case reflect.Int32:
+			return cadence.NewInt32(int32(v.Int())), nil
+		case reflect.Int64:
+			return cadence.NewInt64(v.Int()), nil
+		default:
+			return cadence.NewInt(int(v.Int())), nil
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if typeName != "" {
+			return encodeBigInt(new(big.Int).SetUint64(v.Uint()), typeName)
+		}
+		switch v.Kind() {
+		case reflect.Uint8:
+			return cadence.NewUInt8(uint8(v.Uint())), nil
+		case reflect.Uint16:
+			return cadence.NewUInt16(uint16(v.Uint())), nil
+		case reflect.Uint32:
+			return cadence.NewUInt32(uint32(v.Uint())), nil
+		case reflect.Uint64:
+			return cadence.NewUInt64(v.Uint()), nil
+		default:
+			return cadence.NewUInt(uint(v.Uint())), nil
+		}
+
+	case reflect.Float32, reflect.Float64:
+		if typeName != "UFix64" && typeName != "Fix64" {
+			return nil, fmt.Errorf("cannot encode %s without a UFix64 or Fix64 type tag", v.Type())
+		}
+		return encodeString(strconv.FormatFloat(v.Float(), 'f', 8, 64), typeName)
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return cadence.NewArray(nil), nil
+		}
+		elementTypeName := strings.TrimSuffix(strings.TrimPrefix(typeName, "["), "]")
+		values := make([]cadence.Value, v.Len())
+		for i := range values {
+			value, err := encodeValue(v.Index(i), elementTypeName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode element %d: %w", i, err)
+			}
+			values[i] = value
+		}
+		return cadence.NewArray(values), nil
+
+	case reflect.Map:
+		pairs := make([]cadence.KeyValuePair, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, err := encodeValue(iter.Key(), "")
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode dictionary key: %w", err)
+			}
+			value, err := encodeValue(iter.Value(), "")
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode dictionary value for key %s: %w", key, err)
+			}
+			pairs = append(pairs, cadence.KeyValuePair{Key: key, Value: value})
+		}
+		// map iteration order is random, sort the pairs to make the encoding deterministic
+		sort.Slice(pairs, func(i, j int) bool {
+			return pairs[i].Key.String() < pairs[j].Key.String()
+		})
+		return cadence.NewDictionary(pairs), nil
+
+	case reflect.Struct:
+		return encodeStruct(v)
+	}
+
+	return nil, fmt.Errorf("cannot encode Go type %s to a Cadence value", v.Type())
+}
+
+func encodeStruct(v reflect.Value) (cadence.Value, error) {
+	if !v.Type().Implements(typeIdentifierType) {
+		return nil, fmt.Errorf("cannot encode Go struct %s: it does not implement codec.TypeIdentifier", v.Type())
+	}
+
+	typeID := v.Interface().(TypeIdentifier).CadenceTypeID()
+	location, qualifiedIdentifier, err := common.DecodeTypeID(nil, typeID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Cadence type ID %q: %w", typeID, err)
+	}
+
+	infos := structFields(v.Type())
+	values := make([]cadence.Value, len(infos))
+	fields := make([]cadence.Field, len(infos))
+
+	for i, info := range infos {
+		value, err := encodeValue(v.Field(info.index), info.typeName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode field %s: %w", info.name, err)
+		}
+
+		fieldType := value.Type()
+		if fieldType == nil {
+			fieldType = cadence.AnyStructType
+		}
+
+		values[i] = value
+		fields[i] = cadence.Field{
+			Identifier: info.name,
+			Type:       fieldType,
+		}
+	}
+
+	return cadence.NewStruct(values).
+		WithType(cadence.NewStructType(location, qualifiedIdentifier, fields, nil)), nil
+}
+
+func encodeString(s string, typeName string) (cadence.Value, error) {
+	switch typeName {
+	case "", "String":
+		return cadence.NewString(s)
+	case "Character":
+		return cadence.NewCharacter(s)
+	case "UFix64":
+		return cadence.NewUFix64(s)
+	case "Fix64":
+		return cadence.NewFix64(s)
+	case "Address":
+		return cadence.NewAddress(flow.HexToAddress(s)), nil
+	case "Path", "StoragePath", "PublicPath", "PrivatePath":
+		domain, identifier, ok := strings.Cut(strings.TrimPrefix(s, "/"), "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid path %q", s)
+		}
+		return cadence.NewPath(common.PathDomainFromIdentifier(domain), identifier)
+	}
+
+	i, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("cannot encode string %q as %s", s, typeName)
+	}
+	return encodeBigInt(i, typeName)
+}
+
+func encodeBigInt(i *big.Int, typeName string) (cadence.Value, error) {
+	if i == nil {
+		return cadence.NewOptional(nil), nil
+	}
+
+	inRange := func(min, max int64) bool {
+		return i.Cmp(big.NewInt(min)) >= 0 && i.Cmp(big.NewInt(max)) <= 0
+	}
+	inRangeUnsigned := func(bits uint) bool {
+		return i.Sign() >= 0 && i.BitLen() <= int(bits)
+	}
+	outOfRange := func() (cadence.Value, error) {
+		return nil, fmt.Errorf("integer %s is out of range for %s", i, typeName)
+	}
+
+	switch typeName {
+	case "", "Int":
+		return cadence.NewIntFromBig(i), nil
+	case "Int8":
+		if !inRange(-1<<7, 1<<7-1) {
+			return outOfRange()
+		}
+		return cadence.NewInt8(int8(i.Int64())), nil
+	case "Int16":
+		if !inRange(-1<<15, 1<<15-1) {
+			return outOfRange()
+		}
+		return cadence.NewInt16(int16(i.Int64())), nil
+	case "Int32":
+		if !inRange(-1<<31, 1<<31-1) {
+			return outOfRange()
+		}
+		return cadence.NewInt32(int32(i.Int64())), nil
+	case "Int64":
+		if !i.IsInt64() {
+			return outOfRange()
+		}
+		return cadence.NewInt64(i.Int64()), nil
+	case "Int128":
+		return cadence.NewInt128FromBig(i)
+	case "Int256":
+		return cadence.NewInt256FromBig(i)
+	case "UInt":
+		return cadence.NewUIntFromBig(i)
+	case "UInt8", "Word8":
+		if !inRangeUnsigned(8) {
+			return outOfRange()
+		}
+		if typeName == "Word8" {
+			return cadence.NewWord8(uint8(i.Uint64())), nil
+		}
+		return cadence.NewUInt8(uint8(i.Uint64())), nil
+	case "UInt16", "Word16":
+		if !inRangeUnsigned(16) {
+			return outOfRange()
+		}
+		if typeName == "Word16" {
+			return cadence.NewWord16(uint16(i.Uint64())), nil
+		}
+		return cadence.NewUInt16(uint16(i.Uint64())), nil
+	case "UInt32", "Word32":
+		if !inRangeUnsigned(32) {
+			return outOfRange()
+		}
+		if typeName == "Word32" {
+			return cadence.NewWord32(uint32(i.Uint64())), nil
+		}
+		return cadence.NewUInt32(uint32(i.Uint64())), nil
+	case "UInt64", "Word64":
+		if !inRangeUnsigned(64) {
+			return outOfRange()
+		}
+		if typeName == "Word64" {
+			return cadence.NewWord64(i.Uint64()), nil
+		}
+		return cadence.NewUInt64(i.Uint64()), nil
+	case "UInt128":
+		return cadence.NewUInt128FromBig(i)
+	case "UInt256":
+		return cadence.NewUInt256FromBig(i)
+	case "Word128":
+		return cadence.NewWord128FromBig(i)
+	case "Word256":
+		return cadence.NewWord256FromBig(i)
+	case "UFix64":
+		if !inRangeUnsigned(64) {
+			return outOfRange()
+		}
+		return cadence.UFix64(i.Uint64()), nil
+	case "Fix64":
+		if !i.IsInt64() {
+			return outOfRange()
+		}
+		return cadence.Fix64(i.Int64()), nil
+	}
+
+	return nil, fmt.Errorf("cannot encode integer as %s", typeName)
+}
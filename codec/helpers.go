@@ -0,0 +1,96 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package codec
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+)
+
+// ExecuteScript executes a script at the latest sealed block and decodes the result into a value of type T.
+//
+// The arguments are Go values encoded with Encode.
+func ExecuteScript[T any](ctx context.Context, client access.Client, script []byte, args ...any) (T, error) {
+	return executeScript[T](args, func(arguments []cadence.Value) (cadence.Value, error) {
+		return client.ExecuteScriptAtLatestBlock(ctx, script, arguments)
+	})
+}
+
+// ExecuteScriptAtBlockHeight executes a script at the given block height and decodes the result into a value of type T.
+//
+// The arguments are Go values encoded with Encode.
+func ExecuteScriptAtBlockHeight[T any](
+	ctx context.Context,
+	client access.Client,
+	height uint64,
+	script []byte,
+	args ...any,
+) (T, error) {
+	return executeScript[T](args, func(arguments []cadence.Value) (cadence.Value, error) {
+		return client.ExecuteScriptAtBlockHeight(ctx, height, script, arguments)
+	})
+}
+
+// ExecuteScriptAtBlockID executes a script at the given block ID and decodes the result into a value of type T.
+//
+// The arguments are Go values encoded with Encode.
+func ExecuteScriptAtBlockID[T any](
+	ctx context.Context,
+	client access.Client,
+	blockID flow.Identifier,
+	script []byte,
+	args ...any,
+) (T, error) {
+	return executeScript[T](args, func(arguments []cadence.Value) (cadence.Value, error) {
+		return client.ExecuteScriptAtBlockID(ctx, blockID, script, arguments)
+	})
+}
+
+func executeScript[T any](args []any, execute func([]cadence.Value) (cadence.Value, error)) (T, error) {
+	var result T
+
+	arguments, err := EncodeArguments(args...)
+	if err != nil {
+		return result, err
+	}
+
+	value, err := execute(arguments)
+	if err != nil {
+		return result, err
+	}
+
+	if err := Decode(value, &result); err != nil {
+		return result, fmt.Errorf("failed to decode script result: %w", err)
+	}
+	return result, nil
+}
+
+// DecodeEvent decodes the fields of an event into a value of type T, usually a Go struct.
+func DecodeEvent[T any](event flow.Event) (T, error) {
+	var result T
+	if err := Decode(event.Value, &result); err != nil {
+		return result, fmt.Errorf("failed to decode event %s: %w", event.Type, err)
+	}
+	return result, nil
+}
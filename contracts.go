@@ -0,0 +1,187 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"errors"
+	"fmt"
+)
+
+// List of core contract names.
+//
+// See https://developers.flow.com/build/core-contracts for a description of each contract.
+const (
+	ContractFungibleToken              = "FungibleToken"
+	ContractFungibleTokenMetadataViews = "FungibleTokenMetadataViews"
+	ContractFungibleTokenSwitchboard   = "FungibleTokenSwitchboard"
+	ContractBurner                     = "Burner"
+	ContractFlowToken                  = "FlowToken"
+	ContractFlowFees                   = "FlowFees"
+	ContractFlowServiceAccount         = "FlowServiceAccount"
+	ContractFlowStorageFees            = "FlowStorageFees"
+	ContractNodeVersionBeacon          = "NodeVersionBeacon"
+	ContractRandomBeaconHistory        = "RandomBeaconHistory"
+	ContractEVM                        = "EVM"
+	ContractCrypto                     = "Crypto"
+	ContractNonFungibleToken           = "NonFungibleToken"
+	ContractMetadataViews              = "MetadataViews"
+	ContractViewResolver               = "ViewResolver"
+	ContractFlowIDTableStaking         = "FlowIDTableStaking"
+	ContractFlowEpoch                  = "FlowEpoch"
+	ContractFlowClusterQC              = "FlowClusterQC"
+	ContractFlowDKG                    = "FlowDKG"
+	ContractLockedTokens               = "LockedTokens"
+	ContractFlowStakingCollection      = "FlowStakingCollection"
+	ContractStakingProxy               = "StakingProxy"
+)
+
+// ErrUnknownContract is returned when a contract is not deployed on a network, or the network is not supported.
+var ErrUnknownContract = errors.New("unknown contract")
+
+// ContractAddresses maps contract names to the addresses of the accounts they are deployed to.
+type ContractAddresses map[string]Address
+
+// Address returns the address of the named contract.
+func (c ContractAddresses) Address(name string) (Address, error) {
+	address, ok := c[name]
+	if !ok {
+		return EmptyAddress, fmt.Errorf("%w: %s", ErrUnknownContract, name)
+	}
+	return address, nil
+}
+
+// CoreContracts returns the addresses of the core contracts on the given network.
+//
+// The emulator and other transient networks (localnet, benchnet) share the same addresses.
+// The returned map is a copy and may be modified by the caller.
+func CoreContracts(chainID ChainID) (ContractAddresses, error) {
+	var contracts ContractAddresses
+
+	switch chainID {
+	case Mainnet:
+		contracts = mainnetContracts
+	case Testnet:
+		contracts = testnetContracts
+	case Emulator, Localnet, Benchnet, BftTestnet:
+		contracts = transientNetworkContracts(chainID)
+	default:
+		return nil, fmt.Errorf("%w: no core contracts for chain %s", ErrUnknownContract, chainID)
+	}
+
+	result := make(ContractAddresses, len(contracts))
+	for name, address := range contracts {
+		result[name] = address
+	}
+	return result, nil
+}
+
+// CoreContractAddress returns the address of the named core contract on the given network.
+func CoreContractAddress(chainID ChainID, name string) (Address, error) {
+	contracts, err := CoreContracts(chainID)
+	if err != nil {
+		return EmptyAddress, err
+	}
+	return contracts.Address(name)
+}
+
+var mainnetContracts = ContractAddresses{
+	ContractFungibleToken:              HexToAddress("f233dcee88fe0abe"),
+	ContractFungibleTokenMetadataViews: HexToAddress("f233dcee88fe0abe"),
+	ContractFungibleTokenSwitchboard:   HexToAddress("f233dcee88fe0abe"),
+	ContractBurner:                     HexToAddress("f233dcee88fe0abe"),
+	ContractFlowToken:                  HexToAddress("1654653399040a61"),
+	ContractFlowFees:                   HexToAddress("f919ee77447b7497"),
+	ContractFlowServiceAccount:         HexToAddress("e467b9dd11fa00df"),
+	ContractFlowStorageFees:            HexToAddress("e467b9dd11fa00df"),
+	ContractNodeVersionBeacon:          HexToAddress("e467b9dd11fa00df"),
+	ContractRandomBeaconHistory:        HexToAddress("e467b9dd11fa00df"),
+	ContractEVM:                        HexToAddress("e467b9dd11fa00df"),
+	ContractCrypto:                     HexToAddress("e467b9dd11fa00df"),
+	ContractNonFungibleToken:           HexToAddress("1d7e57aa55817448"),
+	ContractMetadataViews:              HexToAddress("1d7e57aa55817448"),
+	ContractViewResolver:               HexToAddress("1d7e57aa55817448"),
+	ContractFlowIDTableStaking:         HexToAddress("8624b52f9ddcd04a"),
+	ContractFlowEpoch:                  HexToAddress("8624b52f9ddcd04a"),
+	ContractFlowClusterQC:              HexToAddress("8624b52f9ddcd04a"),
+	ContractFlowDKG:                    HexToAddress("8624b52f9ddcd04a"),
+	ContractLockedTokens:               HexToAddress("8d0e87b65159ae63"),
+	ContractFlowStakingCollection:      HexToAddress("8d0e87b65159ae63"),
+	ContractStakingProxy:               HexToAddress("62430cf28c26d095"),
+}
+
+var testnetContracts = ContractAddresses{
+	ContractFungibleToken:              HexToAddress("9a0766d93b6608b7"),
+	ContractFungibleTokenMetadataViews: HexToAddress("9a0766d93b6608b7"),
+	ContractFungibleTokenSwitchboard:   HexToAddress("9a0766d93b6608b7"),
+	ContractBurner:                     HexToAddress("9a0766d93b6608b7"),
+	ContractFlowToken:                  HexToAddress("7e60df042a9c0868"),
+	ContractFlowFees:                   HexToAddress("912d5440f7e3769e"),
+	ContractFlowServiceAccount:         HexToAddress("8c5303eaa26202d6"),
+	ContractFlowStorageFees:            HexToAddress("8c5303eaa26202d6"),
+	ContractNodeVersionBeacon:          HexToAddress("8c5303eaa26202d6"),
+	ContractRandomBeaconHistory:        HexToAddress("8c5303eaa26202d6"),
+	ContractEVM:                        HexToAddress("8c5303eaa26202d6"),
+	ContractCrypto:                     HexToAddress("8c5303eaa26202d6"),
+	ContractNonFungibleToken:           HexToAddress("631e88ae7f1d7c20"),
+	ContractMetadataViews:              HexToAddress("631e88ae7f1d7c20"),
+	ContractViewResolver:               HexToAddress("631e88ae7f1d7c20"),
+	ContractFlowIDTableStaking:         HexToAddress("9eca2b38b18b5dfe"),
+	ContractFlowEpoch:                  HexToAddress("9eca2b38b18b5dfe"),
+	ContractFlowClusterQC:              HexToAddress("9eca2b38b18b5dfe"),
+	ContractFlowDKG:                    HexToAddress("9eca2b38b18b5dfe"),
+	ContractLockedTokens:               HexToAddress("95e019a17d0e23d7"),
+	ContractFlowStakingCollection:      HexToAddress("95e019a17d0e23d7"),
+	ContractStakingProxy:               HexToAddress("7aad92e5a0715d21"),
+}
+
+// transientNetworkContracts returns the core contract addresses of a network bootstrapped like the emulator.
+//
+// The service account deploys most contracts, the fungible token, FLOW token and fees contracts are deployed
+// to the following accounts, e.g. 0xee82856bf20e2aa6, 0x0ae53cb6e3f42a79 and 0xe5a8b7f23e8b548f on the emulator.
+func transientNetworkContracts(chainID ChainID) ContractAddresses {
+	service := ServiceAddress(chainID)
+	fungibleToken := generateAddress(chainID, serviceAddressState+1)
+	flowToken := generateAddress(chainID, serviceAddressState+2)
+	flowFees := generateAddress(chainID, serviceAddressState+3)
+
+	return ContractAddresses{
+		ContractFungibleToken:              fungibleToken,
+		ContractFungibleTokenMetadataViews: fungibleToken,
+		ContractFungibleTokenSwitchboard:   fungibleToken,
+		ContractBurner:                     service,
+		ContractFlowToken:                  flowToken,
+		ContractFlowFees:                   flowFees,
+		ContractFlowServiceAccount:         service,
+		ContractFlowStorageFees:            service,
+		ContractNodeVersionBeacon:          service,
+		ContractRandomBeaconHistory:        service,
+		ContractEVM:                        service,
+		ContractCrypto:                     service,
+		ContractNonFungibleToken:           service,
+		ContractMetadataViews:              service,
+		ContractViewResolver:               service,
+		ContractFlowIDTableStaking:         service,
+		ContractFlowEpoch:                  service,
+		ContractFlowClusterQC:              service,
+		ContractFlowDKG:                    service,
+		ContractLockedTokens:               service,
+		ContractFlowStakingCollection:      service,
+		ContractStakingProxy:               service,
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+func TestCoreContracts(t *testing.T) {
+	t.Run("Mainnet", func(t *testing.T) {
+		address, err := flow.CoreContractAddress(flow.Mainnet, flow.ContractFlowToken)
+		require.NoError(t, err)
+		assert.Equal(t, flow.HexToAddress("1654653399040a61"), address)
+
+		address, err = flow.CoreContractAddress(flow.Mainnet, flow.ContractFlowServiceAccount)
+		require.NoError(t, err)
+		assert.Equal(t, flow.ServiceAddress(flow.Mainnet), address)
+	})
+
+	t.Run("Emulator", func(t *testing.T) {
+		contracts, err := flow.CoreContracts(flow.Emulator)
+		require.NoError(t, err)
+
+		assert.Equal(t, flow.HexToAddress("f8d6e0586b0a20c7"), contracts[flow.ContractFlowServiceAccount])
+		assert.Equal(t, flow.HexToAddress("ee82856bf20e2aa6"), contracts[flow.ContractFungibleToken])
+		assert.Equal(t, flow.HexToAddress("0ae53cb6e3f42a79"), contracts[flow.ContractFlowToken])
+		assert.Equal(t, flow.HexToAddress("e5a8b7f23e8b548f"), contracts[flow.ContractFlowFees])
+	})
+
+	t.Run("All networks cover the same contracts", func(t *testing.T) {
+		mainnet, err := flow.CoreContracts(flow.Mainnet)
+		require.NoError(t, err)
+
+		for _, chainID := range []flow.ChainID{flow.Testnet, flow.Emulator, flow.Localnet} {
+			contracts, err := flow.CoreContracts(chainID)
+			require.NoError(t, err)
+
+			for name := range mainnet {
+				address, err := contracts.Address(name)
+				require.NoError(t, err, "%s on %s", name, chainID)
+				assert.True(t, address.IsValid(chainID), "%s on %s", name, chainID)
+			}
+		}
+	})
+
+	t.Run("Copy", func(t *testing.T) {
+		contracts, err := flow.CoreContracts(flow.Testnet)
+		require.NoError(t, err)
+		contracts[flow.ContractFlowToken] = flow.EmptyAddress
+
+		address, err := flow.CoreContractAddress(flow.Testnet, flow.ContractFlowToken)
+		require.NoError(t, err)
+		assert.Equal(t, flow.HexToAddress("7e60df042a9c0868"), address)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := flow.CoreContractAddress(flow.Mainnet, "Foo")
+		require.ErrorIs(t, err, flow.ErrUnknownContract)
+
+		_, err = flow.CoreContracts(flow.MonotonicEmulator)
+		require.ErrorIs(t, err, flow.ErrUnknownContract)
+	})
+}
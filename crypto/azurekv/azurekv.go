@@ -0,0 +1,327 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package azurekv provides an Azure Key Vault
+// implementation of the crypto.Signer interface.
+//
+// The client uses the Key Vault REST API directly. Requests are authorized with access tokens
+// for the https://vault.azure.net/.default scope, e.g. obtained with the azidentity package:
+//
+//	client := azurekv.NewClient(azurekv.TokenCredentialFunc(func(ctx context.Context) (string, error) {
+//		token, err := credential.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{azurekv.Scope}})
+//		return token.Token, err
+//	}))
+//
+// The documentation for Azure Key Vault can be found here: https://learn.microsoft.com/azure/key-vault/keys/
+package azurekv
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+const (
+	// Scope is the OAuth scope of access tokens for Key Vault.
+	Scope = "https://vault.azure.net/.default"
+	// DefaultAPIVersion is the Key Vault REST API version used by default.
+	DefaultAPIVersion = "7.4"
+)
+
+// Key is a reference to an Azure Key Vault key.
+//
+// If the version is empty, the current version of the key is used.
+type Key struct {
+	VaultURL string `json:"vaultUrl"`
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+}
+
+// ID returns the key identifier of this key.
+//
+// Example ID format: "https://my-vault.vault.azure.net/keys/my-key/78deebed173b48e48f55abf87ed4cf71"
+func (k Key) ID() string {
+	id := strings.TrimSuffix(k.VaultURL, "/") + "/keys/" + k.Name
+	if k.Version != "" {
+		id += "/" + k.Version
+	}
+	return id
+}
+
+// KeyFromID returns a `Key` from a key identifier.
+func KeyFromID(id string) (Key, error) {
+	u, err := url.Parse(id)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return Key{}, fmt.Errorf("azurekv: wrong format for the key ID: %s", id)
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) < 2 || len(segments) > 3 || segments[0] != "keys" || segments[1] == "" {
+		return Key{}, fmt.Errorf("azurekv: wrong format for the key ID: %s", id)
+	}
+
+	key := Key{
+		VaultURL: u.Scheme + "://" + u.Host,
+		Name:     segments[1],
+	}
+	if len(segments) == 3 {
+		key.Version = segments[2]
+	}
+
+	return key, nil
+}
+
+// TokenCredential provides access tokens for Key Vault.
+type TokenCredential interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenCredentialFunc is a function implementing TokenCredential.
+type TokenCredentialFunc func(ctx context.Context) (string, error)
+
+// Token returns an access token.
+func (f TokenCredentialFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// Client is a client for interacting with the Azure Key Vault API
+// using types native to the Flow Go SDK.
+type Client struct {
+	credential TokenCredential
+	httpClient *http.Client
+	apiVersion string
+}
+
+// An Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithAPIVersion sets the Key Vault REST API version.
+func WithAPIVersion(version string) Option {
+	return func(c *Client) {
+		c.apiVersion = version
+	}
+}
+
+// NewClient creates a new Azure Key Vault client.
+func NewClient(credential TokenCredential, opts ...Option) *Client {
+	c := &Client{
+		credential: credential,
+		httpClient: http.DefaultClient,
+		apiVersion: DefaultAPIVersion,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// jsonWebKey is the subset of an elliptic curve JSON web key returned by Key Vault.
+type jsonWebKey struct {
+	KID string `json:"kid"`
+	KTY string `json:"kty"`
+	CRV string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// GetPublicKey fetches the public key of a Key Vault key.
+//
+// EC keys on the curves `P-256` and `P-256K` are the only keys supported by the SDK.
+//
+// Ref: https://learn.microsoft.com/rest/api/keyvault/keys/get-key/get-key
+func (c *Client) GetPublicKey(ctx context.Context, key Key) (crypto.PublicKey, crypto.HashAlgorithm, error) {
+	_, publicKey, hashAlgo, err := c.getKey(ctx, key)
+	return publicKey, hashAlgo, err
+}
+
+// getKey fetches a Key Vault key and returns it with the version resolved.
+func (c *Client) getKey(ctx context.Context, key Key) (Key, crypto.PublicKey, crypto.HashAlgorithm, error) {
+	var result struct {
+		Key jsonWebKey `json:"key"`
+	}
+	err := c.do(ctx, http.MethodGet, key.ID(), nil, &result)
+	if err != nil {
+		return Key{}, nil,
+			crypto.UnknownHashAlgorithm,
+			fmt.Errorf("azurekv: failed to fetch public key from Key Vault API: %w", err)
+	}
+	jwk := result.Key
+
+	if jwk.KTY != "EC" && jwk.KTY != "EC-HSM" {
+		return Key{}, nil,
+			crypto.UnknownHashAlgorithm,
+			fmt.Errorf("azurekv: unsupported key type %s", jwk.KTY)
+	}
+
+	sigAlgo := parseSignatureAlgorithm(jwk.CRV)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return Key{}, nil,
+			crypto.UnknownHashAlgorithm,
+			fmt.Errorf("azurekv: unsupported signature algorithm %s", jwk.CRV)
+	}
+
+	x, errX := decodeBase64URL(jwk.X)
+	y, errY := decodeBase64URL(jwk.Y)
+	if errX != nil || errY != nil || len(x) > 32 || len(y) > 32 {
+		return Key{}, nil,
+			crypto.UnknownHashAlgorithm,
+			fmt.Errorf("azurekv: invalid public key coordinates")
+	}
+
+	encoded := make([]byte, 64)
+	copy(encoded[32-len(x):], x)
+	copy(encoded[64-len(y):], y)
+
+	publicKey, err := crypto.DecodePublicKey(sigAlgo, encoded)
+	if err != nil {
+		return Key{}, nil,
+			crypto.UnknownHashAlgorithm,
+			fmt.Errorf("azurekv: failed to decode public key: %w", err)
+	}
+
+	resolved := key
+	if jwk.KID != "" {
+		if resolved, err = KeyFromID(jwk.KID); err != nil {
+			return Key{}, nil, crypto.UnknownHashAlgorithm, err
+		}
+	}
+
+	return resolved, publicKey, parseHashAlgorithm(jwk.CRV), nil
+}
+
+// sign signs a digest with the key.
+//
+// Ref: https://learn.microsoft.com/rest/api/keyvault/keys/sign/sign
+func (c *Client) sign(ctx context.Context, key Key, alg string, digest []byte) ([]byte, error) {
+	request := struct {
+		Alg   string `json:"alg"`
+		Value string `json:"value"`
+	}{
+		Alg:   alg,
+		Value: base64.RawURLEncoding.EncodeToString(digest),
+	}
+
+	var result struct {
+		Value string `json:"value"`
+	}
+	if err := c.do(ctx, http.MethodPost, key.ID()+"/sign", request, &result); err != nil {
+		return nil, err
+	}
+
+	return decodeBase64URL(result.Value)
+}
+
+// do sends an authorized request to the Key Vault API and decodes the JSON response.
+func (c *Client) do(ctx context.Context, method string, endpoint string, body any, result any) error {
+	token, err := c.credential.Token(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint+"?api-version="+url.QueryEscape(c.apiVersion), reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		var errorResponse struct {
+			Error struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(data, &errorResponse) == nil && errorResponse.Error.Code != "" {
+			return fmt.Errorf("%s (%d): %s", errorResponse.Error.Code, res.StatusCode, errorResponse.Error.Message)
+		}
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
+
+	return json.Unmarshal(data, result)
+}
+
+// parseSignatureAlgorithm returns the `SignatureAlgorithm` corresponding to the input JSON web key curve.
+func parseSignatureAlgorithm(crv string) crypto.SignatureAlgorithm {
+	switch crv {
+	case "P-256":
+		return crypto.ECDSA_P256
+	case "P-256K", "SECP256K1":
+		return crypto.ECDSA_secp256k1
+	default:
+		return crypto.UnknownSignatureAlgorithm
+	}
+}
+
+// parseHashAlgorithm returns the `HashAlgorithm` corresponding to the input JSON web key curve.
+func parseHashAlgorithm(crv string) crypto.HashAlgorithm {
+	// the ES256 and ES256K signature algorithms of Key Vault use SHA2-256
+	if parseSignatureAlgorithm(crv) != crypto.UnknownSignatureAlgorithm {
+		return crypto.SHA2_256
+	}
+	return crypto.UnknownHashAlgorithm
+}
+
+// signingAlgorithm returns the Key Vault signature algorithm for keys of the signature algorithm.
+func signingAlgorithm(sigAlgo crypto.SignatureAlgorithm) string {
+	if sigAlgo == crypto.ECDSA_secp256k1 {
+		return "ES256K"
+	}
+	return "ES256"
+}
+
+func decodeBase64URL(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
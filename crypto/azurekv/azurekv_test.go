@@ -0,0 +1,255 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package azurekv_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/azurekv"
+)
+
+const (
+	testToken   = "test-token"
+	testVersion = "78deebed173b48e48f55abf87ed4cf71"
+)
+
+func TestKeyFromID(t *testing.T) {
+	key := azurekv.Key{
+		VaultURL: "https://my-vault.vault.azure.net",
+		Name:     "my-key",
+		Version:  testVersion,
+	}
+
+	id := key.ID()
+	assert.Equal(t, "https://my-vault.vault.azure.net/keys/my-key/"+testVersion, id)
+
+	keyFromID, err := azurekv.KeyFromID(id)
+	require.NoError(t, err)
+	assert.Equal(t, key, keyFromID)
+
+	keyFromID, err = azurekv.KeyFromID("https://my-vault.vault.azure.net/keys/my-key")
+	require.NoError(t, err)
+	assert.Equal(t, azurekv.Key{VaultURL: "https://my-vault.vault.azure.net", Name: "my-key"}, keyFromID)
+
+	for _, invalid := range []string{"my-key", "https://my-vault.vault.azure.net/secrets/my-key", "https://my-vault.vault.azure.net/keys/"} {
+		_, err := azurekv.KeyFromID(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+// testKeyVault is a stand-in for the Key Vault API, with a P-256 and a secp256k1 key.
+type testKeyVault struct {
+	p256      *ecdsa.PrivateKey
+	secp256k1 *ecdsa.PrivateKey
+}
+
+func newTestKeyVault(t *testing.T) *httptest.Server {
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	secp256k1, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+
+	kv := &testKeyVault{p256: p256, secp256k1: secp256k1}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /keys/{name}", kv.getKey)
+	mux.HandleFunc("GET /keys/{name}/{version}", kv.getKey)
+	mux.HandleFunc("POST /keys/{name}/{version}/sign", kv.sign)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testToken {
+			writeError(w, http.StatusUnauthorized, "Unauthorized", "invalid token")
+			return
+		}
+		if r.URL.Query().Get("api-version") != azurekv.DefaultAPIVersion {
+			writeError(w, http.StatusBadRequest, "BadParameter", "invalid api-version")
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func (kv *testKeyVault) key(r *http.Request) (*ecdsa.PrivateKey, string) {
+	switch r.PathValue("name") {
+	case "p256":
+		return kv.p256, "P-256"
+	case "secp256k1":
+		return kv.secp256k1, "P-256K"
+	default:
+		return nil, ""
+	}
+}
+
+func (kv *testKeyVault) getKey(w http.ResponseWriter, r *http.Request) {
+	key, crv := kv.key(r)
+	if key == nil {
+		writeError(w, http.StatusNotFound, "KeyNotFound", "key not found")
+		return
+	}
+
+	writeJSON(w, map[string]any{
+		"key": map[string]string{
+			"kid": "http://" + r.Host + "/keys/" + r.PathValue("name") + "/" + testVersion,
+			"kty": "EC-HSM",
+			"crv": crv,
+			"x":   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+			"y":   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+		},
+	})
+}
+
+func (kv *testKeyVault) sign(w http.ResponseWriter, r *http.Request) {
+	key, crv := kv.key(r)
+	if key == nil || r.PathValue("version") != testVersion {
+		writeError(w, http.StatusNotFound, "KeyNotFound", "key not found")
+		return
+	}
+
+	var request struct {
+		Alg   string `json:"alg"`
+		Value string `json:"value"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "BadParameter", err.Error())
+		return
+	}
+	if (crv == "P-256" && request.Alg != "ES256") || (crv == "P-256K" && request.Alg != "ES256K") {
+		writeError(w, http.StatusBadRequest, "BadParameter", "invalid algorithm")
+		return
+	}
+
+	digest, err := base64.RawURLEncoding.DecodeString(request.Value)
+	if err != nil || len(digest) != 32 {
+		writeError(w, http.StatusBadRequest, "BadParameter", "invalid digest")
+		return
+	}
+
+	signature, err := signDigest(key, crv, digest)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "InternalError", err.Error())
+		return
+	}
+
+	writeJSON(w, map[string]string{
+		"kid":   "http://" + r.Host + "/keys/" + r.PathValue("name") + "/" + testVersion,
+		"value": base64.RawURLEncoding.EncodeToString(signature),
+	})
+}
+
+// signDigest returns the signature R || S of the digest.
+func signDigest(key *ecdsa.PrivateKey, crv string, digest []byte) ([]byte, error) {
+	if crv == "P-256K" {
+		signature, err := ethcrypto.Sign(digest, key)
+		if err != nil {
+			return nil, err
+		}
+		// remove the recovery ID
+		return signature[:64], nil
+	}
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest)
+	if err != nil {
+		return nil, err
+	}
+	return append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...), nil
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code string, message string) {
+	w.WriteHeader(status)
+	writeJSON(w, map[string]any{"error": map[string]string{"code": code, "message": message}})
+}
+
+func TestSigning(t *testing.T) {
+	server := newTestKeyVault(t)
+	ctx := context.Background()
+
+	client := azurekv.NewClient(
+		azurekv.TokenCredentialFunc(func(context.Context) (string, error) {
+			return testToken, nil
+		}),
+		azurekv.WithHTTPClient(server.Client()),
+	)
+
+	for name, sigAlgo := range map[string]crypto.SignatureAlgorithm{
+		"p256":      crypto.ECDSA_P256,
+		"secp256k1": crypto.ECDSA_secp256k1,
+	} {
+		t.Run(name, func(t *testing.T) {
+			key := azurekv.Key{VaultURL: server.URL, Name: name}
+
+			pk, hashAlgo, err := client.GetPublicKey(ctx, key)
+			require.NoError(t, err)
+			assert.Equal(t, sigAlgo, pk.Algorithm())
+			assert.Equal(t, crypto.SHA2_256, hashAlgo)
+
+			signer, err := client.SignerForKey(ctx, key)
+			require.NoError(t, err)
+			assert.True(t, pk.Equals(signer.PublicKey()))
+
+			for _, length := range []int{0, 32, 5000} {
+				message := make([]byte, length)
+				signature, err := signer.Sign(message)
+				require.NoError(t, err)
+				require.Len(t, signature, 64)
+
+				valid, err := pk.Verify(signature, message, crypto.NewSHA2_256())
+				require.NoError(t, err)
+				assert.True(t, valid)
+			}
+		})
+	}
+
+	t.Run("Unknown key", func(t *testing.T) {
+		_, err := client.SignerForKey(ctx, azurekv.Key{VaultURL: server.URL, Name: "unknown"})
+		require.ErrorContains(t, err, "KeyNotFound")
+	})
+
+	t.Run("Credential error", func(t *testing.T) {
+		client := azurekv.NewClient(
+			azurekv.TokenCredentialFunc(func(context.Context) (string, error) {
+				return "", errors.New("no credential")
+			}),
+			azurekv.WithHTTPClient(server.Client()),
+		)
+		_, _, err := client.GetPublicKey(ctx, azurekv.Key{VaultURL: server.URL, Name: "p256"})
+		require.ErrorContains(t, err, "no credential")
+	})
+}
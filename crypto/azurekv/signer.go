@@ -0,0 +1,97 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package azurekv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/internal"
+)
+
+var _ crypto.Signer = (*Signer)(nil)
+
+// Signer is an Azure Key Vault implementation of crypto.Signer.
+type Signer struct {
+	ctx    context.Context
+	client *Client
+	key    Key
+	// ECDSA is the only algorithm supported by this package. The signature algorithm
+	// therefore represents the elliptic curve used. The curve is needed to parse the signature.
+	curve crypto.SignatureAlgorithm
+	// public key for easier access
+	publicKey crypto.PublicKey
+	// Hash algorithm associated to the Key Vault signing key
+	hashAlgo crypto.HashAlgorithm
+}
+
+// SignerForKey returns a new Azure Key Vault signer for an EC key.
+//
+// If the key has no version, the signer uses the version which is current when the signer is created.
+// Only ECDSA keys on P-256 and secp256k1 curves and SHA2-256 are supported.
+func (c *Client) SignerForKey(
+	ctx context.Context,
+	key Key,
+) (*Signer, error) {
+	key, pk, hashAlgo, err := c.getKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Signer{
+		ctx:       ctx,
+		client:    c,
+		key:       key,
+		curve:     pk.Algorithm(),
+		publicKey: pk,
+		hashAlgo:  hashAlgo,
+	}, nil
+}
+
+// Sign signs the given message using the Key Vault signing key for this signer.
+//
+// Key Vault signs digests, the message is hashed outside Key Vault.
+func (s *Signer) Sign(message []byte) ([]byte, error) {
+	hasher, err := crypto.NewHasher(s.hashAlgo)
+	if err != nil {
+		return nil, fmt.Errorf("azurekv: failed to sign: %w", err)
+	}
+	digest := hasher.ComputeHash(message)
+
+	signature, err := s.client.sign(s.ctx, s.key, signingAlgorithm(s.curve), digest)
+	if err != nil {
+		return nil, fmt.Errorf("azurekv: failed to sign: %w", err)
+	}
+
+	// Key Vault returns signatures as R || S, like Flow
+	if len(signature) == 64 {
+		return signature, nil
+	}
+
+	sig, err := internal.ParseECDSASignature(signature, s.curve)
+	if err != nil {
+		return nil, fmt.Errorf("azurekv: failed to parse signature: %w", err)
+	}
+	return sig, nil
+}
+
+func (s *Signer) PublicKey() crypto.PublicKey {
+	return s.publicKey
+}
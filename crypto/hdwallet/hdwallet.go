@@ -0,0 +1,178 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package hdwallet derives Flow account keys from BIP-39 mnemonics.
+//
+// Keys are derived with BIP-32 for ECDSA_secp256k1 and SLIP-10 for ECDSA_P256, along the path
+// m/44'/539'/account'/0/index, where 539 is the coin type registered for Flow in SLIP-44.
+// This is the derivation used by Flow wallets, so keys of wallet accounts can be recovered from
+// their recovery phrase:
+//
+//	privateKey, err := hdwallet.DerivePrivateKey(mnemonic, "", crypto.ECDSA_P256, hdwallet.DefaultPath)
+package hdwallet
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// CoinType is the SLIP-44 coin type of Flow.
+const CoinType = 539
+
+// HardenedOffset is added to the index of hardened children.
+const HardenedOffset uint32 = 0x80000000
+
+// DefaultPath is the derivation path of the first key of the first account, used by Flow wallets.
+const DefaultPath = "m/44'/539'/0'/0/0"
+
+// ErrInvalidMnemonic is returned for mnemonics with unknown words or an invalid checksum.
+var ErrInvalidMnemonic = errors.New("invalid mnemonic")
+
+// NewMnemonic generates a random mnemonic of the given number of words.
+//
+// The number of words must be 12, 15, 18, 21 or 24.
+func NewMnemonic(words int) (string, error) {
+	if words < 12 || words > 24 || words%3 != 0 {
+		return "", fmt.Errorf("invalid number of mnemonic words %d: must be 12, 15, 18, 21 or 24", words)
+	}
+
+	// each word encodes 11 bits, of which one bit per three words is checksum
+	entropy, err := bip39.NewEntropy(words / 3 * 32)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate entropy: %w", err)
+	}
+
+	return bip39.NewMnemonic(entropy)
+}
+
+// ValidateMnemonic returns ErrInvalidMnemonic if the mnemonic has unknown words, an invalid number of words,
+// or an invalid checksum.
+func ValidateMnemonic(mnemonic string) error {
+	if !bip39.IsMnemonicValid(normalizeMnemonic(mnemonic)) {
+		return ErrInvalidMnemonic
+	}
+	return nil
+}
+
+// Seed validates the mnemonic and returns the BIP-39 seed of the mnemonic and the optional passphrase.
+func Seed(mnemonic string, passphrase string) ([]byte, error) {
+	mnemonic = normalizeMnemonic(mnemonic)
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	return bip39.NewSeed(mnemonic, passphrase), nil
+}
+
+// DerivePrivateKey derives the private key at the path from the mnemonic and the optional passphrase.
+//
+// The signature algorithm must be ECDSA_P256 or ECDSA_secp256k1.
+func DerivePrivateKey(
+	mnemonic string,
+	passphrase string,
+	sigAlgo crypto.SignatureAlgorithm,
+	path string,
+) (crypto.PrivateKey, error) {
+	seed, err := Seed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	master, err := NewMasterKey(sigAlgo, seed)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := master.DerivePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return key.PrivateKey()
+}
+
+// Path is a BIP-32 derivation path. Hardened indexes include HardenedOffset.
+type Path []uint32
+
+// FlowPath returns the path m/44'/539'/account'/0/index of a key of an account.
+func FlowPath(account uint32, index uint32) Path {
+	return Path{
+		44 + HardenedOffset,
+		CoinType + HardenedOffset,
+		account + HardenedOffset,
+		0,
+		index,
+	}
+}
+
+// ParsePath parses a derivation path such as m/44'/539'/0'/0/0.
+//
+// Hardened indexes are marked with ', h or H.
+func ParsePath(s string) (Path, error) {
+	segments := strings.Split(strings.TrimSpace(s), "/")
+	if segments[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path %q: must start with m", s)
+	}
+
+	path := make(Path, 0, len(segments)-1)
+	for _, segment := range segments[1:] {
+		hardened := false
+		if trimmed := strings.TrimRight(segment, "'hH"); len(trimmed) == len(segment)-1 {
+			hardened = true
+			segment = trimmed
+		}
+
+		index, err := strconv.ParseUint(segment, 10, 32)
+		if err != nil || index >= uint64(HardenedOffset) {
+			return nil, fmt.Errorf("invalid derivation path %q: invalid index %q", s, segment)
+		}
+
+		if hardened {
+			index += uint64(HardenedOffset)
+		}
+		path = append(path, uint32(index))
+	}
+
+	return path, nil
+}
+
+// String returns the path in the form m/44'/539'/0'/0/0.
+func (p Path) String() string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, index := range p {
+		b.WriteString("/")
+		if index >= HardenedOffset {
+			b.WriteString(strconv.FormatUint(uint64(index-HardenedOffset), 10))
+			b.WriteString("'")
+		} else {
+			b.WriteString(strconv.FormatUint(uint64(index), 10))
+		}
+	}
+	return b.String()
+}
+
+// normalizeMnemonic removes redundant whitespace between the words of the mnemonic.
+func normalizeMnemonic(mnemonic string) string {
+	return strings.Join(strings.Fields(mnemonic), " ")
+}
@@ -0,0 +1,232 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hdwallet_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/hdwallet"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestMnemonic(t *testing.T) {
+	t.Run("Generate", func(t *testing.T) {
+		for _, words := range []int{12, 15, 18, 21, 24} {
+			mnemonic, err := hdwallet.NewMnemonic(words)
+			require.NoError(t, err)
+			assert.Len(t, strings.Fields(mnemonic), words)
+			assert.NoError(t, hdwallet.ValidateMnemonic(mnemonic))
+		}
+
+		_, err := hdwallet.NewMnemonic(13)
+		assert.Error(t, err)
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		assert.NoError(t, hdwallet.ValidateMnemonic("  abandon abandon abandon abandon abandon abandon\nabandon abandon abandon abandon abandon about "))
+		assert.ErrorIs(t, hdwallet.ValidateMnemonic(strings.Replace(testMnemonic, "about", "abandon", 1)), hdwallet.ErrInvalidMnemonic)
+		assert.ErrorIs(t, hdwallet.ValidateMnemonic(strings.Replace(testMnemonic, "about", "flow", 1)+"x"), hdwallet.ErrInvalidMnemonic)
+		assert.ErrorIs(t, hdwallet.ValidateMnemonic("abandon about"), hdwallet.ErrInvalidMnemonic)
+	})
+
+	t.Run("Seed", func(t *testing.T) {
+		// BIP-39 test vector
+		seed, err := hdwallet.Seed(testMnemonic, "TREZOR")
+		require.NoError(t, err)
+		assert.Equal(t,
+			"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+			hex.EncodeToString(seed),
+		)
+
+		_, err = hdwallet.Seed("abandon about", "")
+		assert.ErrorIs(t, err, hdwallet.ErrInvalidMnemonic)
+	})
+}
+
+func TestPath(t *testing.T) {
+	path, err := hdwallet.ParsePath(hdwallet.DefaultPath)
+	require.NoError(t, err)
+	assert.Equal(t, hdwallet.FlowPath(0, 0), path)
+	assert.Equal(t, hdwallet.DefaultPath, path.String())
+
+	path, err = hdwallet.ParsePath("m/44h/539H/1'/0/7")
+	require.NoError(t, err)
+	assert.Equal(t, hdwallet.FlowPath(1, 7), path)
+
+	path, err = hdwallet.ParsePath("m")
+	require.NoError(t, err)
+	assert.Empty(t, path)
+
+	for _, invalid := range []string{"", "44'/539'", "m/", "m/x", "m/1''", "m/-1", "m/2147483648"} {
+		_, err := hdwallet.ParsePath(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestDerivation(t *testing.T) {
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+
+	type vector struct {
+		path       string
+		chainCode  string
+		privateKey string
+		publicKey  string
+	}
+
+	tests := map[crypto.SignatureAlgorithm][]vector{
+		// BIP-32 test vector 1
+		crypto.ECDSA_secp256k1: {
+			{
+				path:       "m",
+				chainCode:  "873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508",
+				privateKey: "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35",
+				publicKey:  "0339a36013301597daef41fbe593a02cc513d0b55527ec2df1050e2e8ff49c85c2",
+			},
+			{
+				path:       "m/0'/1",
+				chainCode:  "2a7857631386ba23dacac34180dd1983734e444fdbf774041578e9b6adb37c19",
+				privateKey: "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368",
+				publicKey:  "03501e454bf00751f24b1b489aa925215d66af2234e3891c3b21a52bedb3cd711c",
+			},
+			{
+				path:       "m/0'/1/2'/2/1000000000",
+				chainCode:  "c783e67b921d2beb8f6b389cc646d7263b4145701dadd2161548a8b078e65e9e",
+				privateKey: "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8",
+				publicKey:  "022a471424da5e657499d1ff51cb43c47481a03b1e77f951fe64cec9f5a48f7011",
+			},
+		},
+		// SLIP-10 test vector 1 for nist256p1
+		crypto.ECDSA_P256: {
+			{
+				path:       "m",
+				chainCode:  "beeb672fe4621673f722f38529c07392fecaa61015c80c34f29ce8b41b3cb6ea",
+				privateKey: "612091aaa12e22dd2abef664f8a01a82cae99ad7441b7ef8110424915c268bc2",
+				publicKey:  "0266874dc6ade47b3ecd096745ca09bcd29638dd52c2c12117b11ed3e458cfa9e8",
+			},
+			{
+				path:       "m/0'/1",
+				chainCode:  "4187afff1aafa8445010097fb99d23aee9f599450c7bd140b6826ac22ba21d0c",
+				privateKey: "284e9d38d07d21e4e281b645089a94f4cf5a5a81369acf151a1c3a57f18b2129",
+				publicKey:  "03526c63f8d0b4bbbf9c80df553fe66742df4676b241dabefdef67733e070f6844",
+			},
+			{
+				path:       "m/0'/1/2'/2/1000000000",
+				chainCode:  "b9b7b82d326bb9cb5b5b121066feea4eb93d5241103c9e7a18aad40f1dde8059",
+				privateKey: "21c4f269ef0a5fd1badf47eeacebeeaa3de22eb8e5b0adcd0f27dd99d34d0119",
+				publicKey:  "02216cd26d31147f72427a453c443ed2cde8a1e53c9cc44e5ddf739725413fe3f4",
+			},
+		},
+	}
+
+	for sigAlgo, vectors := range tests {
+		master, err := hdwallet.NewMasterKey(sigAlgo, seed)
+		require.NoError(t, err)
+		assert.Equal(t, sigAlgo, master.SignatureAlgorithm())
+
+		for _, v := range vectors {
+			t.Run(sigAlgo.String()+" "+v.path, func(t *testing.T) {
+				key, err := master.DerivePath(v.path)
+				require.NoError(t, err)
+
+				privateKey, err := key.PrivateKey()
+				require.NoError(t, err)
+
+				assert.Equal(t, v.chainCode, hex.EncodeToString(key.ChainCode()))
+				assert.Equal(t, v.privateKey, hex.EncodeToString(privateKey.Encode()))
+				assert.Equal(t, v.publicKey, hex.EncodeToString(privateKey.PublicKey().EncodeCompressed()))
+			})
+		}
+	}
+
+	// keys of Flow wallets, as derived by the Flow CLI and the Flow Ledger app
+	t.Run("Flow wallets", func(t *testing.T) {
+		wallets := []struct {
+			mnemonic  string
+			sigAlgo   crypto.SignatureAlgorithm
+			path      string
+			publicKey string
+		}{
+			{
+				mnemonic:  "version field tornado move level pretty inject stereo ten catalog salon swallow",
+				sigAlgo:   crypto.ECDSA_P256,
+				path:      hdwallet.DefaultPath,
+				publicKey: "2d6daea8b0ba5b1d5935f7846ccdd7e6f9f981e34d3c0a02a927cc79c837eba56c0f9a979195e41143495b72314ffcab60da6b7031060c80dc12f01f7f2096be",
+			},
+			{
+				mnemonic:  "equip will roof matter pink blind book anxiety banner elbow sun young",
+				sigAlgo:   crypto.ECDSA_secp256k1,
+				path:      "m/44'/539'/513'/0/0",
+				publicKey: "d7482bbaff7827035d5b238df318b10604673dc613808723efbd23fbc4b9fad34a415828d924ec7b83ac0eddf22ef115b7c203ee39fb080572d7e51775ee54be",
+			},
+		}
+
+		for _, wallet := range wallets {
+			privateKey, err := hdwallet.DerivePrivateKey(wallet.mnemonic, "", wallet.sigAlgo, wallet.path)
+			require.NoError(t, err)
+			assert.Equal(t, wallet.publicKey, hex.EncodeToString(privateKey.PublicKey().Encode()), wallet.path)
+		}
+	})
+
+	_, err = hdwallet.NewMasterKey(crypto.BLS_BLS12_381, seed)
+	assert.Error(t, err)
+
+	_, err = hdwallet.NewMasterKey(crypto.ECDSA_P256, seed[:8])
+	assert.Error(t, err)
+}
+
+func TestDerivePrivateKey(t *testing.T) {
+	for _, sigAlgo := range []crypto.SignatureAlgorithm{crypto.ECDSA_P256, crypto.ECDSA_secp256k1} {
+		t.Run(sigAlgo.String(), func(t *testing.T) {
+			privateKey, err := hdwallet.DerivePrivateKey(testMnemonic, "", sigAlgo, hdwallet.DefaultPath)
+			require.NoError(t, err)
+			assert.Equal(t, sigAlgo, privateKey.Algorithm())
+
+			seed, err := hdwallet.Seed(testMnemonic, "")
+			require.NoError(t, err)
+			master, err := hdwallet.NewMasterKey(sigAlgo, seed)
+			require.NoError(t, err)
+			key, err := master.Derive(hdwallet.FlowPath(0, 0))
+			require.NoError(t, err)
+			expected, err := key.PrivateKey()
+			require.NoError(t, err)
+			assert.True(t, expected.Equals(privateKey))
+
+			// every key index derives a different key
+			other, err := hdwallet.DerivePrivateKey(testMnemonic, "", sigAlgo, hdwallet.FlowPath(0, 1).String())
+			require.NoError(t, err)
+			assert.False(t, other.Equals(privateKey))
+
+			// the passphrase changes the seed
+			other, err = hdwallet.DerivePrivateKey(testMnemonic, "passphrase", sigAlgo, hdwallet.DefaultPath)
+			require.NoError(t, err)
+			assert.False(t, other.Equals(privateKey))
+		})
+	}
+
+	_, err := hdwallet.DerivePrivateKey(testMnemonic, "", crypto.ECDSA_P256, "m/44'/x")
+	assert.Error(t, err)
+}
@@ -0,0 +1,188 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hdwallet
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+const keyLen = 32
+
+// curve holds the parameters of the derivation of keys on an elliptic curve.
+type curve struct {
+	// seedKey is the HMAC key of the master key derivation.
+	seedKey []byte
+	// n is the order of the curve.
+	n *big.Int
+}
+
+var secp256k1N, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 16)
+
+var curves = map[crypto.SignatureAlgorithm]curve{
+	// BIP-32
+	crypto.ECDSA_secp256k1: {
+		seedKey: []byte("Bitcoin seed"),
+		n:       secp256k1N,
+	},
+	// SLIP-10
+	crypto.ECDSA_P256: {
+		seedKey: []byte("Nist256p1 seed"),
+		n:       elliptic.P256().Params().N,
+	},
+}
+
+// Key is an extended private key, which derives child keys.
+type Key struct {
+	sigAlgo   crypto.SignatureAlgorithm
+	curve     curve
+	key       []byte
+	chainCode []byte
+}
+
+// NewMasterKey returns the master key of the seed for the signature algorithm.
+//
+// The signature algorithm must be ECDSA_P256 or ECDSA_secp256k1.
+func NewMasterKey(sigAlgo crypto.SignatureAlgorithm, seed []byte) (*Key, error) {
+	c, ok := curves[sigAlgo]
+	if !ok {
+		return nil, fmt.Errorf("unsupported signature algorithm %s: must be ECDSA_P256 or ECDSA_secp256k1", sigAlgo)
+	}
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("invalid seed length %d: must be between 16 and 64 bytes", len(seed))
+	}
+
+	data := seed
+	for {
+		i := hmacSHA512(c.seedKey, data)
+		key, chainCode := i[:keyLen], i[keyLen:]
+
+		// SLIP-10 retries with the output as input if the key is invalid,
+		// for BIP-32 this happens with negligible probability
+		if isValidKey(c, key) {
+			return &Key{
+				sigAlgo:   sigAlgo,
+				curve:     c,
+				key:       key,
+				chainCode: chainCode,
+			}, nil
+		}
+		data = i
+	}
+}
+
+// SignatureAlgorithm returns the signature algorithm of the key.
+func (k *Key) SignatureAlgorithm() crypto.SignatureAlgorithm {
+	return k.sigAlgo
+}
+
+// ChainCode returns the chain code of the key.
+func (k *Key) ChainCode() []byte {
+	return append([]byte(nil), k.chainCode...)
+}
+
+// PrivateKey returns the private key.
+func (k *Key) PrivateKey() (crypto.PrivateKey, error) {
+	return crypto.DecodePrivateKey(k.sigAlgo, k.key)
+}
+
+// Child derives the child key with the index. Indexes of hardened children include HardenedOffset.
+func (k *Key) Child(index uint32) (*Key, error) {
+	data := make([]byte, 0, 1+keyLen+4)
+	if index >= HardenedOffset {
+		data = append(data, 0)
+		data = append(data, k.key...)
+	} else {
+		privateKey, err := k.PrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, privateKey.PublicKey().EncodeCompressed()...)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	for {
+		i := hmacSHA512(k.chainCode, data)
+		il, chainCode := new(big.Int).SetBytes(i[:keyLen]), i[keyLen:]
+
+		if il.Cmp(k.curve.n) < 0 {
+			child := il.Add(il, new(big.Int).SetBytes(k.key))
+			child.Mod(child, k.curve.n)
+
+			if child.Sign() != 0 {
+				return &Key{
+					sigAlgo:   k.sigAlgo,
+					curve:     k.curve,
+					key:       child.FillBytes(make([]byte, keyLen)),
+					chainCode: chainCode,
+				}, nil
+			}
+		}
+
+		// the derived key is invalid: BIP-32 proceeds with the next index,
+		// SLIP-10 retries with 0x01 || IR || index
+		if k.sigAlgo == crypto.ECDSA_secp256k1 {
+			if index+1 == HardenedOffset || index == ^uint32(0) {
+				return nil, fmt.Errorf("cannot derive child key %d", index)
+			}
+			return k.Child(index + 1)
+		}
+		data = append([]byte{1}, chainCode...)
+		data = binary.BigEndian.AppendUint32(data, index)
+	}
+}
+
+// Derive derives the key at the path, relative to this key.
+func (k *Key) Derive(path Path) (*Key, error) {
+	key := k
+	for _, index := range path {
+		var err error
+		key, err = key.Child(index)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// DerivePath parses the derivation path and derives the key at the path, relative to this key.
+func (k *Key) DerivePath(path string) (*Key, error) {
+	p, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return k.Derive(p)
+}
+
+func isValidKey(c curve, key []byte) bool {
+	k := new(big.Int).SetBytes(key)
+	return k.Sign() != 0 && k.Cmp(c.n) < 0
+}
+
+func hmacSHA512(key []byte, data []byte) []byte {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
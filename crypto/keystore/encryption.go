@@ -0,0 +1,432 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// Version is the version of the encrypted key format.
+const Version = 1
+
+// List of supported key derivation functions.
+const (
+	KDFScrypt   = "scrypt"
+	KDFArgon2id = "argon2id"
+)
+
+const (
+	cipherAES256GCM = "aes-256-gcm"
+	derivedKeyLen   = 32
+	saltLen         = 32
+)
+
+// Default key derivation parameters.
+//
+// The scrypt parameters match the "standard" parameters of the Ethereum keystore,
+// the Argon2id parameters follow the recommendations of RFC 9106.
+const (
+	DefaultScryptN       = 1 << 18
+	DefaultScryptR       = 8
+	DefaultScryptP       = 1
+	DefaultArgon2Time    = 3
+	DefaultArgon2Memory  = 64 * 1024
+	DefaultArgon2Threads = 4
+)
+
+// Maximum key derivation parameters, which bound the memory and time needed to decrypt a key file
+// from an untrusted source.
+const (
+	maxKDFMemory    = 1 << 30 // bytes
+	maxScryptN      = 1 << 20
+	maxScryptR      = 32
+	maxScryptP      = 16
+	maxArgon2Time   = 16
+	maxArgon2Memory = maxKDFMemory / 1024 // KiB
+)
+
+// ErrDecryption is returned when a key cannot be decrypted, usually because the password is wrong.
+var ErrDecryption = errors.New("could not decrypt key: wrong password or corrupted key file")
+
+// Key is a decrypted signing key of a Flow account.
+type Key struct {
+	// Address is the address of the account the key belongs to. It may be empty
+	// for keys which are not yet added to an account.
+	Address    flow.Address
+	KeyIndex   uint32
+	PrivateKey crypto.PrivateKey
+	HashAlgo   crypto.HashAlgorithm
+}
+
+// Signer returns an in-memory signer for the key.
+func (k *Key) Signer() (crypto.Signer, error) {
+	return crypto.NewInMemorySigner(k.PrivateKey, k.HashAlgo)
+}
+
+// AccountKey returns the account key of the key, with full weight.
+func (k *Key) AccountKey() *flow.AccountKey {
+	return flow.NewAccountKey().
+		FromPrivateKey(k.PrivateKey).
+		SetHashAlgo(k.HashAlgo).
+		SetWeight(flow.AccountKeyWeightThreshold)
+}
+
+// EncryptedKey is the encrypted representation of a key, as stored in key files.
+//
+// The account metadata and the public key are stored in plain text, so keys can be listed without a password.
+// They are authenticated as additional data of the encryption, so they cannot be modified without
+// failing decryption.
+type EncryptedKey struct {
+	Version            int          `json:"version"`
+	ID                 string       `json:"id"`
+	Address            flow.Address `json:"address"`
+	KeyIndex           uint32       `json:"keyIndex"`
+	SignatureAlgorithm string       `json:"signatureAlgorithm"`
+	HashAlgorithm      string       `json:"hashAlgorithm"`
+	PublicKey          string       `json:"publicKey"`
+	Crypto             CryptoJSON   `json:"crypto"`
+}
+
+// CryptoJSON are the encryption parameters and the ciphertext of an encrypted key.
+type CryptoJSON struct {
+	Cipher     string          `json:"cipher"`
+	CipherText string          `json:"ciphertext"`
+	Nonce      string          `json:"nonce"`
+	KDF        string          `json:"kdf"`
+	KDFParams  json.RawMessage `json:"kdfparams"`
+}
+
+type scryptParams struct {
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+	Salt string `json:"salt"`
+}
+
+type argon2Params struct {
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+	Salt    string `json:"salt"`
+}
+
+// An Option configures the encryption of keys.
+type Option func(*config)
+
+type config struct {
+	kdf    string
+	scrypt scryptParams
+	argon2 argon2Params
+}
+
+func newConfig(opts []Option) config {
+	cfg := config{
+		kdf: KDFScrypt,
+		scrypt: scryptParams{
+			N: DefaultScryptN,
+			R: DefaultScryptR,
+			P: DefaultScryptP,
+		},
+		argon2: argon2Params{
+			Time:    DefaultArgon2Time,
+			Memory:  DefaultArgon2Memory,
+			Threads: DefaultArgon2Threads,
+		},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithScrypt configures scrypt with the given parameters as key derivation function. This is the default.
+func WithScrypt(n, r, p int) Option {
+	return func(cfg *config) {
+		cfg.kdf = KDFScrypt
+		cfg.scrypt.N = n
+		cfg.scrypt.R = r
+		cfg.scrypt.P = p
+	}
+}
+
+// WithArgon2id configures Argon2id with the given parameters as key derivation function.
+//
+// The memory is given in KiB.
+func WithArgon2id(time, memory uint32, threads uint8) Option {
+	return func(cfg *config) {
+		cfg.kdf = KDFArgon2id
+		cfg.argon2.Time = time
+		cfg.argon2.Memory = memory
+		cfg.argon2.Threads = threads
+	}
+}
+
+// Encrypt encrypts the key with the password.
+func Encrypt(key *Key, password []byte, opts ...Option) (*EncryptedKey, error) {
+	if key.PrivateKey == nil {
+		return nil, fmt.Errorf("missing private key")
+	}
+	sigAlgo := key.PrivateKey.Algorithm()
+	if !crypto.CompatibleAlgorithms(sigAlgo, key.HashAlgo) {
+		return nil, fmt.Errorf("signature algorithm %s and hash algorithm %s are not compatible", sigAlgo, key.HashAlgo)
+	}
+
+	id, err := randomBytes(16)
+	if err != nil {
+		return nil, err
+	}
+	salt, err := randomBytes(saltLen)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := newConfig(opts)
+
+	encrypted := &EncryptedKey{
+		Version:            Version,
+		ID:                 hex.EncodeToString(id),
+		Address:            key.Address,
+		KeyIndex:           key.KeyIndex,
+		SignatureAlgorithm: sigAlgo.String(),
+		HashAlgorithm:      key.HashAlgo.String(),
+		PublicKey:          hex.EncodeToString(key.PrivateKey.PublicKey().Encode()),
+		Crypto: CryptoJSON{
+			Cipher: cipherAES256GCM,
+			KDF:    cfg.kdf,
+		},
+	}
+
+	switch cfg.kdf {
+	case KDFScrypt:
+		params := cfg.scrypt
+		params.Salt = hex.EncodeToString(salt)
+		encrypted.Crypto.KDFParams, err = json.Marshal(params)
+	case KDFArgon2id:
+		params := cfg.argon2
+		params.Salt = hex.EncodeToString(salt)
+		encrypted.Crypto.KDFParams, err = json.Marshal(params)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	derivedKey, err := deriveKey(encrypted.Crypto, password)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(derivedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := randomBytes(gcm.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+
+	cipherText := gcm.Seal(nil, nonce, key.PrivateKey.Encode(), encrypted.additionalData())
+
+	encrypted.Crypto.Nonce = hex.EncodeToString(nonce)
+	encrypted.Crypto.CipherText = hex.EncodeToString(cipherText)
+
+	return encrypted, nil
+}
+
+// Decrypt decrypts the key with the password.
+//
+// It returns ErrDecryption if the password is wrong, or the key was modified.
+func Decrypt(encrypted *EncryptedKey, password []byte) (*Key, error) {
+	if encrypted.Version != Version {
+		return nil, fmt.Errorf("unsupported key version %d", encrypted.Version)
+	}
+	if encrypted.Crypto.Cipher != cipherAES256GCM {
+		return nil, fmt.Errorf("unsupported cipher %s", encrypted.Crypto.Cipher)
+	}
+
+	sigAlgo := crypto.StringToSignatureAlgorithm(encrypted.SignatureAlgorithm)
+	hashAlgo := crypto.StringToHashAlgorithm(encrypted.HashAlgorithm)
+	if !crypto.CompatibleAlgorithms(sigAlgo, hashAlgo) {
+		return nil, fmt.Errorf(
+			"invalid algorithms %s and %s",
+			encrypted.SignatureAlgorithm,
+			encrypted.HashAlgorithm,
+		)
+	}
+
+	nonce, err := hex.DecodeString(encrypted.Crypto.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nonce: %w", err)
+	}
+	cipherText, err := hex.DecodeString(encrypted.Crypto.CipherText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ciphertext: %w", err)
+	}
+
+	derivedKey, err := deriveKey(encrypted.Crypto, password)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(derivedKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce length %d", len(nonce))
+	}
+
+	encodedPrivateKey, err := gcm.Open(nil, nonce, cipherText, encrypted.additionalData())
+	if err != nil {
+		return nil, ErrDecryption
+	}
+
+	privateKey, err := crypto.DecodePrivateKey(sigAlgo, encodedPrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	return &Key{
+		Address:    encrypted.Address,
+		KeyIndex:   encrypted.KeyIndex,
+		PrivateKey: privateKey,
+		HashAlgo:   hashAlgo,
+	}, nil
+}
+
+// additionalData returns the metadata authenticated by the encryption.
+func (e *EncryptedKey) additionalData() []byte {
+	data := make([]byte, 0, 128)
+	data = binary.BigEndian.AppendUint32(data, uint32(e.Version))
+	data = append(data, e.ID...)
+	data = append(data, e.Address.Bytes()...)
+	data = binary.BigEndian.AppendUint32(data, e.KeyIndex)
+	data = append(data, e.SignatureAlgorithm...)
+	data = append(data, 0)
+	data = append(data, e.HashAlgorithm...)
+	data = append(data, 0)
+	data = append(data, e.PublicKey...)
+	return data
+}
+
+func deriveKey(params CryptoJSON, password []byte) ([]byte, error) {
+	switch params.KDF {
+	case KDFScrypt:
+		p, salt, err := parseScryptParams(params.KDFParams)
+		if err != nil {
+			return nil, err
+		}
+		return scrypt.Key(password, salt, p.N, p.R, p.P, derivedKeyLen)
+
+	case KDFArgon2id:
+		p, salt, err := parseArgon2Params(params.KDFParams)
+		if err != nil {
+			return nil, err
+		}
+		return argon2.IDKey(password, salt, p.Time, p.Memory, p.Threads, derivedKeyLen), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key derivation function %s", params.KDF)
+	}
+}
+
+// checkKDFParams checks that the key derivation parameters are valid and within the maximums.
+func checkKDFParams(params CryptoJSON) error {
+	var err error
+	switch params.KDF {
+	case KDFScrypt:
+		_, _, err = parseScryptParams(params.KDFParams)
+	case KDFArgon2id:
+		_, _, err = parseArgon2Params(params.KDFParams)
+	default:
+		err = fmt.Errorf("unsupported key derivation function %s", params.KDF)
+	}
+	return err
+}
+
+func parseScryptParams(data json.RawMessage) (scryptParams, []byte, error) {
+	var p scryptParams
+	if err := json.Unmarshal(data, &p); err != nil {
+		return p, nil, fmt.Errorf("invalid scrypt parameters: %w", err)
+	}
+	salt, err := hex.DecodeString(p.Salt)
+	if err != nil {
+		return p, nil, fmt.Errorf("invalid salt: %w", err)
+	}
+	if p.N <= 1 || p.R <= 0 || p.P <= 0 {
+		return p, nil, fmt.Errorf("invalid scrypt parameters")
+	}
+	if p.N > maxScryptN || p.R > maxScryptR || p.P > maxScryptP || 128*int64(p.N)*int64(p.R) > maxKDFMemory {
+		return p, nil, fmt.Errorf(
+			"scrypt parameters n=%d, r=%d, p=%d exceed the maximum n=%d, r=%d, p=%d or %d bytes of memory",
+			p.N, p.R, p.P, maxScryptN, maxScryptR, maxScryptP, maxKDFMemory,
+		)
+	}
+	return p, salt, nil
+}
+
+func parseArgon2Params(data json.RawMessage) (argon2Params, []byte, error) {
+	var p argon2Params
+	if err := json.Unmarshal(data, &p); err != nil {
+		return p, nil, fmt.Errorf("invalid argon2id parameters: %w", err)
+	}
+	salt, err := hex.DecodeString(p.Salt)
+	if err != nil {
+		return p, nil, fmt.Errorf("invalid salt: %w", err)
+	}
+	if p.Time == 0 || p.Memory == 0 || p.Threads == 0 {
+		return p, nil, fmt.Errorf("invalid argon2id parameters")
+	}
+	if p.Time > maxArgon2Time || p.Memory > maxArgon2Memory {
+		return p, nil, fmt.Errorf(
+			"argon2id parameters time=%d, memory=%d exceed the maximum time=%d, memory=%d",
+			p.Time, p.Memory, maxArgon2Time, maxArgon2Memory,
+		)
+	}
+	return p, salt, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return b, nil
+}
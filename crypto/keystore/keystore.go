@@ -0,0 +1,306 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package keystore stores Flow account signing keys in password-encrypted files.
+//
+// Each key is stored in its own JSON file, similar to the Ethereum keystore. The private key is encrypted
+// with AES-256-GCM, using a key derived from the password with scrypt or Argon2id. The account address,
+// key index, algorithms and public key are stored in plain text, but authenticated by the encryption.
+//
+//	ks, err := keystore.New("/path/to/keys")
+//	entry, err := ks.Import(&keystore.Key{Address: address, KeyIndex: 0, PrivateKey: privateKey, HashAlgo: crypto.SHA3_256}, password)
+//	signer, err := ks.Unlock(entry.ID, password)
+package keystore
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+const keyFileExtension = ".json"
+
+// ErrKeyNotFound is returned when the key store does not contain a key.
+var ErrKeyNotFound = errors.New("key not found")
+
+// Entry describes a key in the key store, without decrypting it.
+type Entry struct {
+	ID        string
+	Address   flow.Address
+	KeyIndex  uint32
+	SigAlgo   crypto.SignatureAlgorithm
+	HashAlgo  crypto.HashAlgorithm
+	PublicKey crypto.PublicKey
+	// Path is the path of the key file.
+	Path string
+}
+
+// A KeyStore stores encrypted keys in a directory.
+type KeyStore struct {
+	dir  string
+	opts []Option
+}
+
+// New returns a key store for the given directory, which is created if it does not exist.
+//
+// The options configure the encryption of new keys.
+func New(dir string, opts ...Option) (*KeyStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create key store directory: %w", err)
+	}
+	return &KeyStore{
+		dir:  dir,
+		opts: opts,
+	}, nil
+}
+
+// Create generates a new private key with a secure random seed, and stores it encrypted with the password.
+func (ks *KeyStore) Create(
+	address flow.Address,
+	keyIndex uint32,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+	password []byte,
+) (*Entry, error) {
+	seed, err := randomBytes(crypto.MinSeedLength)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := crypto.GeneratePrivateKey(sigAlgo, seed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate private key: %w", err)
+	}
+
+	return ks.Import(&Key{
+		Address:    address,
+		KeyIndex:   keyIndex,
+		PrivateKey: privateKey,
+		HashAlgo:   hashAlgo,
+	}, password)
+}
+
+// Import stores the key encrypted with the password.
+func (ks *KeyStore) Import(key *Key, password []byte) (*Entry, error) {
+	encrypted, err := Encrypt(key, password, ks.opts...)
+	if err != nil {
+		return nil, err
+	}
+	return ks.store(encrypted)
+}
+
+// ImportEncrypted stores a key exported from another key store, without decrypting it.
+func (ks *KeyStore) ImportEncrypted(data []byte) (*Entry, error) {
+	var encrypted EncryptedKey
+	if err := json.Unmarshal(data, &encrypted); err != nil {
+		return nil, fmt.Errorf("invalid key file: %w", err)
+	}
+	if encrypted.Version != Version {
+		return nil, fmt.Errorf("unsupported key version %d", encrypted.Version)
+	}
+	if _, err := hex.DecodeString(encrypted.ID); err != nil || encrypted.ID == "" {
+		return nil, fmt.Errorf("invalid key ID %q", encrypted.ID)
+	}
+	if err := checkKDFParams(encrypted.Crypto); err != nil {
+		return nil, fmt.Errorf("invalid key file: %w", err)
+	}
+	return ks.store(&encrypted)
+}
+
+// Export returns the encrypted key file of the key with the given ID, which can be imported with ImportEncrypted.
+func (ks *KeyStore) Export(id string) ([]byte, error) {
+	encrypted, err := ks.load(id)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(encrypted, "", "  ")
+}
+
+// List returns the keys in the key store, sorted by address and key index.
+func (ks *KeyStore) List() ([]*Entry, error) {
+	files, err := os.ReadDir(ks.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key store directory: %w", err)
+	}
+
+	entries := make([]*Entry, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), keyFileExtension) {
+			continue
+		}
+
+		encrypted, err := ks.load(strings.TrimSuffix(file.Name(), keyFileExtension))
+		if err != nil {
+			return nil, err
+		}
+
+		entry, err := ks.entry(encrypted)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Address != entries[j].Address {
+			return entries[i].Address.Hex() < entries[j].Address.Hex()
+		}
+		if entries[i].KeyIndex != entries[j].KeyIndex {
+			return entries[i].KeyIndex < entries[j].KeyIndex
+		}
+		return entries[i].ID < entries[j].ID
+	})
+
+	return entries, nil
+}
+
+// Find returns the key of the given account and key index.
+func (ks *KeyStore) Find(address flow.Address, keyIndex uint32) (*Entry, error) {
+	entries, err := ks.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.Address == address && entry.KeyIndex == keyIndex {
+			return entry, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: account %s, key index %d", ErrKeyNotFound, address, keyIndex)
+}
+
+// UnlockKey decrypts the key with the given ID.
+func (ks *KeyStore) UnlockKey(id string, password []byte) (*Key, error) {
+	encrypted, err := ks.load(id)
+	if err != nil {
+		return nil, err
+	}
+	return Decrypt(encrypted, password)
+}
+
+// Unlock decrypts the key with the given ID and returns a signer for it.
+func (ks *KeyStore) Unlock(id string, password []byte) (crypto.Signer, error) {
+	key, err := ks.UnlockKey(id, password)
+	if err != nil {
+		return nil, err
+	}
+	return key.Signer()
+}
+
+// Delete removes the key with the given ID from the key store.
+func (ks *KeyStore) Delete(id string) error {
+	path, err := ks.path(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%w: %s", ErrKeyNotFound, id)
+		}
+		return err
+	}
+	return nil
+}
+
+func (ks *KeyStore) store(encrypted *EncryptedKey) (*Entry, error) {
+	entry, err := ks.entry(encrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(encrypted, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	// never overwrite an existing key
+	file, err := os.OpenFile(entry.Path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to write key file: %w", err)
+	}
+
+	return entry, file.Sync()
+}
+
+func (ks *KeyStore) load(id string) (*EncryptedKey, error) {
+	path, err := ks.path(id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	var encrypted EncryptedKey
+	if err := json.Unmarshal(data, &encrypted); err != nil {
+		return nil, fmt.Errorf("invalid key file %s: %w", path, err)
+	}
+	if encrypted.ID != id {
+		return nil, fmt.Errorf("invalid key file %s: ID %s does not match file name", path, encrypted.ID)
+	}
+	return &encrypted, nil
+}
+
+func (ks *KeyStore) entry(encrypted *EncryptedKey) (*Entry, error) {
+	path, err := ks.path(encrypted.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sigAlgo := crypto.StringToSignatureAlgorithm(encrypted.SignatureAlgorithm)
+	publicKey, err := crypto.DecodePublicKeyHex(sigAlgo, encrypted.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key of key %s: %w", encrypted.ID, err)
+	}
+
+	return &Entry{
+		ID:        encrypted.ID,
+		Address:   encrypted.Address,
+		KeyIndex:  encrypted.KeyIndex,
+		SigAlgo:   sigAlgo,
+		HashAlgo:  crypto.StringToHashAlgorithm(encrypted.HashAlgorithm),
+		PublicKey: publicKey,
+		Path:      path,
+	}, nil
+}
+
+// path returns the path of the key file of the key with the given ID.
+func (ks *KeyStore) path(id string) (string, error) {
+	// IDs are hex strings, which prevents path traversal
+	if _, err := hex.DecodeString(id); err != nil || id == "" {
+		return "", fmt.Errorf("invalid key ID %q", id)
+	}
+	return filepath.Join(ks.dir, id+keyFileExtension), nil
+}
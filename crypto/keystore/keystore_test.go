@@ -0,0 +1,220 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keystore_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/keystore"
+)
+
+var password = []byte("correct horse battery staple")
+
+// fastScrypt keeps the tests fast, it must not be used in production.
+var fastScrypt = keystore.WithScrypt(1<<10, 8, 1)
+
+func newKey(t *testing.T) *keystore.Key {
+	privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_secp256k1, make([]byte, crypto.MinSeedLength))
+	require.NoError(t, err)
+
+	return &keystore.Key{
+		Address:    flow.HexToAddress("01"),
+		KeyIndex:   2,
+		PrivateKey: privateKey,
+		HashAlgo:   crypto.SHA2_256,
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	key := newKey(t)
+
+	for name, opt := range map[string]keystore.Option{
+		"scrypt":   fastScrypt,
+		"argon2id": keystore.WithArgon2id(1, 1024, 1),
+	} {
+		t.Run(name, func(t *testing.T) {
+			encrypted, err := keystore.Encrypt(key, password, opt)
+			require.NoError(t, err)
+			assert.Equal(t, "ECDSA_secp256k1", encrypted.SignatureAlgorithm)
+			assert.Equal(t, "SHA2_256", encrypted.HashAlgorithm)
+
+			decrypted, err := keystore.Decrypt(encrypted, password)
+			require.NoError(t, err)
+			assert.True(t, key.PrivateKey.Equals(decrypted.PrivateKey))
+			assert.Equal(t, key.Address, decrypted.Address)
+			assert.Equal(t, key.KeyIndex, decrypted.KeyIndex)
+			assert.Equal(t, key.HashAlgo, decrypted.HashAlgo)
+
+			_, err = keystore.Decrypt(encrypted, []byte("wrong"))
+			require.ErrorIs(t, err, keystore.ErrDecryption)
+		})
+	}
+
+	t.Run("Tampered metadata", func(t *testing.T) {
+		encrypted, err := keystore.Encrypt(key, password, fastScrypt)
+		require.NoError(t, err)
+
+		encrypted.KeyIndex = 3
+
+		_, err = keystore.Decrypt(encrypted, password)
+		require.ErrorIs(t, err, keystore.ErrDecryption)
+	})
+
+	t.Run("Excessive key derivation parameters", func(t *testing.T) {
+		for name, params := range map[string]struct {
+			kdf    string
+			params string
+		}{
+			"scrypt n":        {keystore.KDFScrypt, `{"n":4294967296,"r":8,"p":1,"salt":""}`},
+			"scrypt memory":   {keystore.KDFScrypt, `{"n":1048576,"r":32,"p":1,"salt":""}`},
+			"scrypt zero r":   {keystore.KDFScrypt, `{"n":1024,"r":0,"p":1,"salt":""}`},
+			"argon2id memory": {keystore.KDFArgon2id, `{"time":1,"memory":4294967295,"threads":1,"salt":""}`},
+			"argon2id time":   {keystore.KDFArgon2id, `{"time":4294967295,"memory":1024,"threads":1,"salt":""}`},
+			"unsupported kdf": {"pbkdf2", `{}`},
+		} {
+			t.Run(name, func(t *testing.T) {
+				encrypted, err := keystore.Encrypt(key, password, fastScrypt)
+				require.NoError(t, err)
+
+				encrypted.Crypto.KDF = params.kdf
+				encrypted.Crypto.KDFParams = json.RawMessage(params.params)
+
+				_, err = keystore.Decrypt(encrypted, password)
+				require.Error(t, err)
+				assert.NotErrorIs(t, err, keystore.ErrDecryption)
+
+				data, err := json.Marshal(encrypted)
+				require.NoError(t, err)
+
+				ks, err := keystore.New(t.TempDir())
+				require.NoError(t, err)
+
+				_, err = ks.ImportEncrypted(data)
+				require.Error(t, err)
+			})
+		}
+
+		_, err := keystore.Encrypt(key, password, keystore.WithArgon2id(1, 1<<21, 1))
+		require.Error(t, err)
+	})
+
+	t.Run("Incompatible algorithms", func(t *testing.T) {
+		invalid := *key
+		invalid.HashAlgo = crypto.SHA3_384
+
+		_, err := keystore.Encrypt(&invalid, password, fastScrypt)
+		require.Error(t, err)
+	})
+}
+
+func TestKeyStore(t *testing.T) {
+	dir := t.TempDir()
+
+	ks, err := keystore.New(dir, fastScrypt)
+	require.NoError(t, err)
+
+	key := newKey(t)
+	imported, err := ks.Import(key, password)
+	require.NoError(t, err)
+	assert.True(t, key.PrivateKey.PublicKey().Equals(imported.PublicKey))
+
+	created, err := ks.Create(flow.HexToAddress("02"), 0, crypto.ECDSA_P256, crypto.SHA3_256, password)
+	require.NoError(t, err)
+
+	info, err := os.Stat(created.Path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	t.Run("List", func(t *testing.T) {
+		entries, err := ks.List()
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, imported.ID, entries[0].ID)
+		assert.Equal(t, created.ID, entries[1].ID)
+		assert.Equal(t, crypto.ECDSA_P256, entries[1].SigAlgo)
+
+		found, err := ks.Find(flow.HexToAddress("01"), 2)
+		require.NoError(t, err)
+		assert.Equal(t, imported.ID, found.ID)
+
+		_, err = ks.Find(flow.HexToAddress("01"), 0)
+		require.ErrorIs(t, err, keystore.ErrKeyNotFound)
+	})
+
+	t.Run("Unlock", func(t *testing.T) {
+		signer, err := ks.Unlock(created.ID, password)
+		require.NoError(t, err)
+
+		message := []byte("message")
+		signature, err := signer.Sign(message)
+		require.NoError(t, err)
+
+		hasher, err := crypto.NewHasher(crypto.SHA3_256)
+		require.NoError(t, err)
+		valid, err := created.PublicKey.Verify(signature, message, hasher)
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		_, err = ks.Unlock(created.ID, []byte("wrong"))
+		require.ErrorIs(t, err, keystore.ErrDecryption)
+	})
+
+	t.Run("Export and import", func(t *testing.T) {
+		data, err := ks.Export(imported.ID)
+		require.NoError(t, err)
+
+		var encrypted keystore.EncryptedKey
+		require.NoError(t, json.Unmarshal(data, &encrypted))
+		assert.NotContains(t, string(data), key.PrivateKey.String())
+
+		other, err := keystore.New(t.TempDir())
+		require.NoError(t, err)
+
+		entry, err := other.ImportEncrypted(data)
+		require.NoError(t, err)
+
+		unlocked, err := other.UnlockKey(entry.ID, password)
+		require.NoError(t, err)
+		assert.True(t, key.PrivateKey.Equals(unlocked.PrivateKey))
+
+		// keys are never overwritten
+		_, err = other.ImportEncrypted(data)
+		require.Error(t, err)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, ks.Delete(created.ID))
+		require.ErrorIs(t, ks.Delete(created.ID), keystore.ErrKeyNotFound)
+
+		_, err := ks.Unlock(created.ID, password)
+		require.ErrorIs(t, err, keystore.ErrKeyNotFound)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		_, err := ks.Export("../secret")
+		require.Error(t, err)
+	})
+}
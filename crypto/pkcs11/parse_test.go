@@ -0,0 +1,116 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkcs11
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+func decodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func TestParseSignatureAlgorithm(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  string
+		sigAlgo crypto.SignatureAlgorithm
+		err     bool
+	}{
+		{name: "P-256", params: "06082a8648ce3d030107", sigAlgo: crypto.ECDSA_P256},
+		{name: "secp256k1", params: "06052b8104000a", sigAlgo: crypto.ECDSA_secp256k1},
+		{name: "P-384", params: "06052b81040022", err: true},
+		{name: "Truncated", params: "06082a8648ce", err: true},
+		{name: "Not an OID", params: "0500", err: true},
+		{name: "Empty", params: "", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sigAlgo, err := parseSignatureAlgorithm(decodeHex(t, tt.params))
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.sigAlgo, sigAlgo)
+		})
+	}
+}
+
+func TestParseECPoint(t *testing.T) {
+	// the generators of the curves
+	const (
+		p256X      = "6b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c296"
+		p256Y      = "4fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5"
+		secp256k1X = "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+		secp256k1Y = "483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"
+	)
+
+	tests := []struct {
+		name    string
+		point   string
+		sigAlgo crypto.SignatureAlgorithm
+		encoded string
+	}{
+		{name: "P-256 octet string", point: "0441" + "04" + p256X + p256Y, sigAlgo: crypto.ECDSA_P256, encoded: p256X + p256Y},
+		{name: "P-256 raw", point: "04" + p256X + p256Y, sigAlgo: crypto.ECDSA_P256, encoded: p256X + p256Y},
+		{
+			name:    "secp256k1 octet string",
+			point:   "0441" + "04" + secp256k1X + secp256k1Y,
+			sigAlgo: crypto.ECDSA_secp256k1,
+			encoded: secp256k1X + secp256k1Y,
+		},
+		{
+			name:    "secp256k1 raw",
+			point:   "04" + secp256k1X + secp256k1Y,
+			sigAlgo: crypto.ECDSA_secp256k1,
+			encoded: secp256k1X + secp256k1Y,
+		},
+		{name: "Compressed", point: "02" + p256X},
+		{name: "Compressed octet string", point: "0421" + "02" + p256X},
+		{name: "Trailing data", point: "0441" + "04" + p256X + p256Y + "00"},
+		{name: "Truncated", point: "04" + p256X},
+		{name: "Empty octet string", point: "0400"},
+		{name: "Empty", point: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := parseECPoint(decodeHex(t, tt.point))
+			if tt.encoded == "" {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, decodeHex(t, tt.encoded), encoded)
+
+			_, err = crypto.DecodePublicKey(tt.sigAlgo, encoded)
+			require.NoError(t, err)
+		})
+	}
+}
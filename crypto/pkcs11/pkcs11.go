@@ -0,0 +1,310 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package pkcs11 provides a PKCS#11 implementation of the crypto.Signer interface,
+// for signing keys stored in hardware security modules (HSMs).
+//
+// Any PKCS#11 module with ECDSA keys on the P-256 or secp256k1 curves is supported,
+// for example SoftHSM for local development: https://github.com/opendnssec/SoftHSMv2
+package pkcs11
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"sync"
+
+	p11 "github.com/miekg/pkcs11"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// ErrKeyNotFound is returned when the token does not contain a key.
+var ErrKeyNotFound = errors.New("pkcs11: key not found")
+
+var (
+	oidNamedCurveP256      = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+	oidNamedCurveSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// Config configures the PKCS#11 module and token of a client.
+type Config struct {
+	// Module is the path of the PKCS#11 module, e.g. /usr/lib/softhsm/libsofthsm2.so.
+	Module string
+	// TokenLabel is the label of the token. If empty, the token in Slot is used.
+	TokenLabel string
+	// Slot is the ID of the slot of the token, used if TokenLabel is empty.
+	Slot uint
+	// PIN is the user PIN of the token.
+	PIN string
+}
+
+// Key is a reference to an ECDSA key pair on a PKCS#11 token.
+//
+// Keys are found by label, by ID, or by both. The private and public key objects must have the same label and ID.
+type Key struct {
+	Label string `json:"label"`
+	ID    []byte `json:"id"`
+}
+
+// String returns a description of the key for error messages.
+func (k Key) String() string {
+	if len(k.ID) == 0 {
+		return fmt.Sprintf("label %q", k.Label)
+	}
+	if k.Label == "" {
+		return fmt.Sprintf("ID %x", k.ID)
+	}
+	return fmt.Sprintf("label %q and ID %x", k.Label, k.ID)
+}
+
+// Client is a client for signing with keys of a PKCS#11 token
+// using types native to the Flow Go SDK.
+//
+// A client holds a logged in session of the token. Operations of the session are serialized,
+// so a client can be shared by concurrent signers.
+type Client struct {
+	mu          sync.Mutex
+	ctx         *p11.Ctx
+	session     p11.SessionHandle
+	initialized bool
+}
+
+// NewClient loads the PKCS#11 module, opens a session of the token and logs in with the user PIN.
+//
+// The client must be closed with Close when it is no longer used.
+func NewClient(cfg Config) (*Client, error) {
+	ctx := p11.New(cfg.Module)
+	if ctx == nil {
+		return nil, fmt.Errorf("pkcs11: failed to load module %s", cfg.Module)
+	}
+
+	c := &Client{ctx: ctx}
+
+	err := ctx.Initialize()
+	switch {
+	case err == nil:
+		c.initialized = true
+	case errors.Is(err, p11.Error(p11.CKR_CRYPTOKI_ALREADY_INITIALIZED)):
+		// the module is used by another client of the process
+	default:
+		ctx.Destroy()
+		return nil, fmt.Errorf("pkcs11: failed to initialize module: %w", err)
+	}
+
+	slot, err := c.findSlot(cfg)
+	if err != nil {
+		c.finalize()
+		return nil, err
+	}
+
+	c.session, err = ctx.OpenSession(slot, p11.CKF_SERIAL_SESSION)
+	if err != nil {
+		c.finalize()
+		return nil, fmt.Errorf("pkcs11: failed to open session: %w", err)
+	}
+
+	err = ctx.Login(c.session, p11.CKU_USER, cfg.PIN)
+	if err != nil && !errors.Is(err, p11.Error(p11.CKR_USER_ALREADY_LOGGED_IN)) {
+		_ = ctx.CloseSession(c.session)
+		c.finalize()
+		return nil, fmt.Errorf("pkcs11: failed to log in: %w", err)
+	}
+
+	return c, nil
+}
+
+// Close logs out, closes the session and unloads the module.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ctx == nil {
+		return nil
+	}
+
+	_ = c.ctx.Logout(c.session)
+	err := c.ctx.CloseSession(c.session)
+	c.finalize()
+	c.ctx = nil
+
+	if err != nil {
+		return fmt.Errorf("pkcs11: failed to close session: %w", err)
+	}
+	return nil
+}
+
+// GetPublicKey reads the public key of a key pair of the token.
+//
+// Only ECDSA keys on the P-256 and secp256k1 curves are supported.
+func (c *Client) GetPublicKey(key Key) (crypto.PublicKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	handle, err := c.findObject(p11.CKO_PUBLIC_KEY, key)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes, err := c.ctx.GetAttributeValue(c.session, handle, []*p11.Attribute{
+		p11.NewAttribute(p11.CKA_EC_PARAMS, nil),
+		p11.NewAttribute(p11.CKA_EC_POINT, nil),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11: failed to read public key with %s: %w", key, err)
+	}
+
+	var params, point []byte
+	for _, attribute := range attributes {
+		switch attribute.Type {
+		case p11.CKA_EC_PARAMS:
+			params = attribute.Value
+		case p11.CKA_EC_POINT:
+			point = attribute.Value
+		}
+	}
+
+	sigAlgo, err := parseSignatureAlgorithm(params)
+	if err != nil {
+		return nil, err
+	}
+
+	encodedPoint, err := parseECPoint(point)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := crypto.DecodePublicKey(sigAlgo, encodedPoint)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11: failed to decode public key: %w", err)
+	}
+
+	return publicKey, nil
+}
+
+// PKCS11Context gives access to the pkcs11.Ctx of the client.
+//
+// The context must not be used concurrently with the client.
+func (c *Client) PKCS11Context() *p11.Ctx {
+	return c.ctx
+}
+
+func (c *Client) findSlot(cfg Config) (uint, error) {
+	if cfg.TokenLabel == "" {
+		return cfg.Slot, nil
+	}
+
+	slots, err := c.ctx.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("pkcs11: failed to list slots: %w", err)
+	}
+
+	for _, slot := range slots {
+		info, err := c.ctx.GetTokenInfo(slot)
+		if err != nil {
+			return 0, fmt.Errorf("pkcs11: failed to read token info of slot %d: %w", slot, err)
+		}
+		if info.Label == cfg.TokenLabel {
+			return slot, nil
+		}
+	}
+
+	return 0, fmt.Errorf("pkcs11: token with label %q not found", cfg.TokenLabel)
+}
+
+// findObject returns the handle of the key object of the class. The caller must hold the lock.
+func (c *Client) findObject(class uint, key Key) (p11.ObjectHandle, error) {
+	if c.ctx == nil {
+		return 0, fmt.Errorf("pkcs11: client is closed")
+	}
+	if key.Label == "" && len(key.ID) == 0 {
+		return 0, fmt.Errorf("pkcs11: key label or ID is required")
+	}
+
+	template := []*p11.Attribute{
+		p11.NewAttribute(p11.CKA_CLASS, class),
+		p11.NewAttribute(p11.CKA_KEY_TYPE, p11.CKK_EC),
+	}
+	if key.Label != "" {
+		template = append(template, p11.NewAttribute(p11.CKA_LABEL, key.Label))
+	}
+	if len(key.ID) > 0 {
+		template = append(template, p11.NewAttribute(p11.CKA_ID, key.ID))
+	}
+
+	if err := c.ctx.FindObjectsInit(c.session, template); err != nil {
+		return 0, fmt.Errorf("pkcs11: failed to find key: %w", err)
+	}
+	handles, _, err := c.ctx.FindObjects(c.session, 2)
+	if finalErr := c.ctx.FindObjectsFinal(c.session); err == nil {
+		err = finalErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("pkcs11: failed to find key: %w", err)
+	}
+
+	switch len(handles) {
+	case 0:
+		return 0, fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+	case 1:
+		return handles[0], nil
+	default:
+		return 0, fmt.Errorf("pkcs11: multiple keys with %s", key)
+	}
+}
+
+func (c *Client) finalize() {
+	if c.initialized {
+		_ = c.ctx.Finalize()
+	}
+	c.ctx.Destroy()
+}
+
+// parseSignatureAlgorithm returns the signature algorithm of the DER encoded CKA_EC_PARAMS of a key.
+func parseSignatureAlgorithm(params []byte) (crypto.SignatureAlgorithm, error) {
+	var oid asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(params, &oid); err != nil {
+		return crypto.UnknownSignatureAlgorithm, fmt.Errorf("pkcs11: unsupported EC parameters: %w", err)
+	}
+
+	switch {
+	case oid.Equal(oidNamedCurveP256):
+		return crypto.ECDSA_P256, nil
+	case oid.Equal(oidNamedCurveSecp256k1):
+		return crypto.ECDSA_secp256k1, nil
+	default:
+		return crypto.UnknownSignatureAlgorithm, fmt.Errorf("pkcs11: unsupported curve %s", oid)
+	}
+}
+
+// parseECPoint returns the encoding X || Y of the CKA_EC_POINT of a public key.
+//
+// PKCS#11 specifies the point as a DER encoded octet string of the uncompressed point,
+// but some modules return the uncompressed point without the octet string.
+// The uncompressed points of both supported curves are 0x04 || X || Y, with 32 byte coordinates.
+func parseECPoint(point []byte) ([]byte, error) {
+	var uncompressed []byte
+	if rest, err := asn1.Unmarshal(point, &uncompressed); err != nil || len(rest) > 0 {
+		uncompressed = point
+	}
+
+	if len(uncompressed) != 65 || uncompressed[0] != 0x04 {
+		return nil, fmt.Errorf("pkcs11: unsupported EC point encoding")
+	}
+	return uncompressed[1:], nil
+}
@@ -0,0 +1,185 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkcs11_test
+
+import (
+	"encoding/asn1"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	p11 "github.com/miekg/pkcs11"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/pkcs11"
+)
+
+const (
+	testTokenLabel = "flow"
+	testPIN        = "1234"
+	testSOPIN      = "5678"
+)
+
+func TestNewClient(t *testing.T) {
+	_, err := pkcs11.NewClient(pkcs11.Config{Module: filepath.Join(t.TempDir(), "missing.so")})
+	require.Error(t, err)
+}
+
+// TestSoftHSMSigning tests signing with keys of a SoftHSM token.
+//
+// The test is skipped unless PKCS11_TEST_MODULE is set to the path of the SoftHSM module,
+// e.g. /usr/lib/softhsm/libsofthsm2.so. A new token is initialized in a temporary directory.
+func TestSoftHSMSigning(t *testing.T) {
+	module := os.Getenv("PKCS11_TEST_MODULE")
+	if module == "" {
+		t.Skip("PKCS11_TEST_MODULE is not set")
+	}
+
+	initSoftHSMToken(t, module, map[string]asn1.ObjectIdentifier{
+		"p256":      {1, 2, 840, 10045, 3, 1, 7},
+		"secp256k1": {1, 3, 132, 0, 10},
+	})
+
+	client, err := pkcs11.NewClient(pkcs11.Config{
+		Module:     module,
+		TokenLabel: testTokenLabel,
+		PIN:        testPIN,
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	tests := []struct {
+		key      pkcs11.Key
+		sigAlgo  crypto.SignatureAlgorithm
+		hashAlgo crypto.HashAlgorithm
+	}{
+		{pkcs11.Key{Label: "p256"}, crypto.ECDSA_P256, crypto.SHA3_256},
+		{pkcs11.Key{ID: []byte("p256")}, crypto.ECDSA_P256, crypto.SHA2_256},
+		{pkcs11.Key{Label: "secp256k1", ID: []byte("secp256k1")}, crypto.ECDSA_secp256k1, crypto.SHA2_256},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s %s", test.key, test.hashAlgo), func(t *testing.T) {
+			signer, err := client.SignerForKey(test.key, test.hashAlgo)
+			if test.sigAlgo == crypto.ECDSA_secp256k1 && err != nil {
+				t.Skipf("secp256k1 is not supported by the module: %s", err)
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.sigAlgo, signer.PublicKey().Algorithm())
+
+			hasher, err := crypto.NewHasher(test.hashAlgo)
+			require.NoError(t, err)
+
+			for _, length := range []int{0, 32, 5000} {
+				message := make([]byte, length)
+				signature, err := signer.Sign(message)
+				require.NoError(t, err)
+
+				valid, err := signer.PublicKey().Verify(signature, message, hasher)
+				require.NoError(t, err)
+				assert.True(t, valid)
+			}
+		})
+	}
+
+	t.Run("Unknown key", func(t *testing.T) {
+		_, err := client.SignerForKey(pkcs11.Key{Label: "unknown"}, crypto.SHA3_256)
+		require.ErrorIs(t, err, pkcs11.ErrKeyNotFound)
+	})
+
+	t.Run("Incompatible hash algorithm", func(t *testing.T) {
+		_, err := client.SignerForKey(pkcs11.Key{Label: "p256"}, crypto.SHA3_384)
+		require.Error(t, err)
+	})
+}
+
+// initSoftHSMToken initializes a SoftHSM token in a temporary directory,
+// and generates an EC key pair for each label, with the label as ID.
+func initSoftHSMToken(t *testing.T, module string, curves map[string]asn1.ObjectIdentifier) {
+	dir := t.TempDir()
+	conf := filepath.Join(dir, "softhsm2.conf")
+	require.NoError(t, os.WriteFile(
+		conf,
+		[]byte(fmt.Sprintf("directories.tokendir = %s\nobjectstore.backend = file\nlog.level = ERROR\n", dir)),
+		0o600,
+	))
+	t.Setenv("SOFTHSM2_CONF", conf)
+
+	ctx := p11.New(module)
+	require.NotNil(t, ctx)
+	require.NoError(t, ctx.Initialize())
+	defer func() {
+		_ = ctx.Finalize()
+		ctx.Destroy()
+	}()
+
+	slots, err := ctx.GetSlotList(true)
+	require.NoError(t, err)
+	require.NotEmpty(t, slots)
+	require.NoError(t, ctx.InitToken(slots[0], testSOPIN, testTokenLabel))
+
+	// the token is moved to a new slot after initialization
+	slots, err = ctx.GetSlotList(true)
+	require.NoError(t, err)
+	var slot uint
+	for _, s := range slots {
+		info, err := ctx.GetTokenInfo(s)
+		require.NoError(t, err)
+		if info.Label == testTokenLabel {
+			slot = s
+		}
+	}
+
+	session, err := ctx.OpenSession(slot, p11.CKF_SERIAL_SESSION|p11.CKF_RW_SESSION)
+	require.NoError(t, err)
+	defer func() { _ = ctx.CloseSession(session) }()
+
+	require.NoError(t, ctx.Login(session, p11.CKU_SO, testSOPIN))
+	require.NoError(t, ctx.InitPIN(session, testPIN))
+	require.NoError(t, ctx.Logout(session))
+	require.NoError(t, ctx.Login(session, p11.CKU_USER, testPIN))
+
+	for label, curve := range curves {
+		params, err := asn1.Marshal(curve)
+		require.NoError(t, err)
+
+		// generating secp256k1 keys fails if the module does not support the curve
+		_, _, _ = ctx.GenerateKeyPair(
+			session,
+			[]*p11.Mechanism{p11.NewMechanism(p11.CKM_EC_KEY_PAIR_GEN, nil)},
+			[]*p11.Attribute{
+				p11.NewAttribute(p11.CKA_TOKEN, true),
+				p11.NewAttribute(p11.CKA_VERIFY, true),
+				p11.NewAttribute(p11.CKA_EC_PARAMS, params),
+				p11.NewAttribute(p11.CKA_LABEL, label),
+				p11.NewAttribute(p11.CKA_ID, []byte(label)),
+			},
+			[]*p11.Attribute{
+				p11.NewAttribute(p11.CKA_TOKEN, true),
+				p11.NewAttribute(p11.CKA_SIGN, true),
+				p11.NewAttribute(p11.CKA_SENSITIVE, true),
+				p11.NewAttribute(p11.CKA_LABEL, label),
+				p11.NewAttribute(p11.CKA_ID, []byte(label)),
+			},
+		)
+	}
+}
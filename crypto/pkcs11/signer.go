@@ -0,0 +1,131 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkcs11
+
+import (
+	"fmt"
+
+	p11 "github.com/miekg/pkcs11"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/internal"
+)
+
+var _ crypto.Signer = (*Signer)(nil)
+
+// Signer is a PKCS#11 implementation of crypto.Signer.
+type Signer struct {
+	client *Client
+	key    Key
+	// ECDSA is the only algorithm supported by this package. The signature algorithm
+	// therefore represents the elliptic curve used. The curve is needed to parse the signature.
+	curve crypto.SignatureAlgorithm
+	// public key for easier access
+	publicKey crypto.PublicKey
+	// Hash algorithm of the account key
+	hashAlgo crypto.HashAlgorithm
+}
+
+// SignerForKey returns a new PKCS#11 signer for a key pair of the token.
+//
+// Only ECDSA keys on P-256 and secp256k1 curves are supported. Messages are hashed with
+// the hash algorithm of the account key outside the token, and the digest is signed with CKM_ECDSA.
+func (c *Client) SignerForKey(key Key, hashAlgo crypto.HashAlgorithm) (*Signer, error) {
+	pk, err := c.GetPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !crypto.CompatibleAlgorithms(pk.Algorithm(), hashAlgo) || hashAlgo == crypto.KMAC128 {
+		return nil, fmt.Errorf("pkcs11: unsupported hash algorithm %s for %s key", hashAlgo, pk.Algorithm())
+	}
+
+	return &Signer{
+		client:    c,
+		key:       key,
+		curve:     pk.Algorithm(),
+		publicKey: pk,
+		hashAlgo:  hashAlgo,
+	}, nil
+}
+
+// Sign signs the given message using the private key of the token for this signer.
+func (s *Signer) Sign(message []byte) ([]byte, error) {
+	hasher, err := crypto.NewHasher(s.hashAlgo)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11: failed to sign: %w", err)
+	}
+	digest := hasher.ComputeHash(message)
+
+	signature, err := s.client.sign(s.key, mechanism(s.curve), digest)
+	if err != nil {
+		return nil, err
+	}
+
+	// PKCS#11 specifies ECDSA signatures as R || S, but some modules return DER encoded signatures
+	if len(signature) == 2*curveOrderLen {
+		return signature, nil
+	}
+
+	sig, err := internal.ParseECDSASignature(signature, s.curve)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11: failed to parse signature: %w", err)
+	}
+	return sig, nil
+}
+
+func (s *Signer) PublicKey() crypto.PublicKey {
+	return s.publicKey
+}
+
+// curveOrderLen is the size in bytes of the order of the P-256 and secp256k1 curves.
+const curveOrderLen = 32
+
+// mechanism returns the PKCS#11 signing mechanism for keys on the curve.
+//
+// Both curves sign digests with CKM_ECDSA, the curve is selected by the EC parameters of the key.
+// Combined mechanisms like CKM_ECDSA_SHA256 are not used, as tokens do not support SHA3.
+func mechanism(curve crypto.SignatureAlgorithm) []*p11.Mechanism {
+	switch curve {
+	case crypto.ECDSA_P256, crypto.ECDSA_secp256k1:
+		return []*p11.Mechanism{p11.NewMechanism(p11.CKM_ECDSA, nil)}
+	default:
+		return nil
+	}
+}
+
+func (c *Client) sign(key Key, mechanism []*p11.Mechanism, digest []byte) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// the handle is looked up for every signature, as handles are only valid for the session
+	handle, err := c.findObject(p11.CKO_PRIVATE_KEY, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.ctx.SignInit(c.session, mechanism, handle); err != nil {
+		return nil, fmt.Errorf("pkcs11: failed to sign: %w", err)
+	}
+	signature, err := c.ctx.Sign(c.session, digest)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11: failed to sign: %w", err)
+	}
+	return signature, nil
+}
@@ -0,0 +1,193 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package policy enforces transaction signing policies.
+//
+// A Policy is a set of rules a transaction must satisfy to be signed. The rules are enforced by wrapping
+// a crypto.Signer, which then only signs transaction payloads and envelopes matching the policy:
+//
+//	p := policy.New(
+//		policy.AllowScripts(policy.Script(transferScript, policy.MaxAmount(0, maxAmount), policy.AllowedAddresses(1, treasury))),
+//		policy.AllowPayers(payer),
+//		policy.MaxComputeLimit(1000),
+//	)
+//	err := tx.SignEnvelope(payer, 0, p.Signer(hotKeySigner))
+//
+// Violations are returned as a *ViolationError, which lists every violated rule.
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// ErrPolicyViolation is matched by errors.Is for every *ViolationError.
+var ErrPolicyViolation = errors.New("transaction violates signing policy")
+
+// Names of the rules of violations.
+const (
+	RuleMessage      = "message"
+	RuleScript       = "script"
+	RuleArgument     = "argument"
+	RulePayer        = "payer"
+	RuleAuthorizer   = "authorizer"
+	RuleComputeLimit = "compute-limit"
+)
+
+// Violation describes a violated rule.
+type Violation struct {
+	// Rule is the name of the violated rule, e.g. RuleScript.
+	Rule string
+	// Message describes the violation.
+	Message string
+}
+
+func (v Violation) String() string {
+	return v.Rule + ": " + v.Message
+}
+
+// ViolationError is returned when a transaction violates a policy.
+type ViolationError struct {
+	Violations []Violation
+}
+
+func (e *ViolationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.String()
+	}
+	return fmt.Sprintf("%s: %s", ErrPolicyViolation, strings.Join(messages, "; "))
+}
+
+// Is returns true for ErrPolicyViolation.
+func (e *ViolationError) Is(target error) bool {
+	return target == ErrPolicyViolation
+}
+
+// HasRule returns true if the rule is violated.
+func (e *ViolationError) HasRule(rule string) bool {
+	for _, violation := range e.Violations {
+		if violation.Rule == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// A Rule checks a transaction and returns its violations.
+type Rule interface {
+	Check(tx *flow.Transaction) []Violation
+}
+
+// RuleFunc is a function implementing Rule.
+type RuleFunc func(tx *flow.Transaction) []Violation
+
+// Check calls the function.
+func (f RuleFunc) Check(tx *flow.Transaction) []Violation {
+	return f(tx)
+}
+
+// Policy is a set of rules a transaction must satisfy.
+type Policy struct {
+	rules []Rule
+}
+
+// New returns a policy of the rules.
+func New(rules ...Rule) *Policy {
+	return &Policy{rules: rules}
+}
+
+// Check checks the transaction against all rules of the policy.
+//
+// It returns a *ViolationError listing the violations of all rules, or nil if the transaction satisfies the policy.
+func (p *Policy) Check(tx *flow.Transaction) error {
+	var violations []Violation
+	for _, rule := range p.rules {
+		violations = append(violations, rule.Check(tx)...)
+	}
+	if len(violations) > 0 {
+		return &ViolationError{Violations: violations}
+	}
+	return nil
+}
+
+// Signer returns a signer which only signs transaction payloads and envelopes satisfying the policy.
+func (p *Policy) Signer(signer crypto.Signer) *Signer {
+	return &Signer{
+		signer: signer,
+		policy: p,
+	}
+}
+
+var _ crypto.Signer = (*Signer)(nil)
+
+// Signer is a crypto.Signer enforcing a policy.
+type Signer struct {
+	signer crypto.Signer
+	policy *Policy
+}
+
+// Sign signs the message if it is a transaction payload or envelope satisfying the policy.
+//
+// Other messages, e.g. user messages, are rejected with a violation of RuleMessage.
+func (s *Signer) Sign(message []byte) ([]byte, error) {
+	tx, _, err := DecodeTransactionMessage(message)
+	if err != nil {
+		return nil, &ViolationError{Violations: []Violation{{Rule: RuleMessage, Message: err.Error()}}}
+	}
+
+	if err := s.policy.Check(tx); err != nil {
+		return nil, err
+	}
+
+	return s.signer.Sign(message)
+}
+
+func (s *Signer) PublicKey() crypto.PublicKey {
+	return s.signer.PublicKey()
+}
+
+// DecodeTransactionMessage decodes a signed transaction message, i.e. a transaction payload or envelope
+// prefixed with flow.TransactionDomainTag, and returns whether it is an envelope.
+//
+// The message must be canonically encoded, so the decoded transaction is exactly the transaction which is signed.
+func DecodeTransactionMessage(message []byte) (tx *flow.Transaction, envelope bool, err error) {
+	encoded, ok := bytes.CutPrefix(message, flow.TransactionDomainTag[:])
+	if !ok {
+		return nil, false, errors.New("message is not a transaction")
+	}
+
+	tx, err = flow.DecodeTransaction(encoded)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid transaction message: %w", err)
+	}
+
+	switch {
+	case bytes.Equal(tx.PayloadMessage(), encoded):
+		return tx, false, nil
+	case bytes.Equal(tx.EnvelopeMessage(), encoded):
+		return tx, true, nil
+	default:
+		return nil, false, errors.New("invalid transaction message: not a canonical payload or envelope")
+	}
+}
@@ -0,0 +1,208 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/policy"
+)
+
+var (
+	transferScript = []byte(`transaction(amount: UFix64, to: Address) { prepare(signer: &Account) {} }`)
+	payer          = flow.HexToAddress("01")
+	treasury       = flow.HexToAddress("02")
+	other          = flow.HexToAddress("03")
+)
+
+func newTransfer(t *testing.T, amount string, to flow.Address) *flow.Transaction {
+	value, err := cadence.NewUFix64(amount)
+	require.NoError(t, err)
+
+	tx := flow.NewTransaction().
+		SetScript(transferScript).
+		SetComputeLimit(100).
+		SetProposalKey(payer, 0, 1).
+		SetPayer(payer).
+		AddAuthorizer(payer)
+	require.NoError(t, tx.AddArgument(value))
+	require.NoError(t, tx.AddArgument(cadence.NewAddress(to)))
+	return tx
+}
+
+func newPolicy(t *testing.T) *policy.Policy {
+	maxAmount, err := cadence.NewUFix64("100.0")
+	require.NoError(t, err)
+
+	return policy.New(
+		policy.AllowScripts(policy.Script(transferScript, policy.MaxAmount(0, maxAmount), policy.AllowedAddresses(1, treasury))),
+		policy.AllowPayers(payer),
+		policy.AllowAuthorizers(payer),
+		policy.MaxComputeLimit(1000),
+	)
+}
+
+func TestPolicy_Check(t *testing.T) {
+	p := newPolicy(t)
+
+	t.Run("Allowed", func(t *testing.T) {
+		require.NoError(t, p.Check(newTransfer(t, "100.0", treasury)))
+	})
+
+	t.Run("Script not allowed", func(t *testing.T) {
+		tx := newTransfer(t, "1.0", treasury).SetScript([]byte("transaction {}"))
+
+		var violationErr *policy.ViolationError
+		require.ErrorAs(t, p.Check(tx), &violationErr)
+		assert.True(t, violationErr.HasRule(policy.RuleScript))
+		assert.Len(t, violationErr.Violations, 1)
+	})
+
+	t.Run("Arguments", func(t *testing.T) {
+		err := p.Check(newTransfer(t, "100.00000001", other))
+		require.ErrorIs(t, err, policy.ErrPolicyViolation)
+
+		var violationErr *policy.ViolationError
+		require.True(t, errors.As(err, &violationErr))
+		require.Len(t, violationErr.Violations, 2)
+		assert.Contains(t, violationErr.Violations[0].Message, "exceeds maximum")
+		assert.Contains(t, violationErr.Violations[1].Message, other.HexWithPrefix())
+	})
+
+	t.Run("Missing argument", func(t *testing.T) {
+		tx := newTransfer(t, "1.0", treasury)
+		tx.Arguments = tx.Arguments[:1]
+
+		var violationErr *policy.ViolationError
+		require.ErrorAs(t, p.Check(tx), &violationErr)
+		assert.True(t, violationErr.HasRule(policy.RuleArgument))
+	})
+
+	t.Run("Untyped arguments", func(t *testing.T) {
+		// arrays and dictionaries are decoded without a static type
+		arguments := [][]byte{
+			[]byte(`{"type":"Array","value":[]}`),
+			[]byte(`{"type":"Dictionary","value":[]}`),
+		}
+
+		for _, argument := range arguments {
+			tx := newTransfer(t, "1.0", treasury)
+			tx.Arguments = [][]byte{argument, argument}
+
+			var violationErr *policy.ViolationError
+			require.ErrorAs(t, p.Check(tx), &violationErr)
+			require.Len(t, violationErr.Violations, 2)
+			assert.Equal(t, policy.RuleArgument, violationErr.Violations[0].Rule)
+			assert.Contains(t, violationErr.Violations[0].Message, "expected UFix64 or Fix64 amount")
+			assert.Equal(t, policy.RuleArgument, violationErr.Violations[1].Rule)
+			assert.Contains(t, violationErr.Violations[1].Message, "expected Address")
+		}
+	})
+
+	t.Run("Multiple violations", func(t *testing.T) {
+		tx := newTransfer(t, "1.0", treasury).
+			SetPayer(other).
+			AddAuthorizer(other).
+			SetComputeLimit(9999)
+
+		var violationErr *policy.ViolationError
+		require.ErrorAs(t, p.Check(tx), &violationErr)
+		assert.True(t, violationErr.HasRule(policy.RulePayer))
+		assert.True(t, violationErr.HasRule(policy.RuleAuthorizer))
+		assert.True(t, violationErr.HasRule(policy.RuleComputeLimit))
+		assert.False(t, violationErr.HasRule(policy.RuleScript))
+	})
+}
+
+func TestPolicy_Signer(t *testing.T) {
+	privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, make([]byte, crypto.MinSeedLength))
+	require.NoError(t, err)
+	inMemorySigner, err := crypto.NewInMemorySigner(privateKey, crypto.SHA3_256)
+	require.NoError(t, err)
+
+	signer := newPolicy(t).Signer(inMemorySigner)
+	assert.True(t, privateKey.PublicKey().Equals(signer.PublicKey()))
+
+	t.Run("Allowed", func(t *testing.T) {
+		tx := newTransfer(t, "10.0", treasury)
+		require.NoError(t, tx.SignEnvelope(payer, 0, signer))
+
+		message := append(flow.TransactionDomainTag[:], tx.EnvelopeMessage()...)
+		valid, err := privateKey.PublicKey().Verify(tx.EnvelopeSignatures[0].Signature, message, crypto.NewSHA3_256())
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("Violation", func(t *testing.T) {
+		tx := newTransfer(t, "1000.0", treasury)
+		err := tx.SignEnvelope(payer, 0, signer)
+		require.ErrorIs(t, err, policy.ErrPolicyViolation)
+		assert.Empty(t, tx.EnvelopeSignatures)
+	})
+
+	t.Run("Malformed envelope", func(t *testing.T) {
+		tx := newTransfer(t, "1.0", treasury)
+		tx.PayloadSignatures = []flow.TransactionSignature{{
+			Address:     payer,
+			SignerIndex: 7,
+			Signature:   []byte{1},
+		}}
+
+		_, err := signer.Sign(append(flow.TransactionDomainTag[:], tx.EnvelopeMessage()...))
+
+		var violationErr *policy.ViolationError
+		require.ErrorAs(t, err, &violationErr)
+		assert.True(t, violationErr.HasRule(policy.RuleMessage))
+		assert.Contains(t, violationErr.Violations[0].Message, "invalid signer index 7")
+	})
+
+	t.Run("User message", func(t *testing.T) {
+		_, err := flow.SignUserMessage(signer, []byte("hello"))
+
+		var violationErr *policy.ViolationError
+		require.ErrorAs(t, err, &violationErr)
+		assert.True(t, violationErr.HasRule(policy.RuleMessage))
+	})
+}
+
+func TestDecodeTransactionMessage(t *testing.T) {
+	tx := newTransfer(t, "1.0", treasury)
+
+	decoded, envelope, err := policy.DecodeTransactionMessage(append(flow.TransactionDomainTag[:], tx.PayloadMessage()...))
+	require.NoError(t, err)
+	assert.False(t, envelope)
+	assert.Equal(t, tx.PayloadMessage(), decoded.PayloadMessage())
+
+	_, envelope, err = policy.DecodeTransactionMessage(append(flow.TransactionDomainTag[:], tx.EnvelopeMessage()...))
+	require.NoError(t, err)
+	assert.True(t, envelope)
+
+	_, _, err = policy.DecodeTransactionMessage(tx.PayloadMessage())
+	assert.Error(t, err)
+
+	_, _, err = policy.DecodeTransactionMessage(append(flow.TransactionDomainTag[:], 0xc0, 0x80))
+	assert.Error(t, err)
+}
@@ -0,0 +1,197 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// ScriptHash returns the hex encoded SHA3-256 hash of a script, as used by AllowScripts.
+func ScriptHash(script []byte) string {
+	return hex.EncodeToString(crypto.NewSHA3_256().ComputeHash(script))
+}
+
+// AllowedScript is a script allowed by AllowScripts, with the constraints of its arguments.
+type AllowedScript struct {
+	// Hash is the hash of the script, see ScriptHash.
+	Hash      string
+	Arguments []ArgumentRule
+}
+
+// Script returns the allowed script with the argument constraints.
+func Script(script []byte, arguments ...ArgumentRule) AllowedScript {
+	return AllowedScript{
+		Hash:      ScriptHash(script),
+		Arguments: arguments,
+	}
+}
+
+// ArgumentRule is a constraint of a transaction argument.
+type ArgumentRule struct {
+	// Index is the index of the argument.
+	Index int
+	// Check returns an error if the decoded argument violates the constraint.
+	Check func(value cadence.Value) error
+}
+
+// AllowScripts returns a rule which only allows transactions with the scripts, and arguments satisfying
+// the constraints of the script.
+func AllowScripts(scripts ...AllowedScript) Rule {
+	allowed := make(map[string]AllowedScript, len(scripts))
+	for _, script := range scripts {
+		allowed[script.Hash] = script
+	}
+
+	return RuleFunc(func(tx *flow.Transaction) []Violation {
+		hash := ScriptHash(tx.Script)
+		script, ok := allowed[hash]
+		if !ok {
+			return []Violation{{
+				Rule:    RuleScript,
+				Message: fmt.Sprintf("script with hash %s is not allowed", hash),
+			}}
+		}
+
+		var violations []Violation
+		for _, rule := range script.Arguments {
+			if err := checkArgument(tx, rule); err != nil {
+				violations = append(violations, Violation{
+					Rule:    RuleArgument,
+					Message: fmt.Sprintf("argument %d: %s", rule.Index, err),
+				})
+			}
+		}
+		return violations
+	})
+}
+
+func checkArgument(tx *flow.Transaction, rule ArgumentRule) error {
+	if rule.Index < 0 || rule.Index >= len(tx.Arguments) {
+		return fmt.Errorf("missing argument")
+	}
+
+	value, err := jsoncdc.Decode(nil, tx.Arguments[rule.Index])
+	if err != nil {
+		return fmt.Errorf("invalid argument: %w", err)
+	}
+
+	return rule.Check(value)
+}
+
+// MaxAmount returns a constraint of a UFix64 or Fix64 argument, which must not exceed the maximum.
+func MaxAmount(index int, max cadence.UFix64) ArgumentRule {
+	return ArgumentRule{
+		Index: index,
+		Check: func(value cadence.Value) error {
+			switch amount := value.(type) {
+			case cadence.UFix64:
+				if amount > max {
+					return fmt.Errorf("amount %s exceeds maximum %s", amount, max)
+				}
+			case cadence.Fix64:
+				if amount > 0 && uint64(amount) > uint64(max) {
+					return fmt.Errorf("amount %s exceeds maximum %s", amount, max)
+				}
+			default:
+				return fmt.Errorf("expected UFix64 or Fix64 amount, got %T", value)
+			}
+			return nil
+		},
+	}
+}
+
+// AllowedAddresses returns a constraint of an Address argument, which must be one of the addresses.
+func AllowedAddresses(index int, addresses ...flow.Address) ArgumentRule {
+	allowed := addressSet(addresses)
+
+	return ArgumentRule{
+		Index: index,
+		Check: func(value cadence.Value) error {
+			address, ok := value.(cadence.Address)
+			if !ok {
+				return fmt.Errorf("expected Address, got %T", value)
+			}
+			if !allowed[flow.Address(address)] {
+				return fmt.Errorf("address %s is not allowed", flow.Address(address).HexWithPrefix())
+			}
+			return nil
+		},
+	}
+}
+
+// AllowPayers returns a rule which only allows transactions paid by one of the addresses.
+func AllowPayers(addresses ...flow.Address) Rule {
+	allowed := addressSet(addresses)
+
+	return RuleFunc(func(tx *flow.Transaction) []Violation {
+		if allowed[tx.Payer] {
+			return nil
+		}
+		return []Violation{{
+			Rule:    RulePayer,
+			Message: fmt.Sprintf("payer %s is not allowed", tx.Payer.HexWithPrefix()),
+		}}
+	})
+}
+
+// AllowAuthorizers returns a rule which only allows transactions authorized by a subset of the addresses.
+func AllowAuthorizers(addresses ...flow.Address) Rule {
+	allowed := addressSet(addresses)
+
+	return RuleFunc(func(tx *flow.Transaction) []Violation {
+		var violations []Violation
+		for _, authorizer := range tx.Authorizers {
+			if !allowed[authorizer] {
+				violations = append(violations, Violation{
+					Rule:    RuleAuthorizer,
+					Message: fmt.Sprintf("authorizer %s is not allowed", authorizer.HexWithPrefix()),
+				})
+			}
+		}
+		return violations
+	})
+}
+
+// MaxComputeLimit returns a rule which only allows transactions with a compute limit of at most the limit.
+func MaxComputeLimit(limit uint64) Rule {
+	return RuleFunc(func(tx *flow.Transaction) []Violation {
+		if tx.GasLimit <= limit {
+			return nil
+		}
+		return []Violation{{
+			Rule:    RuleComputeLimit,
+			Message: fmt.Sprintf("compute limit %d exceeds maximum %d", tx.GasLimit, limit),
+		}}
+	})
+}
+
+func addressSet(addresses []flow.Address) map[flow.Address]bool {
+	set := make(map[flow.Address]bool, len(addresses))
+	for _, address := range addresses {
+		set[address] = true
+	}
+	return set
+}
@@ -0,0 +1,198 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package remote
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// Client is a client of a remote signing server.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient returns a client of the server with the base URL, e.g. https://signer.internal:8443,
+// connecting with the TLS configuration, see ClientTLSConfig.
+func NewClient(baseURL string, tlsConfig *tls.Config) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return NewClientWithHTTPClient(baseURL, &http.Client{Transport: transport})
+}
+
+// NewClientWithHTTPClient returns a client of the server with the base URL, using the HTTP client.
+// The HTTP client must present a client certificate.
+func NewClientWithHTTPClient(baseURL string, httpClient *http.Client) *Client {
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// Keys returns the keys hosted by the server.
+func (c *Client) Keys(ctx context.Context) ([]Key, error) {
+	var keys []Key
+	if err := c.do(ctx, http.MethodGet, "/v1/keys", nil, &keys); err != nil {
+		return nil, fmt.Errorf("remote: failed to list keys: %w", err)
+	}
+	return keys, nil
+}
+
+// GetPublicKey fetches the public key of a key hosted by the server.
+func (c *Client) GetPublicKey(ctx context.Context, id string) (crypto.PublicKey, error) {
+	var key Key
+	if err := c.do(ctx, http.MethodGet, "/v1/keys/"+url.PathEscape(id), nil, &key); err != nil {
+		return nil, fmt.Errorf("remote: failed to fetch public key: %w", err)
+	}
+
+	sigAlgo := crypto.StringToSignatureAlgorithm(key.SignatureAlgorithm)
+	publicKey, err := crypto.DecodePublicKeyHex(sigAlgo, key.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("remote: failed to decode public key: %w", err)
+	}
+
+	return publicKey, nil
+}
+
+// SignerForKey returns a new signer for a key hosted by the server.
+//
+// The hash algorithm is the one of the account key, and is used to verify the signatures of the server.
+func (c *Client) SignerForKey(ctx context.Context, id string, hashAlgo crypto.HashAlgorithm) (*Signer, error) {
+	publicKey, err := c.GetPublicKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !crypto.CompatibleAlgorithms(publicKey.Algorithm(), hashAlgo) {
+		return nil, fmt.Errorf("remote: unsupported hash algorithm %s for %s key", hashAlgo, publicKey.Algorithm())
+	}
+
+	return &Signer{
+		ctx:       ctx,
+		client:    c,
+		id:        id,
+		publicKey: publicKey,
+		hashAlgo:  hashAlgo,
+	}, nil
+}
+
+func (c *Client) sign(ctx context.Context, id string, message []byte) ([]byte, error) {
+	var res signResponse
+	err := c.do(ctx, http.MethodPost, "/v1/keys/"+url.PathEscape(id)+"/sign", signRequest{Message: message}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// do sends a request to the server and decodes the JSON response.
+func (c *Client) do(ctx context.Context, method string, path string, body any, result any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		var errRes errorResponse
+		_ = json.NewDecoder(res.Body).Decode(&errRes)
+
+		switch res.StatusCode {
+		case http.StatusNotFound:
+			return fmt.Errorf("%w: %s", ErrKeyNotFound, errRes.Error)
+		case http.StatusForbidden:
+			return fmt.Errorf("%w: %s", ErrPolicyViolation, errRes.Error)
+		case http.StatusUnauthorized:
+			return ErrUnauthenticated
+		default:
+			return fmt.Errorf("unexpected status %s: %s", res.Status, errRes.Error)
+		}
+	}
+
+	return json.NewDecoder(res.Body).Decode(result)
+}
+
+var _ crypto.Signer = (*Signer)(nil)
+
+// Signer is a crypto.Signer which forwards messages to a remote signing server.
+type Signer struct {
+	ctx       context.Context
+	client    *Client
+	id        string
+	publicKey crypto.PublicKey
+	// Hash algorithm of the account key
+	hashAlgo crypto.HashAlgorithm
+}
+
+// Sign requests the signature of the message from the server, and verifies it with the public key of the key.
+//
+// It returns an error wrapping ErrPolicyViolation if the policy of the server rejects the message,
+// and ErrInvalidSignature if the signature is not valid.
+func (s *Signer) Sign(message []byte) ([]byte, error) {
+	signature, err := s.client.sign(s.ctx, s.id, message)
+	if err != nil {
+		return nil, fmt.Errorf("remote: failed to sign: %w", err)
+	}
+
+	hasher, err := crypto.NewHasher(s.hashAlgo)
+	if err != nil {
+		return nil, fmt.Errorf("remote: failed to verify signature: %w", err)
+	}
+	valid, err := s.publicKey.Verify(signature, message, hasher)
+	if err != nil {
+		return nil, fmt.Errorf("remote: failed to verify signature: %w", err)
+	}
+	if !valid {
+		return nil, ErrInvalidSignature
+	}
+
+	return signature, nil
+}
+
+func (s *Signer) PublicKey() crypto.PublicKey {
+	return s.publicKey
+}
@@ -0,0 +1,154 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package remote provides a remote signing protocol, so applications can request signatures
+// without holding signing keys.
+//
+// A Server hosts crypto.Signers of any kind, e.g. in-memory, KMS or HSM signers, and a Client provides
+// crypto.Signers which forward messages to the server. The protocol is JSON over HTTPS, and both sides
+// authenticate each other with mutual TLS:
+//
+//	server := remote.NewServer(remote.WithPolicy(policy))
+//	server.AddSigner("payer", kmsSigner)
+//	httpServer := &http.Server{Handler: server, TLSConfig: remote.ServerTLSConfig(serverCert, clientCAs)}
+//
+//	client := remote.NewClient("https://signer.internal:8443", remote.ClientTLSConfig(clientCert, serverCAs))
+//	signer, err := client.SignerForKey(ctx, "payer", crypto.SHA3_256)
+//	err = tx.SignEnvelope(payer, 0, signer)
+//
+// The server checks every request with an optional Policy before signing. Transaction payloads and envelopes
+// are decoded, so policies can inspect the transaction being signed.
+//
+// Endpoints:
+//
+//	GET  /v1/keys            lists the keys of the server
+//	GET  /v1/keys/{id}       returns a key
+//	POST /v1/keys/{id}/sign  signs a message
+package remote
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto/policy"
+)
+
+var (
+	// ErrKeyNotFound is returned when the server has no signer for a key ID.
+	ErrKeyNotFound = errors.New("remote: key not found")
+	// ErrPolicyViolation is returned when the policy of the server rejects a request.
+	ErrPolicyViolation = errors.New("remote: signing request rejected by policy")
+	// ErrUnauthenticated is returned when a request has no verified client certificate.
+	ErrUnauthenticated = errors.New("remote: client certificate required")
+	// ErrInvalidSignature is returned when a signature of the server is not valid for the public key of the key.
+	ErrInvalidSignature = errors.New("remote: invalid signature")
+)
+
+// Key describes a key hosted by a server.
+type Key struct {
+	ID string `json:"id"`
+	// PublicKey is the hex encoded public key.
+	PublicKey          string `json:"publicKey"`
+	SignatureAlgorithm string `json:"signatureAlgorithm"`
+}
+
+type signRequest struct {
+	// Message is the message to sign, base64 encoded in JSON.
+	Message []byte `json:"message"`
+}
+
+type signResponse struct {
+	// Signature is the signature, base64 encoded in JSON.
+	Signature []byte `json:"signature"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Request is a signing request, as checked by a policy.
+type Request struct {
+	// KeyID is the ID of the key requested to sign.
+	KeyID string
+	// Message is the message to sign, including the domain tag.
+	Message []byte
+	// Transaction is the decoded transaction, if the message is a transaction payload or envelope
+	// prefixed with flow.TransactionDomainTag. It is nil for other messages.
+	Transaction *flow.Transaction
+	// Envelope is true if the message is a transaction envelope, and false if it is a transaction payload.
+	Envelope bool
+	// ClientCertificate is the verified certificate of the client.
+	ClientCertificate *x509.Certificate
+}
+
+// A Policy decides whether signing requests are allowed.
+type Policy interface {
+	// Check returns an error if the request must not be signed.
+	Check(ctx context.Context, request *Request) error
+}
+
+// PolicyFunc is a function implementing Policy.
+type PolicyFunc func(ctx context.Context, request *Request) error
+
+// Check calls the function.
+func (f PolicyFunc) Check(ctx context.Context, request *Request) error {
+	return f(ctx, request)
+}
+
+// TransactionsOnly is a policy which only allows signing transaction payloads and envelopes.
+var TransactionsOnly Policy = PolicyFunc(func(_ context.Context, request *Request) error {
+	if request.Transaction == nil {
+		return errors.New("message is not a transaction")
+	}
+	return nil
+})
+
+// TransactionPolicy returns a policy which only allows signing transaction payloads and envelopes
+// satisfying the transaction signing policy.
+func TransactionPolicy(p *policy.Policy) Policy {
+	return PolicyFunc(func(ctx context.Context, request *Request) error {
+		if err := TransactionsOnly.Check(ctx, request); err != nil {
+			return err
+		}
+		return p.Check(request.Transaction)
+	})
+}
+
+// ServerTLSConfig returns a TLS configuration for a server, which requires clients to present
+// a certificate signed by one of the client CAs.
+func ServerTLSConfig(certificate tls.Certificate, clientCAs *x509.CertPool) *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS13,
+	}
+}
+
+// ClientTLSConfig returns a TLS configuration for a client, which presents the client certificate,
+// and verifies the server certificate with the root CAs.
+func ClientTLSConfig(certificate tls.Certificate, rootCAs *x509.CertPool) *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		RootCAs:      rootCAs,
+		MinVersion:   tls.VersionTLS13,
+	}
+}
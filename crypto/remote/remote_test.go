@@ -0,0 +1,326 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package remote_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/policy"
+	"github.com/onflow/flow-go-sdk/crypto/remote"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+// testPKI is a CA issuing the server and client certificates of a test.
+type testPKI struct {
+	t    *testing.T
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pool *x509.CertPool
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	return &testPKI{t: t, cert: cert, key: key, pool: pool}
+}
+
+func (p *testPKI) issue(commonName string, usage x509.ExtKeyUsage) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(p.t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, p.cert, &key.PublicKey, p.key)
+	require.NoError(p.t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func newTestSigner(t *testing.T) crypto.Signer {
+	privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, make([]byte, crypto.MinSeedLength))
+	require.NoError(t, err)
+	signer, err := crypto.NewInMemorySigner(privateKey, crypto.SHA3_256)
+	require.NoError(t, err)
+	return signer
+}
+
+// mismatchedSigner signs with a signer, but returns another public key.
+type mismatchedSigner struct {
+	crypto.Signer
+	publicKey crypto.PublicKey
+}
+
+func (s mismatchedSigner) PublicKey() crypto.PublicKey {
+	return s.publicKey
+}
+
+func TestRemoteSigning(t *testing.T) {
+	pki := newTestPKI(t)
+	ctx := context.Background()
+
+	payer := flow.HexToAddress("01")
+	blocked := flow.HexToAddress("02")
+
+	var requests []*remote.Request
+	checkRequest := remote.PolicyFunc(func(_ context.Context, request *remote.Request) error {
+		requests = append(requests, request)
+		if request.Transaction == nil {
+			return nil
+		}
+		for _, authorizer := range request.Transaction.Authorizers {
+			if authorizer == blocked {
+				return errors.New("authorizer is blocked")
+			}
+		}
+		return nil
+	})
+
+	server := remote.NewServer(remote.WithPolicy(checkRequest))
+	localSigner := newTestSigner(t)
+	server.AddSigner("payer", localSigner)
+
+	ts := httptest.NewUnstartedServer(server)
+	// rejected TLS handshakes are expected
+	ts.Config.ErrorLog = log.New(io.Discard, "", 0)
+	ts.TLS = remote.ServerTLSConfig(pki.issue("server", x509.ExtKeyUsageServerAuth), pki.pool)
+	ts.StartTLS()
+	defer ts.Close()
+
+	client := remote.NewClient(ts.URL, remote.ClientTLSConfig(pki.issue("app", x509.ExtKeyUsageClientAuth), pki.pool))
+
+	t.Run("Keys", func(t *testing.T) {
+		keys, err := client.Keys(ctx)
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		assert.Equal(t, "payer", keys[0].ID)
+		assert.Equal(t, "ECDSA_P256", keys[0].SignatureAlgorithm)
+	})
+
+	signer, err := client.SignerForKey(ctx, "payer", crypto.SHA3_256)
+	require.NoError(t, err)
+	assert.True(t, localSigner.PublicKey().Equals(signer.PublicKey()))
+
+	t.Run("Sign transaction", func(t *testing.T) {
+		requests = nil
+
+		tx := test.TransactionGenerator().New()
+		tx.PayloadSignatures = nil
+		tx.EnvelopeSignatures = nil
+		tx.SetPayer(payer)
+		tx.Authorizers = []flow.Address{payer}
+		tx.SetProposalKey(payer, 0, 1)
+
+		require.NoError(t, tx.SignEnvelope(payer, 0, signer))
+
+		require.Len(t, requests, 1)
+		assert.Equal(t, "payer", requests[0].KeyID)
+		assert.True(t, requests[0].Envelope)
+		assert.Equal(t, "app", requests[0].ClientCertificate.Subject.CommonName)
+		require.NotNil(t, requests[0].Transaction)
+		assert.Equal(t, tx.PayloadMessage(), requests[0].Transaction.PayloadMessage())
+
+		message := append(flow.TransactionDomainTag[:], tx.EnvelopeMessage()...)
+		valid, err := signer.PublicKey().Verify(tx.EnvelopeSignatures[0].Signature, message, crypto.NewSHA3_256())
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		require.NoError(t, tx.SignPayload(payer, 0, signer))
+		assert.False(t, requests[1].Envelope)
+	})
+
+	t.Run("Policy violation", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetScript([]byte("transaction {}")).
+			SetPayer(payer).
+			AddAuthorizer(blocked)
+
+		err := tx.SignEnvelope(payer, 0, signer)
+		require.ErrorIs(t, err, remote.ErrPolicyViolation)
+		assert.ErrorContains(t, err, "authorizer is blocked")
+	})
+
+	t.Run("Non-canonical transaction", func(t *testing.T) {
+		message := append(flow.TransactionDomainTag[:], 0xc0, 0x80)
+		_, err := signer.Sign(message)
+		require.ErrorIs(t, err, remote.ErrPolicyViolation)
+	})
+
+	t.Run("Malformed envelope", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetScript([]byte("transaction {}")).
+			SetPayer(payer).
+			AddAuthorizer(payer)
+		tx.PayloadSignatures = []flow.TransactionSignature{{
+			Address:     payer,
+			SignerIndex: 7,
+			Signature:   []byte{1},
+		}}
+
+		_, err := signer.Sign(append(flow.TransactionDomainTag[:], tx.EnvelopeMessage()...))
+		require.ErrorIs(t, err, remote.ErrPolicyViolation)
+		assert.ErrorContains(t, err, "invalid signer index 7")
+	})
+
+	t.Run("User message", func(t *testing.T) {
+		requests = nil
+
+		signature, err := flow.SignUserMessage(signer, []byte("hello"))
+		require.NoError(t, err)
+
+		require.Len(t, requests, 1)
+		assert.Nil(t, requests[0].Transaction)
+
+		valid, err := signer.PublicKey().Verify(
+			signature,
+			append(flow.UserDomainTag[:], []byte("hello")...),
+			crypto.NewSHA3_256(),
+		)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("Unknown key", func(t *testing.T) {
+		_, err := client.SignerForKey(ctx, "unknown", crypto.SHA3_256)
+		require.ErrorIs(t, err, remote.ErrKeyNotFound)
+
+		server.RemoveSigner("payer")
+		defer server.AddSigner("payer", localSigner)
+
+		_, err = signer.Sign([]byte("message"))
+		require.ErrorIs(t, err, remote.ErrKeyNotFound)
+	})
+
+	t.Run("Invalid signature", func(t *testing.T) {
+		seed := make([]byte, crypto.MinSeedLength)
+		seed[0] = 1
+		otherKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, seed)
+		require.NoError(t, err)
+
+		server.AddSigner("mismatched", mismatchedSigner{Signer: localSigner, publicKey: otherKey.PublicKey()})
+		defer server.RemoveSigner("mismatched")
+
+		mismatched, err := client.SignerForKey(ctx, "mismatched", crypto.SHA3_256)
+		require.NoError(t, err)
+
+		_, err = mismatched.Sign([]byte("message"))
+		require.ErrorIs(t, err, remote.ErrInvalidSignature)
+
+		// the signature is only valid with the hash algorithm of the key
+		other, err := client.SignerForKey(ctx, "payer", crypto.SHA2_256)
+		require.NoError(t, err)
+		_, err = other.Sign([]byte("message"))
+		require.ErrorIs(t, err, remote.ErrInvalidSignature)
+
+		_, err = client.SignerForKey(ctx, "payer", crypto.SHA3_384)
+		require.Error(t, err)
+	})
+
+	t.Run("Client certificate required", func(t *testing.T) {
+		client := remote.NewClient(ts.URL, &tls.Config{RootCAs: pki.pool, MinVersion: tls.VersionTLS13})
+		_, err := client.Keys(ctx)
+		require.Error(t, err)
+	})
+
+	t.Run("Certificate of another CA", func(t *testing.T) {
+		other := newTestPKI(t)
+		client := remote.NewClient(ts.URL, remote.ClientTLSConfig(other.issue("app", x509.ExtKeyUsageClientAuth), pki.pool))
+		_, err := client.Keys(ctx)
+		require.Error(t, err)
+	})
+
+	t.Run("Plain HTTP", func(t *testing.T) {
+		plain := httptest.NewServer(server)
+		defer plain.Close()
+
+		client := remote.NewClientWithHTTPClient(plain.URL, http.DefaultClient)
+		_, err := client.Keys(ctx)
+		require.ErrorIs(t, err, remote.ErrUnauthenticated)
+	})
+}
+
+func TestTransactionsOnly(t *testing.T) {
+	ctx := context.Background()
+
+	err := remote.TransactionsOnly.Check(ctx, &remote.Request{Message: []byte("message")})
+	require.Error(t, err)
+
+	err = remote.TransactionsOnly.Check(ctx, &remote.Request{Transaction: flow.NewTransaction()})
+	require.NoError(t, err)
+}
+
+func TestTransactionPolicy(t *testing.T) {
+	ctx := context.Background()
+	payer := flow.HexToAddress("01")
+
+	p := remote.TransactionPolicy(policy.New(policy.AllowPayers(payer)))
+
+	err := p.Check(ctx, &remote.Request{Message: []byte("message")})
+	require.Error(t, err)
+
+	err = p.Check(ctx, &remote.Request{Transaction: flow.NewTransaction().SetPayer(flow.HexToAddress("02"))})
+	require.ErrorIs(t, err, policy.ErrPolicyViolation)
+
+	err = p.Check(ctx, &remote.Request{Transaction: flow.NewTransaction().SetPayer(payer)})
+	require.NoError(t, err)
+}
@@ -0,0 +1,209 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package remote
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/policy"
+)
+
+// maxMessageSize is the maximum size of a sign request body.
+const maxMessageSize = 4 << 20
+
+var _ http.Handler = (*Server)(nil)
+
+// Server hosts signers and signs messages requested by clients. It implements http.Handler.
+//
+// Requests are only served over TLS connections with a verified client certificate,
+// see ServerTLSConfig.
+type Server struct {
+	mu      sync.RWMutex
+	signers map[string]crypto.Signer
+	policy  Policy
+	mux     *http.ServeMux
+}
+
+// A ServerOption configures a Server.
+type ServerOption func(*Server)
+
+// WithPolicy sets the policy checking signing requests before signing.
+func WithPolicy(p Policy) ServerOption {
+	return func(s *Server) {
+		s.policy = p
+	}
+}
+
+// NewServer returns a server without signers.
+func NewServer(opts ...ServerOption) *Server {
+	s := &Server{
+		signers: make(map[string]crypto.Signer),
+		mux:     http.NewServeMux(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.mux.HandleFunc("GET /v1/keys", s.handleKeys)
+	s.mux.HandleFunc("GET /v1/keys/{id}", s.handleKey)
+	s.mux.HandleFunc("POST /v1/keys/{id}/sign", s.handleSign)
+
+	return s
+}
+
+// AddSigner hosts the signer with the key ID, replacing any signer with the same ID.
+func (s *Server) AddSigner(id string, signer crypto.Signer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.signers[id] = signer
+}
+
+// RemoveSigner removes the signer with the key ID.
+func (s *Server) RemoveSigner(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.signers, id)
+}
+
+// ServeHTTP serves a request of the remote signing protocol.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+		writeError(w, http.StatusUnauthorized, ErrUnauthenticated)
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) signer(id string) (crypto.Signer, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	signer, ok := s.signers[id]
+	return signer, ok
+}
+
+func (s *Server) handleKeys(w http.ResponseWriter, _ *http.Request) {
+	s.mu.RLock()
+	keys := make([]Key, 0, len(s.signers))
+	for id, signer := range s.signers {
+		keys = append(keys, newKey(id, signer))
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].ID < keys[j].ID
+	})
+
+	writeJSON(w, http.StatusOK, keys)
+}
+
+func (s *Server) handleKey(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	signer, ok := s.signer(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("%w: %s", ErrKeyNotFound, id))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newKey(id, signer))
+}
+
+func (s *Server) handleSign(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	signer, ok := s.signer(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("%w: %s", ErrKeyNotFound, id))
+		return
+	}
+
+	var req signRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxMessageSize)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid sign request: %w", err))
+		return
+	}
+
+	if s.policy != nil {
+		request, err := newRequest(id, req.Message, r)
+		if err != nil {
+			writeError(w, http.StatusForbidden, err)
+			return
+		}
+		if err := s.policy.Check(r.Context(), request); err != nil {
+			writeError(w, http.StatusForbidden, err)
+			return
+		}
+	}
+
+	signature, err := signer.Sign(req.Message)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to sign: %w", err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, signResponse{Signature: signature})
+}
+
+// newRequest returns the policy request of a message, with the transaction decoded.
+func newRequest(id string, message []byte, r *http.Request) (*Request, error) {
+	request := &Request{
+		KeyID:             id,
+		Message:           message,
+		ClientCertificate: r.TLS.VerifiedChains[0][0],
+	}
+
+	if !bytes.HasPrefix(message, flow.TransactionDomainTag[:]) {
+		return request, nil
+	}
+
+	tx, envelope, err := policy.DecodeTransactionMessage(message)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Transaction = tx
+	request.Envelope = envelope
+	return request, nil
+}
+
+func newKey(id string, signer crypto.Signer) Key {
+	publicKey := signer.PublicKey()
+	return Key{
+		ID:                 id,
+		PublicKey:          hex.EncodeToString(publicKey.Encode()),
+		SignatureAlgorithm: publicKey.Algorithm().String(),
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
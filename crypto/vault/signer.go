@@ -0,0 +1,92 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vault
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/internal"
+)
+
+var _ crypto.Signer = (*Signer)(nil)
+
+// Signer is a Vault Transit implementation of crypto.Signer.
+type Signer struct {
+	ctx    context.Context
+	client *Client
+	key    Key
+	// ECDSA is the only algorithm supported by this package. The signature algorithm
+	// therefore represents the elliptic curve used. The curve is needed to parse the signature.
+	curve crypto.SignatureAlgorithm
+	// public key for easier access
+	publicKey crypto.PublicKey
+	// Hash algorithm associated to the Transit signing key
+	hashAlgo crypto.HashAlgorithm
+}
+
+// SignerForKey returns a new Vault Transit signer for a key version.
+//
+// If the key has no version, the signer uses the version which is latest when the signer is created.
+// Only ECDSA keys on the P-256 curve and SHA2-256 are supported.
+func (c *Client) SignerForKey(
+	ctx context.Context,
+	key Key,
+) (*Signer, error) {
+	key, pk, hashAlgo, err := c.getKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Signer{
+		ctx:       ctx,
+		client:    c,
+		key:       key,
+		curve:     pk.Algorithm(),
+		publicKey: pk,
+		hashAlgo:  hashAlgo,
+	}, nil
+}
+
+// Sign signs the given message using the Transit signing key for this signer.
+//
+// The message is hashed outside Vault, and the digest is signed as prehashed input.
+func (s *Signer) Sign(message []byte) ([]byte, error) {
+	hasher, err := crypto.NewHasher(s.hashAlgo)
+	if err != nil {
+		return nil, fmt.Errorf("vault: failed to sign: %w", err)
+	}
+	digest := hasher.ComputeHash(message)
+
+	signature, err := s.client.sign(s.ctx, s.key, hashAlgorithmName(s.hashAlgo), digest)
+	if err != nil {
+		return nil, fmt.Errorf("vault: failed to sign: %w", err)
+	}
+
+	sig, err := internal.ParseECDSASignature(signature, s.curve)
+	if err != nil {
+		return nil, fmt.Errorf("vault: failed to parse signature: %w", err)
+	}
+	return sig, nil
+}
+
+func (s *Signer) PublicKey() crypto.PublicKey {
+	return s.publicKey
+}
@@ -0,0 +1,303 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package vault provides a HashiCorp Vault Transit secrets engine
+// implementation of the crypto.Signer interface.
+//
+// The client uses the Vault HTTP API directly, authenticated with a Vault token.
+//
+// The documentation for the Transit secrets engine can be found here:
+// https://developer.hashicorp.com/vault/docs/secrets/transit
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// DefaultMount is the default mount path of the Transit secrets engine.
+const DefaultMount = "transit"
+
+// Key is a reference to a Transit key.
+//
+// If the version is 0, the latest version of the key is used.
+type Key struct {
+	Mount   string `json:"mount"`
+	Name    string `json:"name"`
+	Version int    `json:"version"`
+}
+
+// Path returns the API path of this key, relative to /v1/.
+//
+// Example path format: "transit/keys/my-key"
+func (k Key) Path() string {
+	return k.mount() + "/keys/" + k.Name
+}
+
+func (k Key) mount() string {
+	if k.Mount == "" {
+		return DefaultMount
+	}
+	return strings.Trim(k.Mount, "/")
+}
+
+// KeyFromPath returns a `Key` from a key path, optionally followed by a version.
+//
+// Example path formats: "transit/keys/my-key", "/v1/transit/keys/my-key", "transit/keys/my-key:2"
+func KeyFromPath(path string) (Key, error) {
+	trimmed := strings.TrimPrefix(strings.Trim(path, "/"), "v1/")
+
+	index := strings.LastIndex(trimmed, "/keys/")
+	if index <= 0 {
+		return Key{}, fmt.Errorf("vault: wrong format for the key path: %s", path)
+	}
+
+	key := Key{
+		Mount: trimmed[:index],
+		Name:  trimmed[index+len("/keys/"):],
+	}
+
+	if name, version, ok := strings.Cut(key.Name, ":"); ok {
+		v, err := strconv.Atoi(version)
+		if err != nil || v < 1 {
+			return Key{}, fmt.Errorf("vault: wrong format for the key version: %s", path)
+		}
+		key.Name, key.Version = name, v
+	}
+
+	if key.Name == "" || strings.Contains(key.Name, "/") {
+		return Key{}, fmt.Errorf("vault: wrong format for the key path: %s", path)
+	}
+
+	return key, nil
+}
+
+// Client is a client for interacting with the Vault Transit API
+// using types native to the Flow Go SDK.
+type Client struct {
+	address    string
+	token      string
+	namespace  string
+	httpClient *http.Client
+}
+
+// An Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithNamespace sets the Vault Enterprise namespace of requests.
+func WithNamespace(namespace string) Option {
+	return func(c *Client) {
+		c.namespace = namespace
+	}
+}
+
+// NewClient creates a new Vault client for the server address, e.g. https://vault.example.com:8200,
+// authenticated with the token.
+func NewClient(address string, token string, opts ...Option) *Client {
+	c := &Client{
+		address:    strings.TrimSuffix(address, "/"),
+		token:      token,
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// GetPublicKey fetches the public key of a version of a Transit key.
+//
+// Transit keys of the type `ecdsa-p256` are the only keys supported by the SDK.
+//
+// Ref: https://developer.hashicorp.com/vault/api-docs/secret/transit#read-key
+func (c *Client) GetPublicKey(ctx context.Context, key Key) (crypto.PublicKey, crypto.HashAlgorithm, error) {
+	_, publicKey, hashAlgo, err := c.getKey(ctx, key)
+	return publicKey, hashAlgo, err
+}
+
+// getKey fetches a Transit key and returns it with the version resolved.
+func (c *Client) getKey(ctx context.Context, key Key) (Key, crypto.PublicKey, crypto.HashAlgorithm, error) {
+	var result struct {
+		Data struct {
+			Type          string `json:"type"`
+			LatestVersion int    `json:"latest_version"`
+			Keys          map[string]struct {
+				PublicKey string `json:"public_key"`
+			} `json:"keys"`
+		} `json:"data"`
+	}
+	err := c.do(ctx, http.MethodGet, key.Path(), nil, &result)
+	if err != nil {
+		return Key{}, nil,
+			crypto.UnknownHashAlgorithm,
+			fmt.Errorf("vault: failed to fetch public key from Vault API: %w", err)
+	}
+
+	sigAlgo := parseSignatureAlgorithm(result.Data.Type)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return Key{}, nil,
+			crypto.UnknownHashAlgorithm,
+			fmt.Errorf("vault: unsupported signature algorithm %s", result.Data.Type)
+	}
+
+	resolved := key
+	if resolved.Version == 0 {
+		resolved.Version = result.Data.LatestVersion
+	}
+
+	version, ok := result.Data.Keys[strconv.Itoa(resolved.Version)]
+	if !ok {
+		return Key{}, nil,
+			crypto.UnknownHashAlgorithm,
+			fmt.Errorf("vault: key %s has no version %d", key.Path(), resolved.Version)
+	}
+
+	publicKey, err := crypto.DecodePublicKeyPEM(sigAlgo, version.PublicKey)
+	if err != nil {
+		return Key{}, nil,
+			crypto.UnknownHashAlgorithm,
+			fmt.Errorf("vault: failed to parse PEM public key: %w", err)
+	}
+
+	return resolved, publicKey, parseHashAlgorithm(result.Data.Type), nil
+}
+
+// sign signs a digest with the key.
+//
+// Ref: https://developer.hashicorp.com/vault/api-docs/secret/transit#sign-data
+func (c *Client) sign(ctx context.Context, key Key, hashAlgorithm string, digest []byte) ([]byte, error) {
+	request := struct {
+		Input               string `json:"input"`
+		KeyVersion          int    `json:"key_version,omitempty"`
+		HashAlgorithm       string `json:"hash_algorithm"`
+		Prehashed           bool   `json:"prehashed"`
+		MarshalingAlgorithm string `json:"marshaling_algorithm"`
+	}{
+		Input:               base64.StdEncoding.EncodeToString(digest),
+		KeyVersion:          key.Version,
+		HashAlgorithm:       hashAlgorithm,
+		Prehashed:           true,
+		MarshalingAlgorithm: "asn1",
+	}
+
+	var result struct {
+		Data struct {
+			Signature string `json:"signature"`
+		} `json:"data"`
+	}
+	if err := c.do(ctx, http.MethodPost, key.mount()+"/sign/"+key.Name, request, &result); err != nil {
+		return nil, err
+	}
+
+	// signatures have the format vault:v<version>:<base64 signature>
+	parts := strings.Split(result.Data.Signature, ":")
+	if len(parts) != 3 || parts[0] != "vault" {
+		return nil, fmt.Errorf("unexpected signature format %q", result.Data.Signature)
+	}
+
+	return base64.StdEncoding.DecodeString(parts[2])
+}
+
+// do sends an authenticated request to the Vault API and decodes the JSON response.
+func (c *Client) do(ctx context.Context, method string, path string, body any, result any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.address+"/v1/"+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", c.token)
+	req.Header.Set("X-Vault-Request", "true")
+	if c.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		var errorResponse struct {
+			Errors []string `json:"errors"`
+		}
+		if json.Unmarshal(data, &errorResponse) == nil && len(errorResponse.Errors) > 0 {
+			return fmt.Errorf("%s: %s", res.Status, strings.Join(errorResponse.Errors, "; "))
+		}
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
+
+	return json.Unmarshal(data, result)
+}
+
+// parseSignatureAlgorithm returns the `SignatureAlgorithm` corresponding to the input Transit key type.
+func parseSignatureAlgorithm(keyType string) crypto.SignatureAlgorithm {
+	// Transit does not support secp256k1 keys
+	if keyType == "ecdsa-p256" {
+		return crypto.ECDSA_P256
+	}
+	return crypto.UnknownSignatureAlgorithm
+}
+
+// parseHashAlgorithm returns the `HashAlgorithm` corresponding to the input Transit key type.
+func parseHashAlgorithm(keyType string) crypto.HashAlgorithm {
+	if keyType == "ecdsa-p256" {
+		return crypto.SHA2_256
+	}
+	return crypto.UnknownHashAlgorithm
+}
+
+// hashAlgorithmName returns the Transit name of the hash algorithm.
+func hashAlgorithmName(hashAlgo crypto.HashAlgorithm) string {
+	if hashAlgo == crypto.SHA3_256 {
+		return "sha3-256"
+	}
+	return "sha2-256"
+}
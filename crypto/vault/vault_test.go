@@ -0,0 +1,244 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vault_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/vault"
+)
+
+const testToken = "test-token"
+
+func TestKeyFromPath(t *testing.T) {
+	key := vault.Key{Mount: "transit", Name: "my-key"}
+	assert.Equal(t, "transit/keys/my-key", key.Path())
+	assert.Equal(t, "transit/keys/my-key", vault.Key{Name: "my-key"}.Path())
+
+	tests := map[string]vault.Key{
+		"transit/keys/my-key":            key,
+		"/v1/transit/keys/my-key":        key,
+		"transit/keys/my-key:2":          {Mount: "transit", Name: "my-key", Version: 2},
+		"team/flow/transit/keys/my-key/": {Mount: "team/flow/transit", Name: "my-key"},
+	}
+	for path, expected := range tests {
+		keyFromPath, err := vault.KeyFromPath(path)
+		require.NoError(t, err, path)
+		assert.Equal(t, expected, keyFromPath, path)
+	}
+
+	for _, invalid := range []string{"my-key", "transit/keys/", "transit/keys/my-key:0", "transit/keys/my-key:x", "transit/keys/a/b"} {
+		_, err := vault.KeyFromPath(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+// testVault is a stand-in for the Vault Transit API, with a P-256 key with two versions.
+type testVault struct {
+	versions []*ecdsa.PrivateKey
+}
+
+func newTestVault(t *testing.T) *httptest.Server {
+	v := &testVault{}
+	for i := 0; i < 2; i++ {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		v.versions = append(v.versions, key)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/transit/keys/{name}", v.readKey)
+	mux.HandleFunc("POST /v1/transit/sign/{name}", v.sign)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != testToken {
+			writeErrors(w, http.StatusForbidden, "permission denied")
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func (v *testVault) readKey(w http.ResponseWriter, r *http.Request) {
+	keyType := "ecdsa-p256"
+	switch r.PathValue("name") {
+	case "flow":
+	case "ed25519":
+		keyType = "ed25519"
+	default:
+		writeErrors(w, http.StatusNotFound)
+		return
+	}
+
+	keys := make(map[string]any, len(v.versions))
+	for i, key := range v.versions {
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		if err != nil {
+			writeErrors(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		keys[strconv.Itoa(i+1)] = map[string]string{
+			"public_key": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+		}
+	}
+
+	writeJSON(w, map[string]any{
+		"data": map[string]any{
+			"name":           r.PathValue("name"),
+			"type":           keyType,
+			"latest_version": len(v.versions),
+			"keys":           keys,
+		},
+	})
+}
+
+func (v *testVault) sign(w http.ResponseWriter, r *http.Request) {
+	if r.PathValue("name") != "flow" {
+		writeErrors(w, http.StatusBadRequest, "encryption key not found")
+		return
+	}
+
+	var request struct {
+		Input               string `json:"input"`
+		KeyVersion          int    `json:"key_version"`
+		HashAlgorithm       string `json:"hash_algorithm"`
+		Prehashed           bool   `json:"prehashed"`
+		MarshalingAlgorithm string `json:"marshaling_algorithm"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeErrors(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !request.Prehashed || request.HashAlgorithm != "sha2-256" || request.MarshalingAlgorithm != "asn1" {
+		writeErrors(w, http.StatusBadRequest, "unexpected signing parameters")
+		return
+	}
+
+	version := request.KeyVersion
+	if version == 0 {
+		version = len(v.versions)
+	}
+	if version > len(v.versions) {
+		writeErrors(w, http.StatusBadRequest, "invalid key version")
+		return
+	}
+
+	digest, err := base64.StdEncoding.DecodeString(request.Input)
+	if err != nil || len(digest) != 32 {
+		writeErrors(w, http.StatusBadRequest, "invalid input")
+		return
+	}
+
+	signature, err := ecdsa.SignASN1(rand.Reader, v.versions[version-1], digest)
+	if err != nil {
+		writeErrors(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, map[string]any{
+		"data": map[string]any{
+			"signature":   fmt.Sprintf("vault:v%d:%s", version, base64.StdEncoding.EncodeToString(signature)),
+			"key_version": version,
+		},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeErrors(w http.ResponseWriter, status int, errors ...string) {
+	w.WriteHeader(status)
+	writeJSON(w, map[string]any{"errors": append([]string{}, errors...)})
+}
+
+func TestSigning(t *testing.T) {
+	server := newTestVault(t)
+	ctx := context.Background()
+
+	client := vault.NewClient(server.URL, testToken, vault.WithHTTPClient(server.Client()))
+
+	for _, version := range []int{0, 1, 2} {
+		t.Run(fmt.Sprintf("Version %d", version), func(t *testing.T) {
+			key := vault.Key{Name: "flow", Version: version}
+
+			pk, hashAlgo, err := client.GetPublicKey(ctx, key)
+			require.NoError(t, err)
+			assert.Equal(t, crypto.ECDSA_P256, pk.Algorithm())
+			assert.Equal(t, crypto.SHA2_256, hashAlgo)
+
+			signer, err := client.SignerForKey(ctx, key)
+			require.NoError(t, err)
+			assert.True(t, pk.Equals(signer.PublicKey()))
+
+			for _, length := range []int{0, 32, 5000} {
+				message := make([]byte, length)
+				signature, err := signer.Sign(message)
+				require.NoError(t, err)
+				require.Len(t, signature, 64)
+
+				valid, err := pk.Verify(signature, message, crypto.NewSHA2_256())
+				require.NoError(t, err)
+				assert.True(t, valid)
+			}
+		})
+	}
+
+	t.Run("Versions have different keys", func(t *testing.T) {
+		first, _, err := client.GetPublicKey(ctx, vault.Key{Name: "flow", Version: 1})
+		require.NoError(t, err)
+		second, _, err := client.GetPublicKey(ctx, vault.Key{Name: "flow", Version: 2})
+		require.NoError(t, err)
+		assert.False(t, first.Equals(second))
+
+		_, _, err = client.GetPublicKey(ctx, vault.Key{Name: "flow", Version: 3})
+		require.Error(t, err)
+	})
+
+	t.Run("Unsupported key type", func(t *testing.T) {
+		_, err := client.SignerForKey(ctx, vault.Key{Name: "ed25519"})
+		require.ErrorContains(t, err, "unsupported signature algorithm ed25519")
+	})
+
+	t.Run("Permission denied", func(t *testing.T) {
+		client := vault.NewClient(server.URL, "invalid", vault.WithHTTPClient(server.Client()))
+		_, _, err := client.GetPublicKey(ctx, vault.Key{Name: "flow"})
+		require.ErrorContains(t, err, "permission denied")
+	})
+}
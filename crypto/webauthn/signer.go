@@ -0,0 +1,122 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webauthn
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/internal"
+)
+
+// An Authenticator returns assertions for challenges, e.g. a passkey accessed through a browser
+// or a platform API.
+type Authenticator interface {
+	GetAssertion(ctx context.Context, challenge []byte) (*Assertion, error)
+}
+
+// AuthenticatorFunc is a function implementing Authenticator.
+type AuthenticatorFunc func(ctx context.Context, challenge []byte) (*Assertion, error)
+
+// GetAssertion calls the function.
+func (f AuthenticatorFunc) GetAssertion(ctx context.Context, challenge []byte) (*Assertion, error) {
+	return f(ctx, challenge)
+}
+
+// Signer signs transactions with an authenticator.
+//
+// Signer does not implement crypto.Signer, since WebAuthn signatures must be added to transactions
+// together with their extension data. Use SignPayload and SignEnvelope instead of the methods of
+// flow.Transaction.
+type Signer struct {
+	ctx           context.Context
+	authenticator Authenticator
+	publicKey     crypto.PublicKey
+}
+
+// NewSigner returns a signer for an authenticator with an ECDSA_P256 public key.
+//
+// The public key is used to check the signatures of the authenticator before they are added to transactions.
+func NewSigner(ctx context.Context, authenticator Authenticator, publicKey crypto.PublicKey) (*Signer, error) {
+	if publicKey.Algorithm() != crypto.ECDSA_P256 {
+		return nil, fmt.Errorf("webauthn: unsupported signature algorithm %s", publicKey.Algorithm())
+	}
+
+	return &Signer{
+		ctx:           ctx,
+		authenticator: authenticator,
+		publicKey:     publicKey,
+	}, nil
+}
+
+// PublicKey returns the public key of the authenticator.
+func (s *Signer) PublicKey() crypto.PublicKey {
+	return s.publicKey
+}
+
+// Sign signs a transaction message, i.e. a transaction payload or envelope without domain tag, and returns
+// the signature and its extension data.
+func (s *Signer) Sign(message []byte) (signature []byte, extensionData []byte, err error) {
+	assertion, err := s.authenticator.GetAssertion(s.ctx, Challenge(message))
+	if err != nil {
+		return nil, nil, fmt.Errorf("webauthn: failed to get assertion: %w", err)
+	}
+
+	signature, err = internal.ParseECDSASignature(assertion.Signature, crypto.ECDSA_P256)
+	if err != nil {
+		return nil, nil, fmt.Errorf("webauthn: invalid assertion signature: %w", err)
+	}
+
+	extensionData, err = ExtensionData(assertion.AuthenticatorData, assertion.ClientDataJSON)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := Verify(s.publicKey, message, signature, extensionData); err != nil {
+		return nil, nil, err
+	}
+
+	return signature, extensionData, nil
+}
+
+// SignPayload signs the transaction payload with the specified account key, and adds the signature
+// with its extension data to the transaction.
+func (s *Signer) SignPayload(tx *flow.Transaction, address flow.Address, keyIndex uint32) error {
+	signature, extensionData, err := s.Sign(tx.PayloadMessage())
+	if err != nil {
+		return err
+	}
+
+	tx.AddPayloadSignatureWithExtensionData(address, keyIndex, signature, extensionData)
+	return nil
+}
+
+// SignEnvelope signs the transaction envelope with the specified account key, and adds the signature
+// with its extension data to the transaction.
+func (s *Signer) SignEnvelope(tx *flow.Transaction, address flow.Address, keyIndex uint32) error {
+	signature, extensionData, err := s.Sign(tx.EnvelopeMessage())
+	if err != nil {
+		return err
+	}
+
+	tx.AddEnvelopeSignatureWithExtensionData(address, keyIndex, signature, extensionData)
+	return nil
+}
@@ -0,0 +1,148 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package webauthn signs and verifies Flow transactions with WebAuthn authenticators, e.g. passkeys (FLIP 264).
+//
+// An authenticator does not sign the transaction message directly. It signs its authenticator data followed by
+// the SHA2-256 hash of the client data JSON, and the client data contains a challenge, which must be the SHA2-256
+// hash of the transaction message. The authenticator data and the client data are attached to the transaction
+// signature as extension data, so the network can reconstruct the signed data.
+//
+// Account keys of authenticators must use ECDSA_P256 and SHA2_256.
+package webauthn
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/internal/webauthn"
+)
+
+// SchemeIdentifier is the first byte of the extension data of WebAuthn signatures.
+const SchemeIdentifier = webauthn.SchemeIdentifier
+
+// TypeGet is the client data type of assertions.
+const TypeGet = webauthn.TypeGet
+
+// ErrInvalidAssertion is returned when an assertion does not match the transaction message.
+var ErrInvalidAssertion = errors.New("webauthn: invalid assertion")
+
+// Assertion is the response of an authenticator to a request for a signature.
+type Assertion struct {
+	AuthenticatorData []byte
+	ClientDataJSON    []byte
+	// Signature is the ASN.1 DER encoded ECDSA signature of the authenticator.
+	Signature []byte
+}
+
+// ClientData is the client data of an assertion.
+type ClientData = webauthn.ClientData
+
+// Challenge returns the challenge of an assertion signing a transaction message,
+// i.e. the SHA2-256 hash of the message prefixed with flow.TransactionDomainTag.
+func Challenge(message []byte) []byte {
+	return crypto.NewSHA2_256().ComputeHash(append(flow.TransactionDomainTag[:], message...))
+}
+
+// ExtensionData encodes the authenticator data and client data JSON of an assertion as
+// transaction signature extension data.
+func ExtensionData(authenticatorData []byte, clientDataJSON []byte) ([]byte, error) {
+	data, err := webauthn.EncodeExtensionData(authenticatorData, clientDataJSON)
+	if err != nil {
+		return nil, fmt.Errorf("webauthn: %w", err)
+	}
+	return data, nil
+}
+
+// ParseExtensionData decodes transaction signature extension data into the authenticator data and client data JSON.
+func ParseExtensionData(data []byte) (authenticatorData []byte, clientDataJSON []byte, err error) {
+	authenticatorData, clientDataJSON, err = webauthn.ParseExtensionData(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidAssertion, err)
+	}
+	return authenticatorData, clientDataJSON, nil
+}
+
+// SignedData checks that the assertion data signs the transaction message, and returns the data signed
+// by the authenticator: the authenticator data followed by the SHA2-256 hash of the client data JSON.
+//
+// The client data must be of type TypeGet, its challenge must be the Challenge of the message, and the
+// authenticator data must have the user presence flag set.
+func SignedData(authenticatorData []byte, clientDataJSON []byte, message []byte) ([]byte, error) {
+	signed, err := webauthn.SignedData(authenticatorData, clientDataJSON, append(flow.TransactionDomainTag[:], message...))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAssertion, err)
+	}
+	return signed, nil
+}
+
+// Verify verifies a WebAuthn signature of a transaction message, i.e. a transaction payload or envelope,
+// with its extension data.
+//
+// The signature is the raw R||S signature, as included in transactions.
+func Verify(publicKey crypto.PublicKey, message []byte, signature []byte, extensionData []byte) error {
+	if publicKey.Algorithm() != crypto.ECDSA_P256 {
+		return fmt.Errorf("webauthn: unsupported signature algorithm %s", publicKey.Algorithm())
+	}
+
+	authenticatorData, clientDataJSON, err := ParseExtensionData(extensionData)
+	if err != nil {
+		return err
+	}
+
+	signed, err := SignedData(authenticatorData, clientDataJSON, message)
+	if err != nil {
+		return err
+	}
+
+	valid, err := publicKey.Verify(signature, signed, crypto.NewSHA2_256())
+	if err != nil {
+		return fmt.Errorf("webauthn: failed to verify signature: %w", err)
+	}
+	if !valid {
+		return flow.ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// VerifyTransactionSignature verifies a WebAuthn payload or envelope signature of a transaction.
+//
+// The signature is found by the address and key index of the signer.
+func VerifyTransactionSignature(
+	tx *flow.Transaction,
+	address flow.Address,
+	keyIndex uint32,
+	publicKey crypto.PublicKey,
+	envelope bool,
+) error {
+	signatures, message := tx.PayloadSignatures, tx.PayloadMessage()
+	if envelope {
+		signatures, message = tx.EnvelopeSignatures, tx.EnvelopeMessage()
+	}
+
+	for _, sig := range signatures {
+		if sig.Address == address && sig.KeyIndex == keyIndex {
+			return Verify(publicKey, message, sig.Signature, sig.ExtensionData)
+		}
+	}
+
+	return fmt.Errorf("webauthn: no signature of %s key %d", address, keyIndex)
+}
@@ -0,0 +1,192 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webauthn_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/webauthn"
+)
+
+// testAuthenticator is a software authenticator with a P-256 key.
+type testAuthenticator struct {
+	key   *ecdsa.PrivateKey
+	flags byte
+	// clientDataType overrides the client data type if not empty.
+	clientDataType string
+}
+
+func newTestAuthenticator(t *testing.T) *testAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return &testAuthenticator{key: key, flags: 0x05}
+}
+
+func (a *testAuthenticator) publicKey(t *testing.T) crypto.PublicKey {
+	encoded := make([]byte, 64)
+	a.key.PublicKey.X.FillBytes(encoded[:32])
+	a.key.PublicKey.Y.FillBytes(encoded[32:])
+	publicKey, err := crypto.DecodePublicKey(crypto.ECDSA_P256, encoded)
+	require.NoError(t, err)
+	return publicKey
+}
+
+func (a *testAuthenticator) GetAssertion(_ context.Context, challenge []byte) (*webauthn.Assertion, error) {
+	rpIDHash := sha256.Sum256([]byte("wallet.example"))
+	authenticatorData := append(rpIDHash[:], a.flags, 0, 0, 0, 1)
+
+	clientDataType := webauthn.TypeGet
+	if a.clientDataType != "" {
+		clientDataType = a.clientDataType
+	}
+	clientDataJSON, err := json.Marshal(webauthn.ClientData{
+		Type:      clientDataType,
+		Challenge: base64.RawURLEncoding.EncodeToString(challenge),
+		Origin:    "https://wallet.example",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(append([]byte{}, authenticatorData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &webauthn.Assertion{
+		AuthenticatorData: authenticatorData,
+		ClientDataJSON:    clientDataJSON,
+		Signature:         signature,
+	}, nil
+}
+
+func newTransaction(address flow.Address) *flow.Transaction {
+	return flow.NewTransaction().
+		SetScript([]byte(`transaction { prepare(signer: &Account) {} }`)).
+		SetReferenceBlockID(flow.Identifier{0x01}).
+		SetProposalKey(address, 0, 1).
+		SetPayer(address).
+		AddAuthorizer(address)
+}
+
+func TestSigner(t *testing.T) {
+	ctx := context.Background()
+	address := flow.HexToAddress("01")
+
+	authenticator := newTestAuthenticator(t)
+	publicKey := authenticator.publicKey(t)
+
+	signer, err := webauthn.NewSigner(ctx, authenticator, publicKey)
+	require.NoError(t, err)
+
+	tx := newTransaction(address)
+	require.NoError(t, signer.SignEnvelope(tx, address, 0))
+
+	require.Len(t, tx.EnvelopeSignatures, 1)
+	assert.Equal(t, webauthn.SchemeIdentifier, tx.EnvelopeSignatures[0].ExtensionData[0])
+
+	t.Run("Offline verification", func(t *testing.T) {
+		require.NoError(t, webauthn.VerifyTransactionSignature(tx, address, 0, publicKey, true))
+
+		err := webauthn.VerifyTransactionSignature(tx, address, 0, publicKey, false)
+		assert.Error(t, err)
+
+		other := newTestAuthenticator(t).publicKey(t)
+		err = webauthn.VerifyTransactionSignature(tx, address, 0, other, true)
+		assert.ErrorIs(t, err, flow.ErrInvalidSignature)
+	})
+
+	t.Run("Transaction verification", func(t *testing.T) {
+		report, err := flow.VerifyTransactionSignatures(tx, map[flow.Address][]*flow.AccountKey{
+			address: {{
+				Index:     0,
+				PublicKey: publicKey,
+				SigAlgo:   crypto.ECDSA_P256,
+				HashAlgo:  crypto.SHA2_256,
+				Weight:    flow.AccountKeyWeightThreshold,
+			}},
+		})
+		require.NoError(t, err)
+		assert.NoError(t, report.Err())
+	})
+
+	t.Run("Extension data round trip", func(t *testing.T) {
+		authenticatorData, clientDataJSON, err := webauthn.ParseExtensionData(tx.EnvelopeSignatures[0].ExtensionData)
+		require.NoError(t, err)
+
+		var clientData webauthn.ClientData
+		require.NoError(t, json.Unmarshal(clientDataJSON, &clientData))
+		challenge, err := base64.RawURLEncoding.DecodeString(clientData.Challenge)
+		require.NoError(t, err)
+		assert.Equal(t, webauthn.Challenge(tx.EnvelopeMessage()), challenge)
+
+		encoded, err := webauthn.ExtensionData(authenticatorData, clientDataJSON)
+		require.NoError(t, err)
+		assert.Equal(t, tx.EnvelopeSignatures[0].ExtensionData, encoded)
+	})
+
+	t.Run("Challenge mismatch", func(t *testing.T) {
+		sig := tx.EnvelopeSignatures[0]
+		err := webauthn.Verify(publicKey, tx.PayloadMessage(), sig.Signature, sig.ExtensionData)
+		assert.ErrorIs(t, err, webauthn.ErrInvalidAssertion)
+	})
+
+	t.Run("Invalid assertions", func(t *testing.T) {
+		notPresent := newTestAuthenticator(t)
+		notPresent.flags = 0x04
+		signer, err := webauthn.NewSigner(ctx, notPresent, notPresent.publicKey(t))
+		require.NoError(t, err)
+		err = signer.SignPayload(newTransaction(address), address, 0)
+		assert.ErrorIs(t, err, webauthn.ErrInvalidAssertion)
+
+		create := newTestAuthenticator(t)
+		create.clientDataType = "webauthn.create"
+		signer, err = webauthn.NewSigner(ctx, create, create.publicKey(t))
+		require.NoError(t, err)
+		err = signer.SignPayload(newTransaction(address), address, 0)
+		assert.ErrorIs(t, err, webauthn.ErrInvalidAssertion)
+
+		// the public key of the signer does not match the authenticator
+		signer, err = webauthn.NewSigner(ctx, authenticator, create.publicKey(t))
+		require.NoError(t, err)
+		err = signer.SignPayload(newTransaction(address), address, 0)
+		assert.ErrorIs(t, err, flow.ErrInvalidSignature)
+	})
+
+	t.Run("Unsupported key", func(t *testing.T) {
+		privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_secp256k1, make([]byte, crypto.MinSeedLength))
+		require.NoError(t, err)
+		_, err = webauthn.NewSigner(ctx, authenticator, privateKey.PublicKey())
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,611 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/sema"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// List of built-in inbox, capability and capability controller event types.
+const (
+	EventInboxValuePublished                      string = "flow.InboxValuePublished"
+	EventInboxValueUnpublished                    string = "flow.InboxValueUnpublished"
+	EventInboxValueClaimed                        string = "flow.InboxValueClaimed"
+	EventStorageCapabilityControllerIssued        string = "flow.StorageCapabilityControllerIssued"
+	EventAccountCapabilityControllerIssued        string = "flow.AccountCapabilityControllerIssued"
+	EventStorageCapabilityControllerDeleted       string = "flow.StorageCapabilityControllerDeleted"
+	EventAccountCapabilityControllerDeleted       string = "flow.AccountCapabilityControllerDeleted"
+	EventStorageCapabilityControllerTargetChanged string = "flow.StorageCapabilityControllerTargetChanged"
+	EventCapabilityPublished                      string = "flow.CapabilityPublished"
+	EventCapabilityUnpublished                    string = "flow.CapabilityUnpublished"
+)
+
+// List of token event names, qualified by the contract name.
+//
+// The full event type also contains the address of the contract, which depends on the network,
+// e.g. `A.1654653399040a61.FlowToken.TokensDeposited` on mainnet.
+const (
+	EventFungibleTokenDeposited string = "FungibleToken.Deposited"
+	EventFungibleTokenWithdrawn string = "FungibleToken.Withdrawn"
+	EventFlowTokenDeposited     string = "FlowToken.TokensDeposited"
+	EventFlowTokenWithdrawn     string = "FlowToken.TokensWithdrawn"
+	EventFlowFeesDeducted       string = "FlowFees.FeesDeducted"
+	EventFlowFeesDeposited      string = "FlowFees.TokensDeposited"
+	EventFlowFeesWithdrawn      string = "FlowFees.TokensWithdrawn"
+)
+
+// ErrUnknownEventType is returned when no decoder is registered for the type of an event.
+var ErrUnknownEventType = errors.New("unknown event type")
+
+func eventField(e Event, name string) cadence.Value {
+	return cadence.SearchFieldByName(e.Value, name)
+}
+
+func eventAddressField(e Event, name string) Address {
+	return BytesToAddress(eventField(e, name).(cadence.Address).Bytes())
+}
+
+func eventOptionalAddressField(e Event, name string) *Address {
+	optional, ok := eventField(e, name).(cadence.Optional)
+	if !ok || optional.Value == nil {
+		return nil
+	}
+	address := BytesToAddress(optional.Value.(cadence.Address).Bytes())
+	return &address
+}
+
+func eventBytesField(e Event, name string) []byte {
+	array := eventField(e, name).(cadence.Array)
+	b := make([]byte, len(array.Values))
+	for i, value := range array.Values {
+		b[i] = byte(value.(cadence.UInt8))
+	}
+	return b
+}
+
+func eventTypeField(e Event, name string) cadence.Type {
+	return eventField(e, name).(cadence.TypeValue).StaticType
+}
+
+// An AccountKeyAddedEvent is emitted when a key is added to an account.
+type AccountKeyAddedEvent Event
+
+// Address returns the address of the account the key was added to.
+func (evt AccountKeyAddedEvent) Address() Address {
+	return eventAddressField(Event(evt), "address")
+}
+
+// KeyIndex returns the index of the added key.
+func (evt AccountKeyAddedEvent) KeyIndex() uint32 {
+	return uint32(eventField(Event(evt), "keyIndex").(cadence.Int).Big().Uint64())
+}
+
+// PublicKey returns the added public key.
+func (evt AccountKeyAddedEvent) PublicKey() (crypto.PublicKey, error) {
+	publicKey := eventField(Event(evt), "publicKey").(cadence.Struct)
+
+	sigAlgo, err := signatureAlgorithmFromCadence(cadence.SearchFieldByName(publicKey, sema.PublicKeyTypeSignatureAlgorithmFieldName))
+	if err != nil {
+		return nil, err
+	}
+
+	array := cadence.SearchFieldByName(publicKey, sema.PublicKeyTypePublicKeyFieldName).(cadence.Array)
+	encoded := make([]byte, len(array.Values))
+	for i, value := range array.Values {
+		encoded[i] = byte(value.(cadence.UInt8))
+	}
+
+	return crypto.DecodePublicKey(sigAlgo, encoded)
+}
+
+// HashAlgorithm returns the hashing algorithm of the added key.
+func (evt AccountKeyAddedEvent) HashAlgorithm() crypto.HashAlgorithm {
+	return hashAlgorithmFromCadence(eventField(Event(evt), "hashAlgorithm"))
+}
+
+// Weight returns the weight of the added key.
+func (evt AccountKeyAddedEvent) Weight() int {
+	return int(uint64(eventField(Event(evt), "weight").(cadence.UFix64)) / 1_0000_0000)
+}
+
+// AccountKey returns the added key as an account key.
+func (evt AccountKeyAddedEvent) AccountKey() (*AccountKey, error) {
+	publicKey, err := evt.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountKey{
+		Index:     evt.KeyIndex(),
+		PublicKey: publicKey,
+		SigAlgo:   publicKey.Algorithm(),
+		HashAlgo:  evt.HashAlgorithm(),
+		Weight:    evt.Weight(),
+	}, nil
+}
+
+// An AccountKeyRemovedEvent is emitted when a key is revoked from an account.
+type AccountKeyRemovedEvent Event
+
+// Address returns the address of the account the key was removed from.
+func (evt AccountKeyRemovedEvent) Address() Address {
+	return eventAddressField(Event(evt), "address")
+}
+
+// KeyIndex returns the index of the removed key.
+func (evt AccountKeyRemovedEvent) KeyIndex() uint32 {
+	return uint32(eventField(Event(evt), "publicKey").(cadence.Int).Big().Uint64())
+}
+
+// An AccountContractEvent is emitted when a contract is added to, updated on, or removed from an account.
+//
+// The event type distinguishes the three cases.
+type AccountContractEvent Event
+
+// Address returns the address of the account.
+func (evt AccountContractEvent) Address() Address {
+	return eventAddressField(Event(evt), "address")
+}
+
+// ContractName returns the name of the contract.
+func (evt AccountContractEvent) ContractName() string {
+	return string(eventField(Event(evt), "contract").(cadence.String))
+}
+
+// CodeHash returns the SHA3-256 hash of the contract code.
+func (evt AccountContractEvent) CodeHash() []byte {
+	return eventBytesField(Event(evt), "codeHash")
+}
+
+// An InboxValueEvent is emitted when a value is published to, unpublished from, or claimed from an account inbox.
+//
+// The event type distinguishes the three cases.
+type InboxValueEvent Event
+
+// Provider returns the address of the account that published the value.
+func (evt InboxValueEvent) Provider() Address {
+	return eventAddressField(Event(evt), "provider")
+}
+
+// Recipient returns the address of the recipient, or nil for unpublished values.
+func (evt InboxValueEvent) Recipient() *Address {
+	if eventField(Event(evt), "recipient") == nil {
+		return nil
+	}
+	address := eventAddressField(Event(evt), "recipient")
+	return &address
+}
+
+// Name returns the name the value was published under.
+func (evt InboxValueEvent) Name() string {
+	return string(eventField(Event(evt), "name").(cadence.String))
+}
+
+// ValueType returns the type of the published value, or nil if it is not part of the event.
+func (evt InboxValueEvent) ValueType() cadence.Type {
+	if eventField(Event(evt), "type") == nil {
+		return nil
+	}
+	return eventTypeField(Event(evt), "type")
+}
+
+// A CapabilityControllerEvent is emitted when a storage or account capability controller is
+// issued, deleted, or retargeted.
+//
+// The event type distinguishes the cases.
+type CapabilityControllerEvent Event
+
+// ID returns the ID of the capability controller.
+func (evt CapabilityControllerEvent) ID() uint64 {
+	return uint64(eventField(Event(evt), "id").(cadence.UInt64))
+}
+
+// Address returns the address of the account.
+func (evt CapabilityControllerEvent) Address() Address {
+	return eventAddressField(Event(evt), "address")
+}
+
+// BorrowType returns the borrow type of an issued capability, or nil for other events.
+func (evt CapabilityControllerEvent) BorrowType() cadence.Type {
+	if eventField(Event(evt), "type") == nil {
+		return nil
+	}
+	return eventTypeField(Event(evt), "type")
+}
+
+// Path returns the target storage path, or nil for account capability controllers and deletions.
+func (evt CapabilityControllerEvent) Path() *cadence.Path {
+	path, ok := eventField(Event(evt), "path").(cadence.Path)
+	if !ok {
+		return nil
+	}
+	return &path
+}
+
+// A CapabilityPublishEvent is emitted when a capability is published or unpublished.
+//
+// The event type distinguishes the two cases.
+type CapabilityPublishEvent Event
+
+// Address returns the address of the account.
+func (evt CapabilityPublishEvent) Address() Address {
+	return eventAddressField(Event(evt), "address")
+}
+
+// Path returns the public path of the capability.
+func (evt CapabilityPublishEvent) Path() cadence.Path {
+	return eventField(Event(evt), "path").(cadence.Path)
+}
+
+// Capability returns the published capability, or nil for unpublished capabilities.
+func (evt CapabilityPublishEvent) Capability() *cadence.Capability {
+	capability, ok := eventField(Event(evt), "capability").(cadence.Capability)
+	if !ok {
+		return nil
+	}
+	return &capability
+}
+
+// A FungibleTokenEvent is emitted by the FungibleToken standard when tokens are deposited or withdrawn.
+//
+// The event type distinguishes deposits and withdrawals.
+type FungibleTokenEvent Event
+
+// TokenType returns the type identifier of the vault.
+func (evt FungibleTokenEvent) TokenType() string {
+	return string(eventField(Event(evt), "type").(cadence.String))
+}
+
+// Amount returns the amount of tokens deposited or withdrawn.
+func (evt FungibleTokenEvent) Amount() cadence.UFix64 {
+	return eventField(Event(evt), "amount").(cadence.UFix64)
+}
+
+// Account returns the address of the account owning the vault the tokens were deposited to, or withdrawn from,
+// or nil if the vault is not stored in an account.
+func (evt FungibleTokenEvent) Account() *Address {
+	if eventField(Event(evt), "to") != nil {
+		return eventOptionalAddressField(Event(evt), "to")
+	}
+	return eventOptionalAddressField(Event(evt), "from")
+}
+
+// VaultUUID returns the UUID of the vault the tokens were deposited to, or withdrawn from.
+func (evt FungibleTokenEvent) VaultUUID() uint64 {
+	if value, ok := eventField(Event(evt), "toUUID").(cadence.UInt64); ok {
+		return uint64(value)
+	}
+	return uint64(eventField(Event(evt), "fromUUID").(cadence.UInt64))
+}
+
+// BalanceAfter returns the balance of the vault after the deposit or withdrawal.
+func (evt FungibleTokenEvent) BalanceAfter() cadence.UFix64 {
+	return eventField(Event(evt), "balanceAfter").(cadence.UFix64)
+}
+
+// A FlowTokenEvent is emitted by the FlowToken contract when tokens are deposited or withdrawn.
+//
+// The event type distinguishes deposits and withdrawals.
+type FlowTokenEvent Event
+
+// Amount returns the amount of FLOW deposited or withdrawn.
+func (evt FlowTokenEvent) Amount() cadence.UFix64 {
+	return eventField(Event(evt), "amount").(cadence.UFix64)
+}
+
+// Account returns the address of the account the tokens were deposited to, or withdrawn from,
+// or nil if the vault is not stored in an account.
+func (evt FlowTokenEvent) Account() *Address {
+	if eventField(Event(evt), "to") != nil {
+		return eventOptionalAddressField(Event(evt), "to")
+	}
+	return eventOptionalAddressField(Event(evt), "from")
+}
+
+// A FlowFeesEvent is emitted by the FlowFees contract when fees are deducted, or tokens are deposited to
+// or withdrawn from the fee vault.
+//
+// The event type distinguishes the cases.
+type FlowFeesEvent Event
+
+// Amount returns the amount of FLOW.
+func (evt FlowFeesEvent) Amount() cadence.UFix64 {
+	return eventField(Event(evt), "amount").(cadence.UFix64)
+}
+
+// InclusionEffort returns the inclusion effort of a transaction, or zero for other events than FeesDeducted.
+func (evt FlowFeesEvent) InclusionEffort() cadence.UFix64 {
+	value, _ := eventField(Event(evt), "inclusionEffort").(cadence.UFix64)
+	return value
+}
+
+// ExecutionEffort returns the execution effort of a transaction, or zero for other events than FeesDeducted.
+func (evt FlowFeesEvent) ExecutionEffort() cadence.UFix64 {
+	value, _ := eventField(Event(evt), "executionEffort").(cadence.UFix64)
+	return value
+}
+
+func signatureAlgorithmFromCadence(value cadence.Value) (crypto.SignatureAlgorithm, error) {
+	enum, ok := value.(cadence.Enum)
+	if !ok {
+		return crypto.UnknownSignatureAlgorithm, fmt.Errorf("invalid signature algorithm value %s", value)
+	}
+	rawValue, ok := cadence.SearchFieldByName(enum, sema.EnumRawValueFieldName).(cadence.UInt8)
+	if !ok {
+		return crypto.UnknownSignatureAlgorithm, fmt.Errorf("invalid signature algorithm value %s", value)
+	}
+
+	switch sema.SignatureAlgorithm(rawValue) {
+	case sema.SignatureAlgorithmECDSA_P256:
+		return crypto.ECDSA_P256, nil
+	case sema.SignatureAlgorithmECDSA_secp256k1:
+		return crypto.ECDSA_secp256k1, nil
+	case sema.SignatureAlgorithmBLS_BLS12_381:
+		return crypto.BLS_BLS12_381, nil
+	default:
+		return crypto.UnknownSignatureAlgorithm, fmt.Errorf("unsupported signature algorithm %d", rawValue)
+	}
+}
+
+func hashAlgorithmFromCadence(value cadence.Value) crypto.HashAlgorithm {
+	enum, ok := value.(cadence.Enum)
+	if !ok {
+		return crypto.UnknownHashAlgorithm
+	}
+	rawValue, ok := cadence.SearchFieldByName(enum, sema.EnumRawValueFieldName).(cadence.UInt8)
+	if !ok {
+		return crypto.UnknownHashAlgorithm
+	}
+
+	switch sema.HashAlgorithm(rawValue) {
+	case sema.HashAlgorithmSHA2_256:
+		return crypto.SHA2_256
+	case sema.HashAlgorithmSHA2_384:
+		return crypto.SHA2_384
+	case sema.HashAlgorithmSHA3_256:
+		return crypto.SHA3_256
+	case sema.HashAlgorithmSHA3_384:
+		return crypto.SHA3_384
+	case sema.HashAlgorithmKMAC128_BLS_BLS12_381:
+		return crypto.KMAC128
+	case sema.HashAlgorithmKECCAK_256:
+		return crypto.Keccak256
+	default:
+		return crypto.UnknownHashAlgorithm
+	}
+}
+
+// An EventDecoder converts a generic event into its typed form.
+type EventDecoder func(event Event) (any, error)
+
+// An EventRegistry dispatches generic events to typed decoders, based on the event type.
+type EventRegistry struct {
+	decoders map[string]EventDecoder
+}
+
+// NewEventRegistry returns an empty event registry.
+func NewEventRegistry() *EventRegistry {
+	return &EventRegistry{
+		decoders: make(map[string]EventDecoder),
+	}
+}
+
+// Register registers a decoder for the given fully qualified event type, replacing any existing decoder.
+func (r *EventRegistry) Register(eventType string, decoder EventDecoder) *EventRegistry {
+	r.decoders[eventType] = decoder
+	return r
+}
+
+// Decode converts the event into its typed form.
+//
+// It returns ErrUnknownEventType if no decoder is registered for the event type.
+func (r *EventRegistry) Decode(event Event) (any, error) {
+	decoder, ok := r.decoders[event.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, event.Type)
+	}
+	return decoder(event)
+}
+
+// CoreEventRegistry returns a registry with decoders for all built-in flow.* events, as well as
+// the FungibleToken, FlowToken and FlowFees events at their addresses on the given network.
+//
+// Typed events are returned as values, e.g. AccountKeyAddedEvent or FlowTokenEvent.
+// Each decoder checks the event fields, so the accessors of the typed event are safe to call.
+func CoreEventRegistry(chainID ChainID) *EventRegistry {
+	registry := NewEventRegistry()
+
+	address := field("address", isValue[cadence.Address])
+	path := field("path", isValue[cadence.Path])
+	id := field("id", isValue[cadence.UInt64])
+	typeValue := field("type", isValue[cadence.TypeValue])
+	provider := field("provider", isValue[cadence.Address])
+	recipient := field("recipient", isValue[cadence.Address])
+	name := field("name", isValue[cadence.String])
+	contract := []eventFieldCheck{
+		address,
+		field("codeHash", isByteArray),
+		field("contract", isValue[cadence.String]),
+	}
+	amount := field("amount", isValue[cadence.UFix64])
+
+	registry.
+		Register(EventAccountCreated, typedDecoder[AccountCreatedEvent](address)).
+		Register(EventAccountKeyAdded, typedDecoder[AccountKeyAddedEvent](
+			address,
+			field("publicKey", isPublicKey),
+			field("weight", isValue[cadence.UFix64]),
+			field("hashAlgorithm", isValue[cadence.Enum]),
+			field("keyIndex", isValue[cadence.Int]),
+		)).
+		Register(EventAccountKeyRemoved, typedDecoder[AccountKeyRemovedEvent](
+			address,
+			field("publicKey", isValue[cadence.Int]),
+		)).
+		Register(EventAccountContractAdded, typedDecoder[AccountContractEvent](contract...)).
+		Register(EventAccountContractUpdated, typedDecoder[AccountContractEvent](contract...)).
+		Register(EventAccountContractRemoved, typedDecoder[AccountContractEvent](contract...)).
+		Register(EventInboxValuePublished, typedDecoder[InboxValueEvent](provider, recipient, name, typeValue)).
+		Register(EventInboxValueUnpublished, typedDecoder[InboxValueEvent](provider, name)).
+		Register(EventInboxValueClaimed, typedDecoder[InboxValueEvent](provider, recipient, name)).
+		Register(EventStorageCapabilityControllerIssued, typedDecoder[CapabilityControllerEvent](id, address, typeValue, path)).
+		Register(EventAccountCapabilityControllerIssued, typedDecoder[CapabilityControllerEvent](id, address, typeValue)).
+		Register(EventStorageCapabilityControllerDeleted, typedDecoder[CapabilityControllerEvent](id, address)).
+		Register(EventAccountCapabilityControllerDeleted, typedDecoder[CapabilityControllerEvent](id, address)).
+		Register(EventStorageCapabilityControllerTargetChanged, typedDecoder[CapabilityControllerEvent](id, address, path)).
+		Register(EventCapabilityPublished, typedDecoder[CapabilityPublishEvent](
+			address,
+			path,
+			field("capability", isValue[cadence.Capability]),
+		)).
+		Register(EventCapabilityUnpublished, typedDecoder[CapabilityPublishEvent](address, path))
+
+	contracts, err := CoreContracts(chainID)
+	if err != nil {
+		return registry
+	}
+
+	eventType := func(contract Address, qualifiedName string) string {
+		return fmt.Sprintf("A.%s.%s", contract.Hex(), qualifiedName)
+	}
+
+	tokenType := field("type", isValue[cadence.String])
+	balanceAfter := field("balanceAfter", isValue[cadence.UFix64])
+
+	registry.
+		Register(
+			eventType(contracts[ContractFungibleToken], EventFungibleTokenDeposited),
+			typedDecoder[FungibleTokenEvent](
+				tokenType,
+				amount,
+				field("to", isOptional[cadence.Address]),
+				field("toUUID", isValue[cadence.UInt64]),
+				balanceAfter,
+			),
+		).
+		Register(
+			eventType(contracts[ContractFungibleToken], EventFungibleTokenWithdrawn),
+			typedDecoder[FungibleTokenEvent](
+				tokenType,
+				amount,
+				field("from", isOptional[cadence.Address]),
+				field("fromUUID", isValue[cadence.UInt64]),
+				balanceAfter,
+			),
+		).
+		Register(
+			eventType(contracts[ContractFlowToken], EventFlowTokenDeposited),
+			typedDecoder[FlowTokenEvent](amount, field("to", isOptional[cadence.Address])),
+		).
+		Register(
+			eventType(contracts[ContractFlowToken], EventFlowTokenWithdrawn),
+			typedDecoder[FlowTokenEvent](amount, field("from", isOptional[cadence.Address])),
+		).
+		Register(
+			eventType(contracts[ContractFlowFees], EventFlowFeesDeducted),
+			typedDecoder[FlowFeesEvent](
+				amount,
+				field("inclusionEffort", isValue[cadence.UFix64]),
+				field("executionEffort", isValue[cadence.UFix64]),
+			),
+		).
+		Register(eventType(contracts[ContractFlowFees], EventFlowFeesDeposited), typedDecoder[FlowFeesEvent](amount)).
+		Register(eventType(contracts[ContractFlowFees], EventFlowFeesWithdrawn), typedDecoder[FlowFeesEvent](amount))
+
+	return registry
+}
+
+type eventFieldCheck struct {
+	name  string
+	check func(cadence.Value) bool
+}
+
+func field(name string, check func(cadence.Value) bool) eventFieldCheck {
+	return eventFieldCheck{name: name, check: check}
+}
+
+func isValue[T cadence.Value](value cadence.Value) bool {
+	_, ok := value.(T)
+	return ok
+}
+
+func isOptional[T cadence.Value](value cadence.Value) bool {
+	optional, ok := value.(cadence.Optional)
+	return ok && (optional.Value == nil || isValue[T](optional.Value))
+}
+
+func isByteArray(value cadence.Value) bool {
+	array, ok := value.(cadence.Array)
+	if !ok {
+		return false
+	}
+	for _, element := range array.Values {
+		if !isValue[cadence.UInt8](element) {
+			return false
+		}
+	}
+	return true
+}
+
+func isPublicKey(value cadence.Value) bool {
+	publicKey, ok := value.(cadence.Struct)
+	return ok &&
+		isByteArray(cadence.SearchFieldByName(publicKey, sema.PublicKeyTypePublicKeyFieldName)) &&
+		isValue[cadence.Enum](cadence.SearchFieldByName(publicKey, sema.PublicKeyTypeSignatureAlgorithmFieldName))
+}
+
+// typedEvent is satisfied by the typed events defined on top of Event.
+type typedEvent interface {
+	~struct {
+		Type             string
+		TransactionID    Identifier
+		TransactionIndex int
+		EventIndex       int
+		Value            cadence.Event
+		Payload          []byte
+	}
+}
+
+// typedDecoder returns a decoder that checks the event fields and converts the event to the typed event T.
+func typedDecoder[T typedEvent](fields ...eventFieldCheck) EventDecoder {
+	return func(event Event) (any, error) {
+		if event.Value.EventType == nil {
+			return nil, fmt.Errorf("event %s has no value", event.Type)
+		}
+
+		values := cadence.FieldsMappedByName(event.Value)
+		for _, f := range fields {
+			value, ok := values[f.name]
+			if !ok {
+				return nil, fmt.Errorf("event %s is missing field %s", event.Type, f.name)
+			}
+			if !f.check(value) {
+				return nil, fmt.Errorf("event %s has invalid field %s: %s", event.Type, f.name, value)
+			}
+		}
+
+		return T(event), nil
+	}
+}
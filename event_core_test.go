@@ -0,0 +1,226 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+func newEvent(location common.Location, qualifiedName string, fields []cadence.Field, values []cadence.Value) flow.Event {
+	eventType := cadence.NewEventType(location, qualifiedName, fields, nil)
+	return flow.Event{
+		Type:  eventType.ID(),
+		Value: cadence.NewEvent(values).WithType(eventType),
+	}
+}
+
+func newEnum(value uint8) cadence.Enum {
+	return cadence.NewEnum([]cadence.Value{cadence.UInt8(value)}).
+		WithType(cadence.NewEnumType(nil, "E", cadence.UInt8Type, []cadence.Field{{Identifier: "rawValue", Type: cadence.UInt8Type}}, nil))
+}
+
+func TestCoreEventRegistry(t *testing.T) {
+	registry := flow.CoreEventRegistry(flow.Mainnet)
+	address := flow.HexToAddress("0x01")
+
+	t.Run("Account key added", func(t *testing.T) {
+		privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, make([]byte, crypto.MinSeedLength))
+		require.NoError(t, err)
+
+		encoded := privateKey.PublicKey().Encode()
+		bytes := make([]cadence.Value, len(encoded))
+		for i, b := range encoded {
+			bytes[i] = cadence.UInt8(b)
+		}
+
+		publicKey := cadence.NewStruct([]cadence.Value{
+			cadence.NewArray(bytes),
+			newEnum(1),
+		}).WithType(cadence.NewStructType(nil, "PublicKey", []cadence.Field{
+			{Identifier: "publicKey", Type: cadence.NewVariableSizedArrayType(cadence.UInt8Type)},
+			{Identifier: "signatureAlgorithm", Type: cadence.UInt8Type},
+		}, nil))
+
+		event := newEvent(nil, "flow.AccountKeyAdded", []cadence.Field{
+			{Identifier: "address", Type: cadence.AddressType},
+			{Identifier: "publicKey", Type: cadence.AnyStructType},
+			{Identifier: "weight", Type: cadence.UFix64Type},
+			{Identifier: "hashAlgorithm", Type: cadence.AnyStructType},
+			{Identifier: "keyIndex", Type: cadence.IntType},
+		}, []cadence.Value{
+			cadence.NewAddress(address),
+			publicKey,
+			cadence.UFix64(1000_00000000),
+			newEnum(3),
+			cadence.NewInt(2),
+		})
+		require.Equal(t, flow.EventAccountKeyAdded, event.Type)
+
+		decoded, err := registry.Decode(event)
+		require.NoError(t, err)
+
+		keyAdded, ok := decoded.(flow.AccountKeyAddedEvent)
+		require.True(t, ok)
+
+		key, err := keyAdded.AccountKey()
+		require.NoError(t, err)
+
+		assert.Equal(t, address, keyAdded.Address())
+		assert.Equal(t, uint32(2), key.Index)
+		assert.True(t, privateKey.PublicKey().Equals(key.PublicKey))
+		assert.Equal(t, crypto.ECDSA_P256, key.SigAlgo)
+		assert.Equal(t, crypto.SHA3_256, key.HashAlgo)
+		assert.Equal(t, 1000, key.Weight)
+	})
+
+	t.Run("Account contract updated", func(t *testing.T) {
+		hash := make([]cadence.Value, 32)
+		for i := range hash {
+			hash[i] = cadence.UInt8(i)
+		}
+
+		event := newEvent(nil, "flow.AccountContractUpdated", []cadence.Field{
+			{Identifier: "address", Type: cadence.AddressType},
+			{Identifier: "codeHash", Type: cadence.NewConstantSizedArrayType(32, cadence.UInt8Type)},
+			{Identifier: "contract", Type: cadence.StringType},
+		}, []cadence.Value{
+			cadence.NewAddress(address),
+			cadence.NewArray(hash),
+			cadence.String("Foo"),
+		})
+
+		decoded, err := registry.Decode(event)
+		require.NoError(t, err)
+
+		contract := decoded.(flow.AccountContractEvent)
+		assert.Equal(t, address, contract.Address())
+		assert.Equal(t, "Foo", contract.ContractName())
+		assert.Len(t, contract.CodeHash(), 32)
+		assert.Equal(t, byte(31), contract.CodeHash()[31])
+	})
+
+	t.Run("Storage capability controller issued", func(t *testing.T) {
+		path, err := cadence.NewPath(common.PathDomainStorage, "vault")
+		require.NoError(t, err)
+
+		event := newEvent(nil, "flow.StorageCapabilityControllerIssued", []cadence.Field{
+			{Identifier: "id", Type: cadence.UInt64Type},
+			{Identifier: "address", Type: cadence.AddressType},
+			{Identifier: "type", Type: cadence.MetaType},
+			{Identifier: "path", Type: cadence.StoragePathType},
+		}, []cadence.Value{
+			cadence.UInt64(7),
+			cadence.NewAddress(address),
+			cadence.NewTypeValue(cadence.IntType),
+			path,
+		})
+
+		decoded, err := registry.Decode(event)
+		require.NoError(t, err)
+
+		issued := decoded.(flow.CapabilityControllerEvent)
+		assert.Equal(t, uint64(7), issued.ID())
+		assert.Equal(t, address, issued.Address())
+		assert.Equal(t, cadence.IntType, issued.BorrowType())
+		assert.Equal(t, &path, issued.Path())
+	})
+
+	t.Run("Flow token deposited", func(t *testing.T) {
+		event := newEvent(
+			common.AddressLocation{Address: common.Address(flow.HexToAddress("1654653399040a61")), Name: "FlowToken"},
+			flow.EventFlowTokenDeposited,
+			[]cadence.Field{
+				{Identifier: "amount", Type: cadence.UFix64Type},
+				{Identifier: "to", Type: cadence.NewOptionalType(cadence.AddressType)},
+			},
+			[]cadence.Value{
+				cadence.UFix64(5_00000000),
+				cadence.NewOptional(cadence.NewAddress(address)),
+			},
+		)
+		require.Equal(t, "A.1654653399040a61.FlowToken.TokensDeposited", event.Type)
+
+		decoded, err := registry.Decode(event)
+		require.NoError(t, err)
+
+		deposited := decoded.(flow.FlowTokenEvent)
+		assert.Equal(t, cadence.UFix64(5_00000000), deposited.Amount())
+		assert.Equal(t, &address, deposited.Account())
+	})
+
+	t.Run("Fungible token withdrawn", func(t *testing.T) {
+		event := newEvent(
+			common.AddressLocation{Address: common.Address(flow.HexToAddress("f233dcee88fe0abe")), Name: "FungibleToken"},
+			flow.EventFungibleTokenWithdrawn,
+			[]cadence.Field{
+				{Identifier: "type", Type: cadence.StringType},
+				{Identifier: "amount", Type: cadence.UFix64Type},
+				{Identifier: "from", Type: cadence.NewOptionalType(cadence.AddressType)},
+				{Identifier: "fromUUID", Type: cadence.UInt64Type},
+				{Identifier: "withdrawnUUID", Type: cadence.UInt64Type},
+				{Identifier: "balanceAfter", Type: cadence.UFix64Type},
+			},
+			[]cadence.Value{
+				cadence.String("A.1654653399040a61.FlowToken.Vault"),
+				cadence.UFix64(1_00000000),
+				cadence.NewOptional(nil),
+				cadence.UInt64(10),
+				cadence.UInt64(11),
+				cadence.UFix64(2_00000000),
+			},
+		)
+
+		decoded, err := registry.Decode(event)
+		require.NoError(t, err)
+
+		withdrawn := decoded.(flow.FungibleTokenEvent)
+		assert.Equal(t, "A.1654653399040a61.FlowToken.Vault", withdrawn.TokenType())
+		assert.Nil(t, withdrawn.Account())
+		assert.Equal(t, uint64(10), withdrawn.VaultUUID())
+		assert.Equal(t, cadence.UFix64(2_00000000), withdrawn.BalanceAfter())
+	})
+
+	t.Run("Invalid fields", func(t *testing.T) {
+		event := newEvent(nil, "flow.AccountCreated", []cadence.Field{
+			{Identifier: "address", Type: cadence.StringType},
+		}, []cadence.Value{
+			cadence.String("0x01"),
+		})
+
+		_, err := registry.Decode(event)
+		require.Error(t, err)
+	})
+
+	t.Run("Unknown event type", func(t *testing.T) {
+		_, err := registry.Decode(flow.Event{Type: "A.0000000000000001.Foo.Bar"})
+		require.ErrorIs(t, err, flow.ErrUnknownEventType)
+
+		// token events are registered per network
+		_, err = flow.CoreEventRegistry(flow.Testnet).Decode(flow.Event{Type: "A.1654653399040a61.FlowToken.TokensDeposited"})
+		require.ErrorIs(t, err, flow.ErrUnknownEventType)
+	})
+}
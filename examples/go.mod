@@ -5,8 +5,8 @@ go 1.26.0
 replace github.com/onflow/flow-go-sdk => ../
 
 require (
-	github.com/onflow/cadence v1.10.5
-	github.com/onflow/crypto v0.27.0
+	github.com/onflow/cadence v1.10.6
+	github.com/onflow/crypto v0.27.2
 	github.com/onflow/flow-go-sdk v1.2.2
 	github.com/onflow/flowkit v1.19.0
 	github.com/spf13/afero v1.11.0
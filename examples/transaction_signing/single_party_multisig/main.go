@@ -75,12 +75,16 @@ func SinglePartyMultiSignatureDemo() {
 		SetPayer(account1.Address).
 		AddAuthorizer(account1.Address)
 
-	// account 1 signs the envelope with key 1
-	err = tx.SignEnvelope(account1.Address, account1.Keys[0].Index, key1Signer)
+	// account 1 signs the envelope with key 1 and key 2, which together reach the weight threshold
+	signer := flow.NewMultiSigner(
+		account1.Address,
+		flow.KeySigner{KeyIndex: account1.Keys[0].Index, Signer: key1Signer},
+		flow.KeySigner{KeyIndex: account1.Keys[1].Index, Signer: key2Signer},
+	)
+	err = signer.UpdateKeys(account1.Keys)
 	examples.Handle(err)
 
-	// account 1 signs the envelope with key 2
-	err = tx.SignEnvelope(account1.Address, account1.Keys[1].Index, key2Signer)
+	err = signer.Sign(tx)
 	examples.Handle(err)
 
 	err = flowClient.SendTransaction(ctx, *tx)
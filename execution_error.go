@@ -0,0 +1,255 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrorCode is an error code reported by the Flow virtual machine, e.g. 1101 for Cadence runtime errors.
+type ErrorCode int
+
+// List of error codes reported by the Flow virtual machine.
+//
+// The codes are defined by flow-go and included in error messages as `[Error Code: 1101]`.
+const (
+	ErrorCodeUnknown                        ErrorCode = 0
+	ErrorCodeInvalidTxByteSize              ErrorCode = 1001
+	ErrorCodeInvalidReferenceBlock          ErrorCode = 1002
+	ErrorCodeExpiredTransaction             ErrorCode = 1003
+	ErrorCodeInvalidScript                  ErrorCode = 1004
+	ErrorCodeInvalidGasLimit                ErrorCode = 1005
+	ErrorCodeInvalidProposalSignature       ErrorCode = 1006
+	ErrorCodeInvalidProposalSeqNumber       ErrorCode = 1007
+	ErrorCodeInvalidPayloadSignature        ErrorCode = 1008
+	ErrorCodeInvalidEnvelopeSignature       ErrorCode = 1009
+	ErrorCodeCadenceRuntime                 ErrorCode = 1101
+	ErrorCodeEncodingUnsupportedValue       ErrorCode = 1102
+	ErrorCodeStorageCapacityExceeded        ErrorCode = 1103
+	ErrorCodeEventLimitExceeded             ErrorCode = 1105
+	ErrorCodeLedgerInteractionLimitExceeded ErrorCode = 1106
+	ErrorCodeStateKeySizeLimit              ErrorCode = 1107
+	ErrorCodeStateValueSizeLimit            ErrorCode = 1108
+	ErrorCodeTransactionFeeDeductionFailed  ErrorCode = 1109
+	ErrorCodeComputationLimitExceeded       ErrorCode = 1110
+	ErrorCodeMemoryLimitExceeded            ErrorCode = 1111
+	ErrorCodeScriptExecutionTimedOut        ErrorCode = 1113
+	ErrorCodeScriptExecutionCancelled       ErrorCode = 1114
+	ErrorCodeInsufficientPayerBalance       ErrorCode = 1118
+	ErrorCodeAccountNotFound                ErrorCode = 1201
+	ErrorCodeAccountPublicKeyNotFound       ErrorCode = 1202
+	ErrorCodeAccountAlreadyExists           ErrorCode = 1203
+	ErrorCodeAccountPublicKeyLimit          ErrorCode = 1206
+)
+
+// CadenceErrorKind is the kind of a Cadence runtime error, taken from the prefix of the Cadence error message.
+type CadenceErrorKind string
+
+// List of Cadence error kinds.
+const (
+	CadenceErrorKindUnknown            CadenceErrorKind = ""
+	CadenceErrorKindPanic              CadenceErrorKind = "panic"
+	CadenceErrorKindPreCondition       CadenceErrorKind = "pre-condition failed"
+	CadenceErrorKindPostCondition      CadenceErrorKind = "post-condition failed"
+	CadenceErrorKindAssertion          CadenceErrorKind = "assertion failed"
+	CadenceErrorKindForceNil           CadenceErrorKind = "unexpectedly found nil while forcing an Optional value"
+	CadenceErrorKindOverflow           CadenceErrorKind = "overflow"
+	CadenceErrorKindUnderflow          CadenceErrorKind = "underflow"
+	CadenceErrorKindDivisionByZero     CadenceErrorKind = "division by zero"
+	CadenceErrorKindDereferenceFailure CadenceErrorKind = "dereference failure"
+)
+
+// Sentinel errors for common classes of execution errors.
+//
+// They can be used with errors.Is on an *ExecutionError, for example:
+//
+//	if errors.Is(result.Error, flow.ErrInsufficientBalance) { ... }
+//
+// ErrInsufficientBalance matches the insufficient payer balance error, and the withdrawal pre-conditions
+// of the fungible token contracts. Other messages mentioning a balance do not match.
+var (
+	ErrInsufficientBalance         = errors.New("insufficient balance")
+	ErrStorageCapacityExceeded     = errors.New("storage capacity exceeded")
+	ErrSequenceNumberMismatch      = errors.New("sequence number mismatch")
+	ErrInvalidTransactionSignature = errors.New("invalid transaction signature")
+	ErrComputationLimitExceeded    = errors.New("computation limit exceeded")
+)
+
+// ExecutionError is a structured transaction or script execution error.
+//
+// The fields are parsed from the error message returned by the access node. Fields which are not present
+// in the message are left empty.
+type ExecutionError struct {
+	// Code is the innermost Flow error code in the message.
+	Code ErrorCode
+	// Codes are all Flow error codes in the message, from outermost to innermost.
+	Codes []ErrorCode
+	// Kind is the kind of the Cadence error, if the error is a Cadence runtime error.
+	Kind CadenceErrorKind
+	// Location is the location of the program which caused the error, e.g. a transaction ID or `A.0x1.Foo`.
+	Location string
+	// Line is the line of the error in the program, starting at 1.
+	Line int
+	// Column is the column of the error in the line, starting at 0.
+	Column int
+	// Message is the error message, without error codes and Cadence error prefix.
+	Message string
+	// Raw is the original error message.
+	Raw string
+}
+
+// Error returns the original error message.
+func (e *ExecutionError) Error() string {
+	return e.Raw
+}
+
+// HasCode returns true if the error contains the given Flow error code.
+func (e *ExecutionError) HasCode(code ErrorCode) bool {
+	for _, c := range e.Codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Is reports whether the execution error belongs to the class of the given sentinel error.
+func (e *ExecutionError) Is(target error) bool {
+	switch target {
+	case ErrInsufficientBalance:
+		if e.HasCode(ErrorCodeInsufficientPayerBalance) {
+			return true
+		}
+		if e.Kind != CadenceErrorKindPreCondition {
+			return false
+		}
+		for _, pattern := range insufficientBalancePatterns {
+			if pattern.MatchString(e.Message) {
+				return true
+			}
+		}
+		return false
+	case ErrStorageCapacityExceeded:
+		return e.HasCode(ErrorCodeStorageCapacityExceeded)
+	case ErrSequenceNumberMismatch:
+		return e.HasCode(ErrorCodeInvalidProposalSeqNumber)
+	case ErrInvalidTransactionSignature:
+		return e.HasCode(ErrorCodeInvalidProposalSignature) ||
+			e.HasCode(ErrorCodeInvalidPayloadSignature) ||
+			e.HasCode(ErrorCodeInvalidEnvelopeSignature)
+	case ErrComputationLimitExceeded:
+		return e.HasCode(ErrorCodeComputationLimitExceeded)
+	}
+	return false
+}
+
+// insufficientBalancePatterns match the exact pre-condition messages of the fungible token contracts
+// when a withdrawal exceeds the balance of a vault.
+var insufficientBalancePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^Amount withdrawn must be less than or equal than the balance of the Vault$`),
+	regexp.MustCompile(`^FungibleToken\.Vault\.withdraw: Cannot withdraw tokens! ` +
+		`The amount requested to be withdrawn \([0-9.]+\) is greater than the balance of the Vault \([0-9.]+\)\.?$`),
+}
+
+var (
+	errorCodePattern = regexp.MustCompile(`\[Error Code: (\d+)]`)
+	locationPattern  = regexp.MustCompile(`(?m)^\s*--> (\S+?):(\d+):(\d+)\s*$`)
+	cadenceErrorLine = regexp.MustCompile(`(?m)^error: (.*)$`)
+	// codePrefixPattern matches the error codes and wrapping text of flow-go, e.g. `[Error Code: 1101] error caused by: `.
+	codePrefixPattern = regexp.MustCompile(`^(.*\[Error Code: \d+] )+`)
+)
+
+var cadenceErrorKinds = []CadenceErrorKind{
+	CadenceErrorKindPanic,
+	CadenceErrorKindPreCondition,
+	CadenceErrorKindPostCondition,
+	CadenceErrorKindAssertion,
+	CadenceErrorKindForceNil,
+	CadenceErrorKindOverflow,
+	CadenceErrorKindUnderflow,
+	CadenceErrorKindDivisionByZero,
+	CadenceErrorKindDereferenceFailure,
+}
+
+// ParseExecutionError converts an error returned by the access API into an *ExecutionError.
+//
+// It returns the error itself if it already is, or wraps, an *ExecutionError. It returns nil if err is nil.
+// Errors without a Flow error code are parsed as well, e.g. script execution errors
+// returned as RPC errors, so the result has ErrorCodeUnknown if the message contains no code.
+func ParseExecutionError(err error) *ExecutionError {
+	if err == nil {
+		return nil
+	}
+
+	var executionErr *ExecutionError
+	if errors.As(err, &executionErr) {
+		return executionErr
+	}
+
+	return ParseExecutionErrorMessage(err.Error())
+}
+
+// ParseExecutionErrorMessage parses an execution error message, as returned by the access API.
+func ParseExecutionErrorMessage(message string) *ExecutionError {
+	e := &ExecutionError{
+		Raw:     message,
+		Message: strings.TrimSpace(message),
+	}
+
+	for _, match := range errorCodePattern.FindAllStringSubmatch(message, -1) {
+		code, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+		e.Codes = append(e.Codes, ErrorCode(code))
+	}
+	if len(e.Codes) > 0 {
+		e.Code = e.Codes[len(e.Codes)-1]
+	}
+
+	if match := locationPattern.FindStringSubmatch(message); match != nil {
+		e.Location = match[1]
+		e.Line, _ = strconv.Atoi(match[2])
+		e.Column, _ = strconv.Atoi(match[3])
+	}
+
+	if match := cadenceErrorLine.FindStringSubmatch(message); match != nil {
+		e.Message = strings.TrimSpace(match[1])
+		for _, kind := range cadenceErrorKinds {
+			if strings.HasPrefix(e.Message, string(kind)) {
+				e.Kind = kind
+				e.Message = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(e.Message, string(kind)), ":"))
+				break
+			}
+		}
+		if e.Message == "" {
+			e.Message = string(e.Kind)
+		}
+		return e
+	}
+
+	// no Cadence error, use the first line without the error codes
+	line, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	e.Message = strings.TrimSpace(codePrefixPattern.ReplaceAllString(line, ""))
+
+	return e
+}
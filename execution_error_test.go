@@ -0,0 +1,128 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+func TestParseExecutionError(t *testing.T) {
+	t.Run("Cadence panic", func(t *testing.T) {
+		message := "[Error Code: 1101] error caused by: 1 error occurred:\n" +
+			"\t* transaction execute failed: [Error Code: 1101] cadence runtime error: Execution failed:\n" +
+			"error: panic: something went wrong\n" +
+			"  --> 4f0f2bcd1ee1e0f9d2ee4ad0d2a4dcd4d3e4b1d1e3b47c8a2ac6f0e3e1e6f7a8:12:8\n" +
+			"   |\n" +
+			"12 |         panic(\"something went wrong\")\n" +
+			"   |         ^^^^^^^^^^^^^^^^^^^^^^^^^^^^^^\n"
+
+		err := flow.ParseExecutionErrorMessage(message)
+
+		assert.Equal(t, flow.ErrorCodeCadenceRuntime, err.Code)
+		assert.Equal(t, []flow.ErrorCode{1101, 1101}, err.Codes)
+		assert.Equal(t, flow.CadenceErrorKindPanic, err.Kind)
+		assert.Equal(t, "4f0f2bcd1ee1e0f9d2ee4ad0d2a4dcd4d3e4b1d1e3b47c8a2ac6f0e3e1e6f7a8", err.Location)
+		assert.Equal(t, 12, err.Line)
+		assert.Equal(t, 8, err.Column)
+		assert.Equal(t, "something went wrong", err.Message)
+		assert.Equal(t, message, err.Error())
+	})
+
+	t.Run("Pre-condition in contract", func(t *testing.T) {
+		err := flow.ParseExecutionErrorMessage("[Error Code: 1101] cadence runtime error: Execution failed:\n" +
+			"error: pre-condition failed: Amount withdrawn must be less than or equal than the balance of the Vault\n" +
+			"   --> A.1654653399040a61.FlowToken:110:16\n")
+
+		assert.Equal(t, flow.CadenceErrorKindPreCondition, err.Kind)
+		assert.Equal(t, "A.1654653399040a61.FlowToken", err.Location)
+		assert.Equal(t, 110, err.Line)
+		assert.Equal(t, "Amount withdrawn must be less than or equal than the balance of the Vault", err.Message)
+		assert.ErrorIs(t, err, flow.ErrInsufficientBalance)
+		assert.NotErrorIs(t, err, flow.ErrStorageCapacityExceeded)
+	})
+
+	t.Run("Fungible token withdrawal", func(t *testing.T) {
+		err := flow.ParseExecutionErrorMessage("[Error Code: 1101] cadence runtime error: Execution failed:\n" +
+			"error: pre-condition failed: FungibleToken.Vault.withdraw: Cannot withdraw tokens! " +
+			"The amount requested to be withdrawn (10.00000000) is greater than the balance of the Vault (1.00000000).\n" +
+			"   --> f233dcee88fe0abe.FungibleToken:230:16\n")
+
+		assert.ErrorIs(t, err, flow.ErrInsufficientBalance)
+	})
+
+	t.Run("Unrelated insufficient balance", func(t *testing.T) {
+		err := flow.ParseExecutionErrorMessage("[Error Code: 1101] cadence runtime error: Execution failed:\n" +
+			"error: panic: insufficient balance in my game\n" +
+			"  --> 01cf0e2f2f715450.Game:12:8\n")
+
+		assert.Equal(t, "insufficient balance in my game", err.Message)
+		assert.NotErrorIs(t, err, flow.ErrInsufficientBalance)
+	})
+
+	t.Run("Non-Cadence error", func(t *testing.T) {
+		err := flow.ParseExecutionErrorMessage("[Error Code: 1007] invalid proposal key: public key 0 on account f8d6e0586b0a20c7 has sequence number 5, but given 4")
+
+		assert.Equal(t, flow.ErrorCodeInvalidProposalSeqNumber, err.Code)
+		assert.Equal(t, flow.CadenceErrorKindUnknown, err.Kind)
+		assert.Equal(t, "invalid proposal key: public key 0 on account f8d6e0586b0a20c7 has sequence number 5, but given 4", err.Message)
+		assert.ErrorIs(t, err, flow.ErrSequenceNumberMismatch)
+	})
+
+	t.Run("Sentinels", func(t *testing.T) {
+		tests := []struct {
+			message  string
+			sentinel error
+		}{
+			{"[Error Code: 1103] The account with address (f8d6e0586b0a20c7) uses 100 bytes of storage which is over its capacity (10 bytes).", flow.ErrStorageCapacityExceeded},
+			{"[Error Code: 1009] invalid envelope key: signature is not valid", flow.ErrInvalidTransactionSignature},
+			{"[Error Code: 1006] invalid proposal key: signature is not valid", flow.ErrInvalidTransactionSignature},
+			{"[Error Code: 1110] computation exceeds limit (9999)", flow.ErrComputationLimitExceeded},
+			{"[Error Code: 1118] payer f8d6e0586b0a20c7 has insufficient balance", flow.ErrInsufficientBalance},
+		}
+
+		for _, test := range tests {
+			err := flow.ParseExecutionErrorMessage(test.message)
+			assert.ErrorIs(t, err, test.sentinel, test.message)
+		}
+	})
+
+	t.Run("Wrapped errors", func(t *testing.T) {
+		assert.Nil(t, flow.ParseExecutionError(nil))
+
+		original := flow.ParseExecutionErrorMessage("[Error Code: 1110] computation exceeds limit (9999)")
+		wrapped := fmt.Errorf("failed: %w", original)
+		assert.Same(t, original, flow.ParseExecutionError(wrapped))
+		assert.ErrorIs(t, wrapped, flow.ErrComputationLimitExceeded)
+
+		rpcErr := errors.New("rpc error: code = InvalidArgument desc = failed to execute script: [Error Code: 1101] cadence runtime error: Execution failed:\nerror: division by zero\n --> s.0a:2:4\n")
+		parsed := flow.ParseExecutionError(rpcErr)
+		require.NotNil(t, parsed)
+		assert.Equal(t, flow.ErrorCodeCadenceRuntime, parsed.Code)
+		assert.Equal(t, flow.CadenceErrorKindDivisionByZero, parsed.Kind)
+		assert.Equal(t, "division by zero", parsed.Message)
+		assert.Equal(t, "s.0a", parsed.Location)
+	})
+}
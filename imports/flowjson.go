@@ -0,0 +1,134 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package imports
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// NetworkName returns the name of the network used for aliases and deployments in flow.json files,
+// e.g. "mainnet" for flow.Mainnet.
+func NetworkName(chainID flow.ChainID) string {
+	switch chainID {
+	case flow.Mainnet:
+		return "mainnet"
+	case flow.Testnet:
+		return "testnet"
+	case flow.Emulator:
+		return "emulator"
+	default:
+		return string(chainID)
+	}
+}
+
+type flowJSON struct {
+	Contracts    map[string]json.RawMessage              `json:"contracts"`
+	Dependencies map[string]json.RawMessage              `json:"dependencies"`
+	Accounts     map[string]json.RawMessage              `json:"accounts"`
+	Deployments  map[string]map[string][]json.RawMessage `json:"deployments"`
+}
+
+type flowJSONContract struct {
+	Aliases map[string]string `json:"aliases"`
+}
+
+type flowJSONAccount struct {
+	Address string `json:"address"`
+}
+
+type flowJSONDeployment struct {
+	Name string `json:"name"`
+}
+
+// LoadFlowJSON returns the contract aliases of the given network from a flow.json configuration file.
+//
+// The aliases of contracts and dependencies are used, as well as the deployments of contracts
+// to accounts on the network, where deployments take precedence.
+func LoadFlowJSON(data []byte, network string) (Aliases, error) {
+	var config flowJSON
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse flow.json: %w", err)
+	}
+
+	aliases := make(Aliases)
+
+	for _, contracts := range []map[string]json.RawMessage{config.Dependencies, config.Contracts} {
+		for name, raw := range contracts {
+			var contract flowJSONContract
+			// contracts may be declared with a simple source path, which has no aliases
+			if err := json.Unmarshal(raw, &contract); err != nil {
+				continue
+			}
+
+			alias, ok := contract.Aliases[network]
+			if !ok {
+				continue
+			}
+
+			address, err := parseAddress(alias)
+			if err != nil {
+				return nil, fmt.Errorf("invalid alias of contract %s on %s: %w", name, network, err)
+			}
+			aliases[name] = address
+		}
+	}
+
+	for accountName, contracts := range config.Deployments[network] {
+		raw, ok := config.Accounts[accountName]
+		if !ok {
+			return nil, fmt.Errorf("deployment to unknown account %s on %s", accountName, network)
+		}
+
+		var account flowJSONAccount
+		if err := json.Unmarshal(raw, &account); err != nil {
+			return nil, fmt.Errorf("invalid account %s: %w", accountName, err)
+		}
+
+		address, err := parseAddress(account.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address of account %s: %w", accountName, err)
+		}
+
+		for _, raw := range contracts {
+			// deployments are either contract names, or objects with a name and arguments
+			var name string
+			if err := json.Unmarshal(raw, &name); err != nil {
+				var deployment flowJSONDeployment
+				if err := json.Unmarshal(raw, &deployment); err != nil {
+					return nil, fmt.Errorf("invalid deployment to account %s: %w", accountName, err)
+				}
+				name = deployment.Name
+			}
+			aliases[name] = address
+		}
+	}
+
+	return aliases, nil
+}
+
+func parseAddress(s string) (flow.Address, error) {
+	address := flow.HexToAddress(s)
+	if address == flow.EmptyAddress {
+		return flow.EmptyAddress, fmt.Errorf("invalid address %q", s)
+	}
+	return address, nil
+}
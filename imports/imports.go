@@ -0,0 +1,188 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package imports resolves the imports of Cadence scripts, transactions and contracts to contract addresses.
+//
+// Cadence code is usually written with string imports, e.g. `import "FlowToken"`, or file imports, e.g.
+// `import FlowToken from "./FlowToken.cdc"`, so the same code can be used on different networks.
+// Before the code is sent to a network, a Resolver rewrites these imports to address imports,
+// e.g. `import FlowToken from 0x1654653399040a61`, using the contract addresses of the network.
+package imports
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/parser"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// ErrUnresolvedImport is returned when an import of a Cadence program has no known address.
+var ErrUnresolvedImport = errors.New("unresolved import")
+
+// Aliases maps contract names to the addresses the contracts are deployed to.
+type Aliases map[string]flow.Address
+
+// A Resolver rewrites string and file imports of Cadence programs to address imports.
+//
+// Imports which already use an address, as well as built-in contract imports such as `import Crypto`,
+// are left unchanged.
+type Resolver struct {
+	aliases Aliases
+}
+
+// NewResolver returns a resolver using the given aliases.
+func NewResolver(aliases Aliases) *Resolver {
+	r := &Resolver{
+		aliases: make(Aliases, len(aliases)),
+	}
+	return r.WithAliases(aliases)
+}
+
+// NewNetworkResolver returns a resolver for the core contracts of the given network,
+// e.g. FlowToken, FungibleToken and NonFungibleToken.
+//
+// Additional contracts can be added with WithAliases, e.g. from a flow.json file with LoadFlowJSON.
+func NewNetworkResolver(chainID flow.ChainID) (*Resolver, error) {
+	contracts, err := flow.CoreContracts(chainID)
+	if err != nil {
+		return nil, err
+	}
+	return NewResolver(Aliases(contracts)), nil
+}
+
+// WithAliases adds the given aliases to the resolver, replacing existing aliases with the same name.
+func (r *Resolver) WithAliases(aliases Aliases) *Resolver {
+	for name, address := range aliases {
+		r.aliases[name] = address
+	}
+	return r
+}
+
+// Aliases returns a copy of the aliases of the resolver.
+func (r *Resolver) Aliases() Aliases {
+	aliases := make(Aliases, len(r.aliases))
+	for name, address := range r.aliases {
+		aliases[name] = address
+	}
+	return aliases
+}
+
+// Resolve parses the Cadence code and rewrites its string and file imports to address imports.
+//
+// A string import `import "X"` is resolved using the alias X. A file import `import X from "./path/Y.cdc"` is
+// resolved using the alias Y, i.e. the file name without extension.
+//
+// All unresolved imports are reported as a single error, which wraps ErrUnresolvedImport.
+func (r *Resolver) Resolve(code []byte) ([]byte, error) {
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Cadence code: %w", err)
+	}
+
+	declarations := program.ImportDeclarations()
+
+	// rewrite from the end, so the offsets of earlier declarations stay valid
+	sort.Slice(declarations, func(i, j int) bool {
+		return declarations[i].StartPos.Offset > declarations[j].StartPos.Offset
+	})
+
+	resolved := make([]byte, len(code))
+	copy(resolved, code)
+
+	var errs []error
+	for _, declaration := range declarations {
+		location, ok := declaration.Location.(common.StringLocation)
+		if !ok {
+			continue
+		}
+
+		name := contractName(string(location))
+		address, ok := r.aliases[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf(
+				"%w: %s at line %d",
+				ErrUnresolvedImport,
+				string(location),
+				declaration.StartPos.Line,
+			))
+			continue
+		}
+
+		replacement := []byte(fmt.Sprintf(
+			"import %s from %s",
+			importedIdentifiers(declaration, name),
+			address.HexWithPrefix(),
+		))
+
+		resolved = append(
+			resolved[:declaration.StartPos.Offset],
+			append(replacement, resolved[declaration.EndPos.Offset+1:]...)...,
+		)
+	}
+
+	if len(errs) > 0 {
+		// report in source order
+		for i, j := 0, len(errs)-1; i < j; i, j = i+1, j-1 {
+			errs[i], errs[j] = errs[j], errs[i]
+		}
+		return nil, errors.Join(errs...)
+	}
+
+	return resolved, nil
+}
+
+// MustResolve resolves the imports of the Cadence code like Resolve, but panics on error.
+func (r *Resolver) MustResolve(code []byte) []byte {
+	resolved, err := r.Resolve(code)
+	if err != nil {
+		panic(err)
+	}
+	return resolved
+}
+
+// contractName returns the contract name of a string or file import location.
+func contractName(location string) string {
+	if !strings.HasSuffix(location, ".cdc") {
+		return location
+	}
+	return strings.TrimSuffix(path.Base(location), ".cdc")
+}
+
+// importedIdentifiers returns the identifiers of an import declaration, e.g. `A, B as C`,
+// or the contract name for string imports, which do not list identifiers.
+func importedIdentifiers(declaration *ast.ImportDeclaration, name string) string {
+	if len(declaration.Imports) == 0 {
+		return name
+	}
+
+	identifiers := make([]string, len(declaration.Imports))
+	for i, imported := range declaration.Imports {
+		identifiers[i] = imported.Identifier.Identifier
+		if imported.Alias.Identifier != "" {
+			identifiers[i] += " as " + imported.Alias.Identifier
+		}
+	}
+	return strings.Join(identifiers, ", ")
+}
@@ -0,0 +1,127 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package imports_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/imports"
+)
+
+func TestResolver_Resolve(t *testing.T) {
+	t.Run("Network contracts", func(t *testing.T) {
+		resolver, err := imports.NewNetworkResolver(flow.Testnet)
+		require.NoError(t, err)
+
+		code := []byte(`import Crypto
+import "FungibleToken"
+import FlowToken from "../contracts/FlowToken.cdc"
+import Foo from 0x01
+
+access(all) fun main(): UFix64 { return 1.0 }
+`)
+
+		resolved, err := resolver.Resolve(code)
+		require.NoError(t, err)
+
+		assert.Equal(t, `import Crypto
+import FungibleToken from 0x9a0766d93b6608b7
+import FlowToken from 0x7e60df042a9c0868
+import Foo from 0x01
+
+access(all) fun main(): UFix64 { return 1.0 }
+`, string(resolved))
+	})
+
+	t.Run("Identifiers and aliases", func(t *testing.T) {
+		resolver := imports.NewResolver(imports.Aliases{"Foo": flow.HexToAddress("0x02")})
+
+		resolved, err := resolver.Resolve([]byte(`import A, B as C from "./Foo.cdc"`))
+		require.NoError(t, err)
+		assert.Equal(t, `import A, B as C from 0x0000000000000002`, string(resolved))
+	})
+
+	t.Run("Unresolved imports", func(t *testing.T) {
+		resolver := imports.NewResolver(nil)
+
+		_, err := resolver.Resolve([]byte("import \"Foo\"\nimport Bar from \"./Bar.cdc\"\n"))
+		require.ErrorIs(t, err, imports.ErrUnresolvedImport)
+		assert.Contains(t, err.Error(), "Foo at line 1")
+		assert.Contains(t, err.Error(), "./Bar.cdc at line 2")
+
+		assert.Panics(t, func() {
+			resolver.MustResolve([]byte(`import "Foo"`))
+		})
+	})
+
+	t.Run("Invalid code", func(t *testing.T) {
+		_, err := imports.NewResolver(nil).Resolve([]byte(`import`))
+		require.Error(t, err)
+	})
+}
+
+func TestLoadFlowJSON(t *testing.T) {
+	config := []byte(`{
+		"contracts": {
+			"Foo": {
+				"source": "./cadence/contracts/Foo.cdc",
+				"aliases": {"testnet": "0x0000000000000003"}
+			},
+			"Bar": "./cadence/contracts/Bar.cdc"
+		},
+		"dependencies": {
+			"NonFungibleToken": {
+				"source": "mainnet://1d7e57aa55817448.NonFungibleToken",
+				"aliases": {"emulator": "f8d6e0586b0a20c7", "testnet": "631e88ae7f1d7c20"}
+			}
+		},
+		"accounts": {
+			"testnet-account": {"address": "0x0000000000000004", "key": "abc"}
+		},
+		"deployments": {
+			"testnet": {
+				"testnet-account": ["Bar", {"name": "Baz", "args": []}]
+			}
+		}
+	}`)
+
+	aliases, err := imports.LoadFlowJSON(config, imports.NetworkName(flow.Testnet))
+	require.NoError(t, err)
+
+	assert.Equal(t, imports.Aliases{
+		"Foo":              flow.HexToAddress("03"),
+		"Bar":              flow.HexToAddress("04"),
+		"Baz":              flow.HexToAddress("04"),
+		"NonFungibleToken": flow.HexToAddress("631e88ae7f1d7c20"),
+	}, aliases)
+
+	resolver, err := imports.NewNetworkResolver(flow.Testnet)
+	require.NoError(t, err)
+
+	resolved, err := resolver.WithAliases(aliases).Resolve([]byte(`import "Bar"`))
+	require.NoError(t, err)
+	assert.Equal(t, `import Bar from 0x0000000000000004`, string(resolved))
+
+	_, err = imports.LoadFlowJSON([]byte(`{"deployments": {"testnet": {"missing": ["Foo"]}}}`), "testnet")
+	require.Error(t, err)
+}
@@ -0,0 +1,126 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package webauthn implements the validation of WebAuthn transaction signature extension data (FLIP 264),
+// shared by the transaction signature verification of the flow package and the crypto/webauthn package.
+package webauthn
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// SchemeIdentifier is the first byte of the extension data of WebAuthn signatures.
+const SchemeIdentifier byte = 1
+
+// TypeGet is the client data type of assertions.
+const TypeGet = "webauthn.get"
+
+// authenticatorDataMinLength is the length of the rpIdHash (32 bytes), flags (1 byte) and signCount (4 bytes)
+// of authenticator data.
+const authenticatorDataMinLength = 37
+
+// flagUserPresent is the user presence flag of authenticator data.
+const flagUserPresent = 0x01
+
+// ClientData is the client data of an assertion.
+type ClientData struct {
+	Type string `json:"type"`
+	// Challenge is the base64url encoded challenge.
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+// extensionData is the RLP structure following the scheme identifier in the extension data.
+type extensionData struct {
+	AuthenticatorData []byte
+	ClientDataJson    []byte
+}
+
+// EncodeExtensionData encodes the authenticator data and client data JSON of an assertion as extension data.
+func EncodeExtensionData(authenticatorData []byte, clientDataJSON []byte) ([]byte, error) {
+	encoded, err := rlp.EncodeToBytes(&extensionData{
+		AuthenticatorData: authenticatorData,
+		ClientDataJson:    clientDataJSON,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode extension data: %w", err)
+	}
+
+	return append([]byte{SchemeIdentifier}, encoded...), nil
+}
+
+// ParseExtensionData decodes extension data into the authenticator data and client data JSON.
+func ParseExtensionData(data []byte) (authenticatorData []byte, clientDataJSON []byte, err error) {
+	if len(data) == 0 {
+		return nil, nil, errors.New("missing extension data")
+	}
+	if data[0] != SchemeIdentifier {
+		return nil, nil, fmt.Errorf("unsupported authentication scheme %d", data[0])
+	}
+
+	var decoded extensionData
+	if err := rlp.DecodeBytes(data[1:], &decoded); err != nil {
+		return nil, nil, fmt.Errorf("invalid extension data: %w", err)
+	}
+
+	return decoded.AuthenticatorData, decoded.ClientDataJson, nil
+}
+
+// SignedData checks that the assertion data signs the domain tagged message, and returns the data signed
+// by the authenticator: the authenticator data followed by the SHA2-256 hash of the client data JSON.
+//
+// The client data must be of type TypeGet, its challenge must be the SHA2-256 hash of the domain tagged
+// message, and the authenticator data must have the user presence flag set.
+func SignedData(authenticatorData []byte, clientDataJSON []byte, taggedMessage []byte) ([]byte, error) {
+	var clientData ClientData
+	if err := json.Unmarshal(clientDataJSON, &clientData); err != nil {
+		return nil, fmt.Errorf("invalid client data: %w", err)
+	}
+	if clientData.Type != TypeGet {
+		return nil, fmt.Errorf("invalid client data type %q", clientData.Type)
+	}
+
+	challenge, err := base64.RawURLEncoding.DecodeString(clientData.Challenge)
+	if err != nil {
+		return nil, fmt.Errorf("invalid challenge encoding: %w", err)
+	}
+	if !bytes.Equal(challenge, crypto.NewSHA2_256().ComputeHash(taggedMessage)) {
+		return nil, errors.New("challenge does not match the transaction message")
+	}
+
+	if len(authenticatorData) < authenticatorDataMinLength {
+		return nil, errors.New("authenticator data is too short")
+	}
+	if authenticatorData[32]&flagUserPresent == 0 {
+		return nil, errors.New("user presence flag is not set")
+	}
+
+	clientDataHash := crypto.NewSHA2_256().ComputeHash(clientDataJSON)
+
+	signed := make([]byte, 0, len(authenticatorData)+len(clientDataHash))
+	signed = append(signed, authenticatorData...)
+	return append(signed, clientDataHash...), nil
+}
@@ -0,0 +1,206 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// KeySigner is a signer of an account key, with the weight of the key.
+type KeySigner struct {
+	KeyIndex uint32
+	Signer   crypto.Signer
+	Weight   int
+	Revoked  bool
+}
+
+// MultiSigner signs transactions for an account with several keys, e.g. a multisig account whose keys
+// each have a fraction of AccountKeyWeightThreshold.
+type MultiSigner struct {
+	Address Address
+	Keys    []KeySigner
+}
+
+// NewMultiSigner returns a signer for the account with a copy of the key signers.
+func NewMultiSigner(address Address, keys ...KeySigner) *MultiSigner {
+	return &MultiSigner{
+		Address: address,
+		Keys:    append([]KeySigner(nil), keys...),
+	}
+}
+
+// UpdateKeys updates the weights and revocation status of the key signers from the keys of the account,
+// typically obtained from GetAccountKeysAtLatestBlock.
+//
+// An error is returned if a key signer has no account key.
+func (m *MultiSigner) UpdateKeys(accountKeys []*AccountKey) error {
+	keys := make([]*AccountKey, len(m.Keys))
+	for i := range m.Keys {
+		keys[i] = findAccountKey(accountKeys, m.Keys[i].KeyIndex)
+		if keys[i] == nil {
+			return fmt.Errorf("%w: %s key %d", ErrMissingAccountKey, m.Address, m.Keys[i].KeyIndex)
+		}
+	}
+
+	for i, key := range keys {
+		m.Keys[i].Weight = key.Weight
+		m.Keys[i].Revoked = key.Revoked
+	}
+	return nil
+}
+
+// SelectKeys returns a minimal set of keys which are not revoked, and whose weights reach AccountKeyWeightThreshold.
+//
+// Keys with higher weights are selected first. If the required key is not nil, it is always selected,
+// e.g. the proposal key of a transaction.
+func (m *MultiSigner) SelectKeys(required *uint32) ([]KeySigner, error) {
+	candidates := make([]KeySigner, 0, len(m.Keys))
+	for _, key := range m.Keys {
+		if !key.Revoked {
+			candidates = append(candidates, key)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Weight > candidates[j].Weight
+	})
+
+	var selected []KeySigner
+	weight := 0
+
+	if required != nil {
+		key, ok := m.key(*required)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s key %d", ErrMissingAccountKey, m.Address, *required)
+		}
+		if key.Revoked {
+			return nil, fmt.Errorf("%w: %s key %d", ErrRevokedAccountKey, m.Address, *required)
+		}
+		selected = append(selected, key)
+		weight += key.Weight
+	}
+
+	for _, key := range candidates {
+		if weight >= AccountKeyWeightThreshold {
+			break
+		}
+		if required != nil && key.KeyIndex == *required {
+			continue
+		}
+		selected = append(selected, key)
+		weight += key.Weight
+	}
+
+	if weight < AccountKeyWeightThreshold {
+		return nil, fmt.Errorf(
+			"%w: keys of account %s have weight %d, required %d",
+			ErrInsufficientKeyWeight,
+			m.Address,
+			weight,
+			AccountKeyWeightThreshold,
+		)
+	}
+
+	return selected, nil
+}
+
+func (m *MultiSigner) key(index uint32) (KeySigner, bool) {
+	for _, key := range m.Keys {
+		if key.KeyIndex == index {
+			return key, true
+		}
+	}
+	return KeySigner{}, false
+}
+
+// Sign adds all signatures the account must provide to the transaction, according to its roles:
+//
+//   - as payer, it signs the envelope with keys reaching AccountKeyWeightThreshold;
+//   - as authorizer, it signs the payload with keys reaching AccountKeyWeightThreshold;
+//   - as proposer only, it signs the payload with the proposal key.
+//
+// The proposal key is always included if the account is the proposer. The payer signs the envelope,
+// so Sign must be called for the payer after all other signers.
+func (m *MultiSigner) Sign(tx *Transaction) error {
+	isPayer := tx.Payer == m.Address
+	isProposer := tx.ProposalKey.Address == m.Address
+
+	isAuthorizer := false
+	for _, authorizer := range tx.Authorizers {
+		if authorizer == m.Address {
+			isAuthorizer = true
+		}
+	}
+
+	switch {
+	case isPayer:
+		return m.SignEnvelope(tx)
+	case isAuthorizer:
+		return m.SignPayload(tx)
+	case isProposer:
+		key, ok := m.key(tx.ProposalKey.KeyIndex)
+		if !ok {
+			return fmt.Errorf("%w: %s key %d", ErrMissingAccountKey, m.Address, tx.ProposalKey.KeyIndex)
+		}
+		return tx.SignPayload(m.Address, key.KeyIndex, key.Signer)
+	default:
+		return fmt.Errorf("account %s is not a signer of the transaction", m.Address)
+	}
+}
+
+// SignPayload signs the transaction payload with a minimal set of keys reaching AccountKeyWeightThreshold.
+func (m *MultiSigner) SignPayload(tx *Transaction) error {
+	keys, err := m.SelectKeys(m.proposalKey(tx))
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := tx.SignPayload(m.Address, key.KeyIndex, key.Signer); err != nil {
+			return fmt.Errorf("failed to sign payload with %s key %d: %w", m.Address, key.KeyIndex, err)
+		}
+	}
+	return nil
+}
+
+// SignEnvelope signs the transaction envelope with a minimal set of keys reaching AccountKeyWeightThreshold.
+func (m *MultiSigner) SignEnvelope(tx *Transaction) error {
+	keys, err := m.SelectKeys(m.proposalKey(tx))
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := tx.SignEnvelope(m.Address, key.KeyIndex, key.Signer); err != nil {
+			return fmt.Errorf("failed to sign envelope with %s key %d: %w", m.Address, key.KeyIndex, err)
+		}
+	}
+	return nil
+}
+
+// proposalKey returns the index of the proposal key if the account is the proposer.
+func (m *MultiSigner) proposalKey(tx *Transaction) *uint32 {
+	if tx.ProposalKey.Address != m.Address {
+		return nil
+	}
+	index := tx.ProposalKey.KeyIndex
+	return &index
+}
@@ -0,0 +1,143 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestMultiSigner(t *testing.T) {
+	addresses := test.AddressGenerator()
+	treasury := addresses.New()
+	payer := addresses.New()
+
+	keys := test.AccountKeyGenerator()
+
+	var (
+		accountKeys []*flow.AccountKey
+		keySigners  []flow.KeySigner
+	)
+	for _, weight := range []int{250, 500, 250, 500} {
+		key, signer := keys.NewWithSigner()
+		key.Weight = weight
+		accountKeys = append(accountKeys, key)
+		keySigners = append(keySigners, flow.KeySigner{KeyIndex: key.Index, Signer: signer})
+	}
+	payerKey, payerSigner := keys.NewWithSigner()
+
+	multiSigner := flow.NewMultiSigner(treasury, keySigners...)
+	require.NoError(t, multiSigner.UpdateKeys(accountKeys))
+
+	payerSigners := flow.NewMultiSigner(payer, flow.KeySigner{KeyIndex: payerKey.Index, Signer: payerSigner})
+	require.NoError(t, payerSigners.UpdateKeys([]*flow.AccountKey{payerKey}))
+
+	allKeys := map[flow.Address][]*flow.AccountKey{
+		treasury: accountKeys,
+		payer:    {payerKey},
+	}
+
+	newTx := func(proposalKey uint32) *flow.Transaction {
+		return flow.NewTransaction().
+			SetScript([]byte(`transaction { prepare(signer: &Account) {} }`)).
+			SetReferenceBlockID(flow.Identifier{0x01}).
+			SetProposalKey(treasury, proposalKey, 1).
+			SetPayer(payer).
+			AddAuthorizer(treasury)
+	}
+
+	t.Run("Select keys", func(t *testing.T) {
+		selected, err := multiSigner.SelectKeys(nil)
+		require.NoError(t, err)
+		require.Len(t, selected, 2)
+		assert.Equal(t, accountKeys[1].Index, selected[0].KeyIndex)
+		assert.Equal(t, accountKeys[3].Index, selected[1].KeyIndex)
+
+		required := accountKeys[0].Index
+		selected, err = multiSigner.SelectKeys(&required)
+		require.NoError(t, err)
+		require.Len(t, selected, 3)
+		assert.Equal(t, required, selected[0].KeyIndex)
+	})
+
+	t.Run("Sign", func(t *testing.T) {
+		tx := newTx(accountKeys[2].Index)
+		require.NoError(t, multiSigner.Sign(tx))
+		require.NoError(t, payerSigners.Sign(tx))
+
+		assert.Len(t, tx.PayloadSignatures, 3)
+		assert.Len(t, tx.EnvelopeSignatures, 1)
+
+		report, err := flow.VerifyTransactionSignatures(tx, allKeys)
+		require.NoError(t, err)
+		assert.NoError(t, report.Err())
+	})
+
+	t.Run("Payer", func(t *testing.T) {
+		tx := newTx(accountKeys[0].Index).SetPayer(treasury)
+		require.NoError(t, multiSigner.Sign(tx))
+
+		assert.Empty(t, tx.PayloadSignatures)
+		assert.Len(t, tx.EnvelopeSignatures, 3)
+
+		report, err := flow.VerifyTransactionSignatures(tx, allKeys)
+		require.NoError(t, err)
+		assert.NoError(t, report.Err())
+	})
+
+	t.Run("Revoked keys", func(t *testing.T) {
+		revokedSigners := append([]flow.KeySigner(nil), keySigners...)
+		revoked := flow.NewMultiSigner(treasury, revokedSigners...)
+		require.NoError(t, revoked.UpdateKeys(accountKeys))
+		revoked.Keys[1].Revoked = true
+		assert.False(t, revokedSigners[1].Revoked)
+		assert.False(t, multiSigner.Keys[1].Revoked)
+
+		selected, err := revoked.SelectKeys(nil)
+		require.NoError(t, err)
+		assert.Len(t, selected, 3)
+		for _, key := range selected {
+			assert.NotEqual(t, accountKeys[1].Index, key.KeyIndex)
+		}
+
+		revoked.Keys[3].Revoked = true
+		_, err = revoked.SelectKeys(nil)
+		assert.ErrorIs(t, err, flow.ErrInsufficientKeyWeight)
+
+		required := accountKeys[1].Index
+		_, err = revoked.SelectKeys(&required)
+		assert.ErrorIs(t, err, flow.ErrRevokedAccountKey)
+	})
+
+	t.Run("Not a signer", func(t *testing.T) {
+		tx := newTx(accountKeys[0].Index)
+		tx.ProposalKey.Address = payer
+		tx.Authorizers = nil
+		assert.Error(t, flow.NewMultiSigner(addresses.New()).Sign(tx))
+	})
+
+	t.Run("Missing account key", func(t *testing.T) {
+		assert.ErrorIs(t, multiSigner.UpdateKeys(accountKeys[:1]), flow.ErrMissingAccountKey)
+	})
+}
@@ -21,7 +21,6 @@ package templates
 import (
 	"encoding/hex"
 	"fmt"
-	"strings"
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/common"
@@ -33,6 +32,7 @@ import (
 	"github.com/onflow/flow-go-sdk/crypto"
 
 	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/imports"
 )
 
 // Contract is a Cadence contract deployed to a Flow account.
@@ -186,6 +186,11 @@ func CreateAccount(accountKeys []*flow.AccountKey, contracts []Contract, payer f
 	return CreateAccountAndFund(accountKeys, contracts, payer, "", "")
 }
 
+// CreateAccountAndFund generates a transaction that creates a new account, like CreateAccount,
+// and funds it with the given amount of FLOW from the payer.
+//
+// The amount is a UFix64 string, e.g. "1.5". If it is empty, the account is not funded.
+// The network determines the addresses of the FlowToken and FungibleToken contracts.
 func CreateAccountAndFund(
 	accountKeys []*flow.AccountKey,
 	contracts []Contract,
@@ -221,28 +226,19 @@ func CreateAccountAndFund(
 		jsoncdc.MustEncode(cadenceContracts),
 	}
 
-	// if we have provided amount and network then we do funding as well
-	if amount != "" && network == flow.Mainnet || network == flow.Testnet {
-		script = templates.CreateAccountFunding
+	// if we have provided an amount then we do funding as well
+	if amount != "" {
+		resolver, err := imports.NewNetworkResolver(network)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create funded CreateAccount transaction: %w", err)
+		}
+
 		// replace the imports on supported networks
-		replaces := map[flow.ChainID]map[string]string{
-			flow.Testnet: {
-				"FlowToken":     "0x7e60df042a9c0868", // https://developers.flow.com/flow/core-contracts/flow-token
-				"FungibleToken": "0x9a0766d93b6608b7", // https://developers.flow.com/flow/core-contracts/fungible-token
-			},
-			flow.Mainnet: {
-				"FlowToken":     "0x1654653399040a61",
-				"FungibleToken": "0xf233dcee88fe0abe",
-			},
-		}[network]
-
-		for contract, address := range replaces {
-			script = strings.ReplaceAll(
-				script,
-				fmt.Sprintf(`"%s"`, contract),
-				fmt.Sprintf(`%s from %s`, contract, address),
-			)
+		resolved, err := resolver.Resolve([]byte(templates.CreateAccountFunding))
+		if err != nil {
+			return nil, fmt.Errorf("cannot create funded CreateAccount transaction: %w", err)
 		}
+		script = string(resolved)
 
 		val, err := cadence.NewUFix64(amount)
 		if err != nil {
@@ -55,3 +55,29 @@ func TestCreateAccount(t *testing.T) {
 				"2 times the contract code (converted to hex) + 500 bytes of extra data.")
 	})
 }
+
+func TestCreateAccountAndFund(t *testing.T) {
+	payer := flow.HexToAddress("01")
+
+	t.Run("Emulator", func(t *testing.T) {
+		tx, err := templates.CreateAccountAndFund(nil, nil, payer, "10.0", flow.Emulator)
+		require.NoError(t, err)
+
+		require.Contains(t, string(tx.Script), "import FlowToken from 0x0ae53cb6e3f42a79")
+		require.Contains(t, string(tx.Script), "import FungibleToken from 0xee82856bf20e2aa6")
+		require.Len(t, tx.Arguments, 3)
+	})
+
+	t.Run("Testnet without amount", func(t *testing.T) {
+		tx, err := templates.CreateAccountAndFund(nil, nil, payer, "", flow.Testnet)
+		require.NoError(t, err)
+
+		require.NotContains(t, string(tx.Script), "FlowToken")
+		require.Len(t, tx.Arguments, 2)
+	})
+
+	t.Run("Unsupported network", func(t *testing.T) {
+		_, err := templates.CreateAccountAndFund(nil, nil, payer, "10.0", flow.ChainID("flow-unknown"))
+		require.ErrorIs(t, err, flow.ErrUnknownContract)
+	})
+}
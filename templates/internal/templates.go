@@ -0,0 +1,50 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/imports"
+)
+
+// ResolveTemplate resolves the imports of a template to the core contracts of the network.
+func ResolveTemplate(network flow.ChainID, template []byte) ([]byte, error) {
+	resolver, err := imports.NewNetworkResolver(network)
+	if err != nil {
+		return nil, err
+	}
+	return resolver.Resolve(template)
+}
+
+// NewTransaction returns a transaction executing the script with the JSON-CDC encoded arguments,
+// authorized by the authorizer.
+func NewTransaction(script []byte, args []cadence.Value, authorizer flow.Address) *flow.Transaction {
+	tx := flow.NewTransaction().
+		SetScript(script).
+		AddAuthorizer(authorizer)
+
+	for _, arg := range args {
+		tx.AddRawArgument(jsoncdc.MustEncode(arg))
+	}
+
+	return tx
+}
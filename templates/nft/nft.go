@@ -0,0 +1,210 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package nft provides transaction and script templates for contracts implementing the
+// NonFungibleToken standard, and decoders for the MetadataViews they resolve.
+//
+// The templates identify an NFT contract by a templates.Contract, e.g. the contract deployed with
+// templates.AddAccountContract, of which only the name is used. Its address is resolved from a contract registry,
+// e.g. the aliases loaded from flow.json with imports.LoadFlowJSON, or else from the core contracts of
+// the network. The storage and public paths of collections are resolved on-chain from the NFTCollectionData
+// view of the contract, so the same templates work for every NFT contract implementing the standard.
+package nft
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/templates"
+	"github.com/onflow/flow-go-sdk/templates/internal"
+)
+
+//go:embed cadence/setup_collection.cdc
+var setupCollectionTemplate []byte
+
+//go:embed cadence/transfer_nft.cdc
+var transferTemplate []byte
+
+//go:embed cadence/batch_transfer_nft.cdc
+var batchTransferTemplate []byte
+
+//go:embed cadence/get_ids.cdc
+var getIDsTemplate []byte
+
+//go:embed cadence/get_views.cdc
+var getViewsTemplate []byte
+
+// contractArguments returns the address and name arguments of the NFT contract. The address is resolved
+// from the contracts, or else from the core contracts of the network.
+func contractArguments(
+	network flow.ChainID,
+	contracts flow.ContractAddresses,
+	contract templates.Contract,
+) ([]cadence.Value, error) {
+	address, err := contracts.Address(contract.Name)
+	if errors.Is(err, flow.ErrUnknownContract) {
+		address, err = flow.CoreContractAddress(network, contract.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return []cadence.Value{
+		cadence.NewAddress(address),
+		cadence.String(contract.Name),
+	}, nil
+}
+
+// SetupCollection generates a transaction that stores an empty collection of the NFT contract in the account,
+// and publishes its public capability.
+//
+// The transaction does nothing if the account already stores a collection at the storage path.
+func SetupCollection(
+	network flow.ChainID,
+	contracts flow.ContractAddresses,
+	contract templates.Contract,
+	account flow.Address,
+) (*flow.Transaction, error) {
+	script, args, err := resolve(network, contracts, contract, setupCollectionTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create SetupCollection transaction: %w", err)
+	}
+
+	return internal.NewTransaction(script, args, account), nil
+}
+
+// Transfer generates a transaction that transfers the NFT with the given ID of the NFT contract
+// from the sender to the recipient.
+//
+// The sender is added as the transaction authorizer.
+func Transfer(
+	network flow.ChainID,
+	contracts flow.ContractAddresses,
+	contract templates.Contract,
+	sender flow.Address,
+	recipient flow.Address,
+	id uint64,
+) (*flow.Transaction, error) {
+	script, args, err := resolve(network, contracts, contract, transferTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create Transfer transaction: %w", err)
+	}
+
+	args = append(args, cadence.NewAddress(recipient), cadence.UInt64(id))
+
+	return internal.NewTransaction(script, args, sender), nil
+}
+
+// BatchTransfer generates a transaction that transfers the NFTs with the given IDs of the NFT contract
+// from the sender to the recipient.
+//
+// The sender is added as the transaction authorizer.
+func BatchTransfer(
+	network flow.ChainID,
+	contracts flow.ContractAddresses,
+	contract templates.Contract,
+	sender flow.Address,
+	recipient flow.Address,
+	ids []uint64,
+) (*flow.Transaction, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("cannot create BatchTransfer transaction: no NFT IDs")
+	}
+
+	script, args, err := resolve(network, contracts, contract, batchTransferTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create BatchTransfer transaction: %w", err)
+	}
+
+	args = append(args, cadence.NewAddress(recipient), uint64Array(ids))
+
+	return internal.NewTransaction(script, args, sender), nil
+}
+
+// GetIDs generates a script that returns the IDs of the NFTs in the collection of the NFT contract
+// in the account, and the arguments to execute it with.
+//
+// The result of the script can be decoded with DecodeIDs.
+func GetIDs(
+	network flow.ChainID,
+	contracts flow.ContractAddresses,
+	contract templates.Contract,
+	account flow.Address,
+) ([]byte, []cadence.Value, error) {
+	script, args, err := resolve(network, contracts, contract, getIDsTemplate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot create GetIDs script: %w", err)
+	}
+
+	return script, append([]cadence.Value{cadence.NewAddress(account)}, args...), nil
+}
+
+// GetViews generates a script that returns the Display, Royalties and NFTCollectionData views of the NFT
+// with the given ID in the collection of the NFT contract in the account, and the arguments to execute it with.
+//
+// The result of the script can be decoded with DecodeViews.
+func GetViews(
+	network flow.ChainID,
+	contracts flow.ContractAddresses,
+	contract templates.Contract,
+	account flow.Address,
+	id uint64,
+) ([]byte, []cadence.Value, error) {
+	script, args, err := resolve(network, contracts, contract, getViewsTemplate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot create GetViews script: %w", err)
+	}
+
+	args = append([]cadence.Value{cadence.NewAddress(account)}, args...)
+	args = append(args, cadence.UInt64(id))
+
+	return script, args, nil
+}
+
+// resolve resolves the imports of a template to the core contracts of the network,
+// and returns the arguments identifying the NFT contract.
+func resolve(
+	network flow.ChainID,
+	contracts flow.ContractAddresses,
+	contract templates.Contract,
+	template []byte,
+) ([]byte, []cadence.Value, error) {
+	script, err := internal.ResolveTemplate(network, template)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	args, err := contractArguments(network, contracts, contract)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return script, args, nil
+}
+
+func uint64Array(values []uint64) cadence.Array {
+	array := make([]cadence.Value, len(values))
+	for i, value := range values {
+		array[i] = cadence.UInt64(value)
+	}
+	return cadence.NewArray(array).WithType(cadence.NewVariableSizedArrayType(cadence.UInt64Type))
+}
@@ -0,0 +1,188 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package nft_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/templates"
+	"github.com/onflow/flow-go-sdk/templates/nft"
+)
+
+var (
+	contractAddress = flow.HexToAddress("0x0000000000000003")
+	contract        = templates.Contract{Name: "ExampleNFT", Source: "access(all) contract ExampleNFT {}"}
+	contracts       = flow.ContractAddresses{contract.Name: contractAddress}
+)
+
+// requireValidTransaction checks that the transaction script parses and its arguments match its parameters.
+func requireValidTransaction(t *testing.T, tx *flow.Transaction) {
+	for _, diagnostic := range tx.Validate(flow.WithoutSignatureChecks()) {
+		switch diagnostic.Code {
+		case flow.DiagnosticInvalidScript,
+			flow.DiagnosticArgumentCountMismatch,
+			flow.DiagnosticInvalidArgument,
+			flow.DiagnosticArgumentTypeMismatch,
+			flow.DiagnosticAuthorizerCountMismatch:
+			require.NoError(t, diagnostic)
+		}
+	}
+}
+
+func TestTransactions(t *testing.T) {
+	sender := flow.HexToAddress("01")
+	recipient := flow.HexToAddress("02")
+
+	setup, err := nft.SetupCollection(flow.Mainnet, contracts, contract, sender)
+	require.NoError(t, err)
+	requireValidTransaction(t, setup)
+	assert.Contains(t, string(setup.Script), "import NonFungibleToken from 0x1d7e57aa55817448")
+
+	transfer, err := nft.Transfer(flow.Mainnet, contracts, contract, sender, recipient, 42)
+	require.NoError(t, err)
+	requireValidTransaction(t, transfer)
+
+	id, err := transfer.Argument(3)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.UInt64(42), id)
+
+	batch, err := nft.BatchTransfer(flow.Testnet, contracts, contract, sender, recipient, []uint64{1, 2, 3})
+	require.NoError(t, err)
+	requireValidTransaction(t, batch)
+	assert.Equal(t, []flow.Address{sender}, batch.Authorizers)
+
+	_, err = nft.BatchTransfer(flow.Testnet, contracts, contract, sender, recipient, nil)
+	require.Error(t, err)
+
+	address, err := setup.Argument(0)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewAddress(contractAddress), address)
+
+	// the deployed contract identifies the NFT contract
+	deploy := templates.AddAccountContract(contractAddress, contract)
+	name, err := deploy.Argument(0)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.String(contract.Name), name)
+
+	_, err = nft.SetupCollection(flow.Mainnet, nil, contract, sender)
+	assert.ErrorIs(t, err, flow.ErrUnknownContract)
+}
+
+func TestScripts(t *testing.T) {
+	account := flow.HexToAddress("01")
+
+	script, args, err := nft.GetIDs(flow.Emulator, contracts, contract, account)
+	require.NoError(t, err)
+	assert.Contains(t, string(script), "import MetadataViews from 0xf8d6e0586b0a20c7")
+	assert.Len(t, args, 3)
+
+	script, args, err = nft.GetViews(flow.Emulator, contracts, contract, account, 7)
+	require.NoError(t, err)
+	assert.Contains(t, string(script), "MetadataViews.getDisplay")
+	assert.Equal(t, cadence.UInt64(7), args[3])
+
+	ids, err := nft.DecodeIDs(cadence.NewArray([]cadence.Value{cadence.UInt64(1), cadence.UInt64(2)}))
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2}, ids)
+}
+
+func newStruct(name string, fields map[string]cadence.Value) cadence.Struct {
+	typeFields := make([]cadence.Field, 0, len(fields))
+	values := make([]cadence.Value, 0, len(fields))
+	for identifier, value := range fields {
+		typeFields = append(typeFields, cadence.Field{Identifier: identifier, Type: cadence.AnyStructType})
+		values = append(values, value)
+	}
+	return cadence.NewStruct(values).WithType(cadence.NewStructType(nil, name, typeFields, nil))
+}
+
+func TestDecodeViews(t *testing.T) {
+	storagePath, err := cadence.NewPath(common.PathDomainStorage, "exampleNFTCollection")
+	require.NoError(t, err)
+	publicPath, err := cadence.NewPath(common.PathDomainPublic, "exampleNFTCollection")
+	require.NoError(t, err)
+
+	royaltyReceiver := flow.HexToAddress("04")
+
+	value := newStruct("NFTViews", map[string]cadence.Value{
+		"id": cadence.UInt64(7),
+		"display": cadence.NewOptional(newStruct("MetadataViews.Display", map[string]cadence.Value{
+			"name":        cadence.String("Example"),
+			"description": cadence.String("An example NFT"),
+			"thumbnail": newStruct("MetadataViews.IPFSFile", map[string]cadence.Value{
+				"cid":  cadence.String("bafy"),
+				"path": cadence.NewOptional(cadence.String("image.png")),
+			}),
+		})),
+		"royalties": cadence.NewOptional(newStruct("MetadataViews.Royalties", map[string]cadence.Value{
+			"cutInfos": cadence.NewArray([]cadence.Value{
+				newStruct("MetadataViews.Royalty", map[string]cadence.Value{
+					"receiver":    cadence.NewCapability(1, cadence.NewAddress(royaltyReceiver), nil),
+					"cut":         cadence.UFix64(5_000000),
+					"description": cadence.String("Creator"),
+				}),
+			}),
+		})),
+		"collectionData": cadence.NewOptional(newStruct("CollectionData", map[string]cadence.Value{
+			"storagePath":      storagePath,
+			"publicPath":       publicPath,
+			"publicCollection": cadence.NewTypeValue(cadence.AnyStructType),
+			"publicLinkedType": cadence.NewTypeValue(cadence.AnyStructType),
+		})),
+	})
+
+	views, err := nft.DecodeViews(value)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(7), views.ID)
+	require.NotNil(t, views.Display)
+	assert.Equal(t, "Example", views.Display.Name)
+	assert.Equal(t, "ipfs://bafy/image.png", views.Display.Thumbnail.URI())
+	assert.Equal(t, []nft.Royalty{{Receiver: royaltyReceiver, Cut: 5_000000, Description: "Creator"}}, views.Royalties)
+	require.NotNil(t, views.CollectionData)
+	assert.Equal(t, storagePath, views.CollectionData.StoragePath)
+	assert.Equal(t, cadence.AnyStructType, views.CollectionData.PublicLinkedType)
+
+	t.Run("Missing views", func(t *testing.T) {
+		views, err := nft.DecodeViews(newStruct("NFTViews", map[string]cadence.Value{
+			"id":             cadence.UInt64(1),
+			"display":        cadence.NewOptional(nil),
+			"royalties":      cadence.NewOptional(nil),
+			"collectionData": cadence.NewOptional(nil),
+		}))
+		require.NoError(t, err)
+		assert.Nil(t, views.Display)
+		assert.Nil(t, views.Royalties)
+		assert.Nil(t, views.CollectionData)
+	})
+
+	t.Run("HTTP file", func(t *testing.T) {
+		file, err := nft.DecodeFile(newStruct("MetadataViews.HTTPFile", map[string]cadence.Value{
+			"url": cadence.String("https://example.com/1.png"),
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/1.png", file.URI())
+	})
+}
@@ -0,0 +1,262 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package nft
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// Views are the metadata views of an NFT, as returned by the script generated by GetViews.
+//
+// Views which are not resolved by the NFT are nil.
+type Views struct {
+	ID             uint64
+	Display        *Display
+	Royalties      []Royalty
+	CollectionData *CollectionData
+}
+
+// Display is the MetadataViews.Display view of an NFT.
+type Display struct {
+	Name        string
+	Description string
+	Thumbnail   File
+}
+
+// File is a MetadataViews.File, either a MetadataViews.HTTPFile or a MetadataViews.IPFSFile.
+type File struct {
+	// URL is the URL of an HTTP file.
+	URL string
+	// CID is the content identifier of an IPFS file.
+	CID string
+	// Path is the optional path of an IPFS file within the directory identified by the CID.
+	Path string
+}
+
+// URI returns the URI of the file, i.e. the URL of an HTTP file, or an ipfs:// URI of an IPFS file.
+func (f File) URI() string {
+	if f.CID == "" {
+		return f.URL
+	}
+	if f.Path == "" {
+		return "ipfs://" + f.CID
+	}
+	return "ipfs://" + f.CID + "/" + strings.TrimPrefix(f.Path, "/")
+}
+
+// Royalty is a MetadataViews.Royalty of an NFT.
+type Royalty struct {
+	// Receiver is the address of the account of the receiver capability.
+	Receiver flow.Address
+	// Cut is the fraction of a sale paid to the receiver, e.g. 0.05 for 5%.
+	Cut         cadence.UFix64
+	Description string
+}
+
+// CollectionData is the subset of the MetadataViews.NFTCollectionData view of an NFT which can be
+// returned by a script.
+type CollectionData struct {
+	StoragePath      cadence.Path
+	PublicPath       cadence.Path
+	PublicCollection cadence.Type
+	PublicLinkedType cadence.Type
+}
+
+// DecodeIDs decodes the result of the script generated by GetIDs.
+func DecodeIDs(value cadence.Value) ([]uint64, error) {
+	array, ok := value.(cadence.Array)
+	if !ok {
+		return nil, fmt.Errorf("invalid IDs: expected array, got %s", value)
+	}
+
+	ids := make([]uint64, len(array.Values))
+	for i, element := range array.Values {
+		id, ok := element.(cadence.UInt64)
+		if !ok {
+			return nil, fmt.Errorf("invalid ID at index %d: expected UInt64, got %s", i, element)
+		}
+		ids[i] = uint64(id)
+	}
+	return ids, nil
+}
+
+// DecodeViews decodes the result of the script generated by GetViews.
+func DecodeViews(value cadence.Value) (*Views, error) {
+	composite, ok := value.(cadence.Struct)
+	if !ok {
+		return nil, fmt.Errorf("invalid views: expected struct, got %s", value)
+	}
+	fields := cadence.FieldsMappedByName(composite)
+
+	id, ok := fields["id"].(cadence.UInt64)
+	if !ok {
+		return nil, fmt.Errorf("invalid views: missing id")
+	}
+	views := &Views{ID: uint64(id)}
+
+	var err error
+
+	if display := optionalValue(fields["display"]); display != nil {
+		views.Display, err = DecodeDisplay(display)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if royalties := optionalValue(fields["royalties"]); royalties != nil {
+		views.Royalties, err = DecodeRoyalties(royalties)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if collectionData := optionalValue(fields["collectionData"]); collectionData != nil {
+		views.CollectionData, err = decodeCollectionData(collectionData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return views, nil
+}
+
+// DecodeDisplay decodes a MetadataViews.Display value.
+func DecodeDisplay(value cadence.Value) (*Display, error) {
+	composite, ok := value.(cadence.Struct)
+	if !ok {
+		return nil, fmt.Errorf("invalid display: expected struct, got %s", value)
+	}
+	fields := cadence.FieldsMappedByName(composite)
+
+	name, nameOk := fields["name"].(cadence.String)
+	description, descriptionOk := fields["description"].(cadence.String)
+	if !nameOk || !descriptionOk {
+		return nil, fmt.Errorf("invalid display: missing name or description")
+	}
+
+	thumbnail, err := DecodeFile(fields["thumbnail"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid display thumbnail: %w", err)
+	}
+
+	return &Display{
+		Name:        string(name),
+		Description: string(description),
+		Thumbnail:   thumbnail,
+	}, nil
+}
+
+// DecodeFile decodes a MetadataViews.HTTPFile or MetadataViews.IPFSFile value.
+func DecodeFile(value cadence.Value) (File, error) {
+	composite, ok := value.(cadence.Struct)
+	if !ok {
+		return File{}, fmt.Errorf("invalid file: expected struct, got %s", value)
+	}
+	fields := cadence.FieldsMappedByName(composite)
+
+	if url, ok := fields["url"].(cadence.String); ok {
+		return File{URL: string(url)}, nil
+	}
+
+	cid, ok := fields["cid"].(cadence.String)
+	if !ok {
+		return File{}, fmt.Errorf("invalid file: missing url or cid")
+	}
+
+	file := File{CID: string(cid)}
+	if path, ok := optionalValue(fields["path"]).(cadence.String); ok {
+		file.Path = string(path)
+	}
+	return file, nil
+}
+
+// DecodeRoyalties decodes a MetadataViews.Royalties value.
+func DecodeRoyalties(value cadence.Value) ([]Royalty, error) {
+	composite, ok := value.(cadence.Struct)
+	if !ok {
+		return nil, fmt.Errorf("invalid royalties: expected struct, got %s", value)
+	}
+
+	cutInfos, ok := cadence.SearchFieldByName(composite, "cutInfos").(cadence.Array)
+	if !ok {
+		return nil, fmt.Errorf("invalid royalties: missing cutInfos")
+	}
+
+	royalties := make([]Royalty, len(cutInfos.Values))
+	for i, element := range cutInfos.Values {
+		royalty, ok := element.(cadence.Struct)
+		if !ok {
+			return nil, fmt.Errorf("invalid royalty at index %d: expected struct, got %s", i, element)
+		}
+		fields := cadence.FieldsMappedByName(royalty)
+
+		receiver, receiverOk := fields["receiver"].(cadence.Capability)
+		cut, cutOk := fields["cut"].(cadence.UFix64)
+		description, descriptionOk := fields["description"].(cadence.String)
+		if !receiverOk || !cutOk || !descriptionOk {
+			return nil, fmt.Errorf("invalid royalty at index %d: missing receiver, cut or description", i)
+		}
+
+		royalties[i] = Royalty{
+			Receiver:    flow.BytesToAddress(receiver.Address.Bytes()),
+			Cut:         cut,
+			Description: string(description),
+		}
+	}
+	return royalties, nil
+}
+
+func decodeCollectionData(value cadence.Value) (*CollectionData, error) {
+	composite, ok := value.(cadence.Struct)
+	if !ok {
+		return nil, fmt.Errorf("invalid collection data: expected struct, got %s", value)
+	}
+	fields := cadence.FieldsMappedByName(composite)
+
+	storagePath, storagePathOk := fields["storagePath"].(cadence.Path)
+	publicPath, publicPathOk := fields["publicPath"].(cadence.Path)
+	if !storagePathOk || !publicPathOk {
+		return nil, fmt.Errorf("invalid collection data: missing paths")
+	}
+
+	data := &CollectionData{
+		StoragePath: storagePath,
+		PublicPath:  publicPath,
+	}
+	if publicCollection, ok := fields["publicCollection"].(cadence.TypeValue); ok {
+		data.PublicCollection = publicCollection.StaticType
+	}
+	if publicLinkedType, ok := fields["publicLinkedType"].(cadence.TypeValue); ok {
+		data.PublicLinkedType = publicLinkedType.StaticType
+	}
+	return data, nil
+}
+
+// optionalValue returns the inner value of an optional, or the value itself if it is not an optional.
+func optionalValue(value cadence.Value) cadence.Value {
+	if optional, ok := value.(cadence.Optional); ok {
+		return optional.Value
+	}
+	return value
+}
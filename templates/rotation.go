@@ -0,0 +1,243 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+	"github.com/onflow/flow-go-sdk/templates/internal"
+)
+
+//go:embed cadence/rotate_keys.cdc
+var rotateKeysTemplate []byte
+
+// ErrInsufficientKeyWeight is returned when a key rotation would leave an account with less active key weight
+// than flow.AccountKeyWeightThreshold, which would lock the account.
+var ErrInsufficientKeyWeight = errors.New("insufficient active key weight after rotation")
+
+// KeyRotation describes a rotation of the keys of an account.
+//
+// The keys in Add are added with their weights, hashing algorithms and public keys. Their indexes are ignored,
+// new keys are assigned the next free indexes of the account. The keys with the indexes in Revoke are revoked.
+type KeyRotation struct {
+	Address flow.Address
+	Add     []*flow.AccountKey
+	Revoke  []uint32
+}
+
+// KeyRotationPlan describes the keys of an account before and after a key rotation.
+type KeyRotationPlan struct {
+	Address flow.Address
+	// Before are the current keys of the account.
+	Before []*flow.AccountKey
+	// After are the keys of the account after the rotation, including revoked keys.
+	After []*flow.AccountKey
+	// Added are the keys added by the rotation, with their expected indexes.
+	Added []*flow.AccountKey
+	// Revoked are the keys revoked by the rotation.
+	Revoked []*flow.AccountKey
+	// ActiveWeightBefore is the total weight of the non-revoked keys before the rotation.
+	ActiveWeightBefore int
+	// ActiveWeightAfter is the total weight of the non-revoked keys after the rotation.
+	ActiveWeightAfter int
+}
+
+// String returns a human-readable description of the plan.
+func (p *KeyRotationPlan) String() string {
+	var b strings.Builder
+
+	added := make(map[uint32]bool, len(p.Added))
+	for _, key := range p.Added {
+		added[key.Index] = true
+	}
+	revoked := make(map[uint32]bool, len(p.Revoked))
+	for _, key := range p.Revoked {
+		revoked[key.Index] = true
+	}
+
+	writeKeys := func(keys []*flow.AccountKey) {
+		for _, key := range keys {
+			fmt.Fprintf(&b, "  #%d %s %s weight %d %s", key.Index, key.SigAlgo, key.HashAlgo, key.Weight, key.PublicKey)
+			switch {
+			case added[key.Index]:
+				b.WriteString(" (added)")
+			case revoked[key.Index]:
+				b.WriteString(" (revoking)")
+			case key.Revoked:
+				b.WriteString(" (revoked)")
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	fmt.Fprintf(&b, "Key rotation of account %s\n", p.Address.HexWithPrefix())
+	fmt.Fprintf(&b, "Before (active weight %d):\n", p.ActiveWeightBefore)
+	writeKeys(p.Before)
+	fmt.Fprintf(&b, "After (active weight %d):\n", p.ActiveWeightAfter)
+	writeKeys(p.After)
+
+	return b.String()
+}
+
+// PlanKeyRotation validates the rotation against the current keys of the account and returns its plan.
+//
+// It returns an error if a key to revoke does not exist or is already revoked, if a key to add is invalid,
+// or if the account would have less active key weight than flow.AccountKeyWeightThreshold after the rotation.
+func PlanKeyRotation(rotation KeyRotation, currentKeys []*flow.AccountKey) (*KeyRotationPlan, error) {
+	if len(rotation.Add) == 0 && len(rotation.Revoke) == 0 {
+		return nil, fmt.Errorf("key rotation adds and revokes no keys")
+	}
+
+	plan := &KeyRotationPlan{
+		Address: rotation.Address,
+		Before:  currentKeys,
+	}
+
+	keys := make(map[uint32]*flow.AccountKey, len(currentKeys))
+	nextIndex := uint32(0)
+	for _, key := range currentKeys {
+		keys[key.Index] = key
+		if key.Index >= nextIndex {
+			nextIndex = key.Index + 1
+		}
+		if !key.Revoked {
+			plan.ActiveWeightBefore += key.Weight
+		}
+	}
+
+	revoking := make(map[uint32]bool, len(rotation.Revoke))
+	for _, index := range rotation.Revoke {
+		key, ok := keys[index]
+		if !ok {
+			return nil, fmt.Errorf("cannot revoke key %d: account %s has no such key", index, rotation.Address)
+		}
+		if key.Revoked {
+			return nil, fmt.Errorf("cannot revoke key %d: key is already revoked", index)
+		}
+		if revoking[index] {
+			return nil, fmt.Errorf("cannot revoke key %d: key is revoked twice", index)
+		}
+		revoking[index] = true
+		plan.Revoked = append(plan.Revoked, key)
+	}
+
+	for _, key := range currentKeys {
+		after := *key
+		if revoking[key.Index] {
+			after.Revoked = true
+		}
+		if !after.Revoked {
+			plan.ActiveWeightAfter += after.Weight
+		}
+		plan.After = append(plan.After, &after)
+	}
+
+	for i, key := range rotation.Add {
+		if key == nil || key.PublicKey == nil {
+			return nil, fmt.Errorf("cannot add key %d: missing public key", i)
+		}
+
+		added := *key
+		added.Index = nextIndex
+		added.SigAlgo = key.PublicKey.Algorithm()
+		added.SequenceNumber = 0
+		added.Revoked = false
+		nextIndex++
+
+		if err := added.Validate(); err != nil {
+			return nil, fmt.Errorf("cannot add key %d: %w", i, err)
+		}
+
+		plan.Added = append(plan.Added, &added)
+		plan.After = append(plan.After, &added)
+		plan.ActiveWeightAfter += added.Weight
+	}
+
+	if plan.ActiveWeightAfter < flow.AccountKeyWeightThreshold {
+		return nil, fmt.Errorf(
+			"%w: account %s would have active key weight %d, at least %d is required",
+			ErrInsufficientKeyWeight,
+			rotation.Address,
+			plan.ActiveWeightAfter,
+			flow.AccountKeyWeightThreshold,
+		)
+	}
+
+	return plan, nil
+}
+
+// RotateAccountKeys generates a transaction that adds and revokes keys of an account in one transaction.
+//
+// The rotation is validated against the keys of the account at the latest sealed block, see PlanKeyRotation.
+// The returned plan describes the keys of the account before and after the rotation.
+// The account is added as the transaction authorizer.
+func RotateAccountKeys(
+	ctx context.Context,
+	client access.Client,
+	rotation KeyRotation,
+) (*flow.Transaction, *KeyRotationPlan, error) {
+	currentKeys, err := client.GetAccountKeysAtLatestBlock(ctx, rotation.Address)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot get keys of account %s: %w", rotation.Address, err)
+	}
+
+	plan, err := PlanKeyRotation(rotation, currentKeys)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot create RotateAccountKeys transaction: %w", err)
+	}
+
+	tx, err := RotateAccountKeysTransaction(plan)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tx, plan, nil
+}
+
+// RotateAccountKeysTransaction generates the transaction executing a key rotation plan.
+func RotateAccountKeysTransaction(plan *KeyRotationPlan) (*flow.Transaction, error) {
+	keys := make([]cadence.Value, len(plan.Added))
+	for i, key := range plan.Added {
+		value, err := AccountKeyToCadenceCryptoKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create RotateAccountKeys transaction: %w", err)
+		}
+		keys[i] = value
+	}
+
+	revoke := make([]cadence.Value, len(plan.Revoked))
+	for i, key := range plan.Revoked {
+		revoke[i] = cadence.NewInt(int(key.Index))
+	}
+
+	args := []cadence.Value{
+		cadence.NewArray(keys),
+		cadence.NewArray(revoke),
+	}
+
+	return internal.NewTransaction(rotateKeysTemplate, args, plan.Address), nil
+}
@@ -0,0 +1,114 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access/mocks"
+	"github.com/onflow/flow-go-sdk/templates"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestRotateAccountKeys(t *testing.T) {
+	address := flow.HexToAddress("01")
+	keys := test.AccountKeyGenerator()
+
+	oldKey := keys.New()
+	oldKey.Index = 0
+	revokedKey := keys.New()
+	revokedKey.Index = 1
+	revokedKey.Revoked = true
+	currentKeys := []*flow.AccountKey{oldKey, revokedKey}
+
+	newKey := keys.New()
+	newKey.Weight = 500
+
+	t.Run("Rotate", func(t *testing.T) {
+		client := mocks.NewClient(t)
+		client.
+			On("GetAccountKeysAtLatestBlock", mock.Anything, address).
+			Return(currentKeys, nil)
+
+		tx, plan, err := templates.RotateAccountKeys(context.Background(), client, templates.KeyRotation{
+			Address: address,
+			Add:     []*flow.AccountKey{newKey, newKey},
+			Revoke:  []uint32{0},
+		})
+		require.NoError(t, err)
+
+		requireValidScript(t, tx)
+		assert.Equal(t, []flow.Address{address}, tx.Authorizers)
+
+		revoke, err := tx.Argument(1)
+		require.NoError(t, err)
+		assert.Equal(t, []cadence.Value{cadence.NewInt(0)}, revoke.(cadence.Array).Values)
+
+		assert.Equal(t, oldKey.Weight, plan.ActiveWeightBefore)
+		assert.Equal(t, 1000, plan.ActiveWeightAfter)
+		require.Len(t, plan.Added, 2)
+		assert.Equal(t, uint32(2), plan.Added[0].Index)
+		assert.Equal(t, uint32(3), plan.Added[1].Index)
+		assert.Equal(t, []*flow.AccountKey{oldKey}, plan.Revoked)
+		require.Len(t, plan.After, 4)
+		assert.True(t, plan.After[0].Revoked)
+		assert.False(t, oldKey.Revoked, "current keys must not be modified")
+		assert.Contains(t, plan.String(), "#0")
+		assert.Contains(t, plan.String(), "(revoking)")
+		assert.Contains(t, plan.String(), "(added)")
+	})
+
+	t.Run("Insufficient weight", func(t *testing.T) {
+		_, err := templates.PlanKeyRotation(templates.KeyRotation{
+			Address: address,
+			Add:     []*flow.AccountKey{newKey},
+			Revoke:  []uint32{0},
+		}, currentKeys)
+		require.ErrorIs(t, err, templates.ErrInsufficientKeyWeight)
+	})
+
+	t.Run("Invalid revocations", func(t *testing.T) {
+		for _, revoke := range [][]uint32{{1}, {5}, {0, 0}} {
+			_, err := templates.PlanKeyRotation(templates.KeyRotation{
+				Address: address,
+				Add:     []*flow.AccountKey{keys.New()},
+				Revoke:  revoke,
+			}, currentKeys)
+			require.Error(t, err, "%v", revoke)
+		}
+	})
+
+	t.Run("Invalid key", func(t *testing.T) {
+		invalidKey := keys.New()
+		invalidKey.Weight = 1001
+
+		_, err := templates.PlanKeyRotation(templates.KeyRotation{
+			Address: address,
+			Add:     []*flow.AccountKey{invalidKey},
+		}, currentKeys)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,176 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/templates/internal"
+)
+
+//go:embed cadence/transfer_tokens.cdc
+var transferTokensTemplate []byte
+
+//go:embed cadence/setup_vault.cdc
+var setupVaultTemplate []byte
+
+//go:embed cadence/get_balance.cdc
+var getBalanceTemplate []byte
+
+// FungibleToken describes a token contract implementing the FungibleToken standard,
+// and the paths of its vault in user accounts.
+type FungibleToken struct {
+	ContractName    string
+	ContractAddress flow.Address
+	// VaultPath is the storage path of the vault.
+	VaultPath cadence.Path
+	// ReceiverPath is the public path of the receiver capability.
+	ReceiverPath cadence.Path
+	// BalancePath is the public path of the balance capability.
+	BalancePath cadence.Path
+}
+
+// FlowToken returns the FLOW token on the given network.
+func FlowToken(network flow.ChainID) (FungibleToken, error) {
+	address, err := flow.CoreContractAddress(network, flow.ContractFlowToken)
+	if err != nil {
+		return FungibleToken{}, err
+	}
+
+	return FungibleToken{
+		ContractName:    flow.ContractFlowToken,
+		ContractAddress: address,
+		VaultPath:       cadence.Path{Domain: common.PathDomainStorage, Identifier: "flowTokenVault"},
+		ReceiverPath:    cadence.Path{Domain: common.PathDomainPublic, Identifier: "flowTokenReceiver"},
+		BalancePath:     cadence.Path{Domain: common.PathDomainPublic, Identifier: "flowTokenBalance"},
+	}, nil
+}
+
+// TransferFlow generates a transaction that transfers FLOW from the sender to the recipient.
+//
+// The amount is a UFix64 string, e.g. "1.5". The sender is added as the transaction authorizer.
+func TransferFlow(network flow.ChainID, sender, recipient flow.Address, amount string) (*flow.Transaction, error) {
+	token, err := FlowToken(network)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create TransferFlow transaction: %w", err)
+	}
+
+	return TransferFungibleToken(network, token, sender, recipient, amount)
+}
+
+// TransferFungibleToken generates a transaction that transfers fungible tokens from the sender to the recipient.
+//
+// The tokens are withdrawn from the vault stored at the vault path of the sender, and deposited to the receiver
+// capability published at the receiver path of the recipient. The amount is a UFix64 string, e.g. "1.5".
+// The sender is added as the transaction authorizer.
+func TransferFungibleToken(
+	network flow.ChainID,
+	token FungibleToken,
+	sender flow.Address,
+	recipient flow.Address,
+	amount string,
+) (*flow.Transaction, error) {
+	script, err := internal.ResolveTemplate(network, transferTokensTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create TransferFungibleToken transaction: %w", err)
+	}
+
+	args, err := TransferFungibleTokenArguments(token, recipient, amount)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create TransferFungibleToken transaction: %w", err)
+	}
+
+	return internal.NewTransaction(script, args, sender), nil
+}
+
+// TransferFungibleTokenArguments returns the arguments of the transaction generated by TransferFungibleToken.
+func TransferFungibleTokenArguments(token FungibleToken, recipient flow.Address, amount string) ([]cadence.Value, error) {
+	value, err := cadence.NewUFix64(amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount %s: %w", amount, err)
+	}
+
+	if token.VaultPath.Domain != common.PathDomainStorage {
+		return nil, fmt.Errorf("invalid vault path %s: must be a storage path", token.VaultPath)
+	}
+	if token.ReceiverPath.Domain != common.PathDomainPublic {
+		return nil, fmt.Errorf("invalid receiver path %s: must be a public path", token.ReceiverPath)
+	}
+
+	return []cadence.Value{
+		value,
+		cadence.NewAddress(recipient),
+		token.VaultPath,
+		token.ReceiverPath,
+	}, nil
+}
+
+// SetupFungibleTokenVault generates a transaction that stores an empty vault of the token in the account,
+// and publishes its receiver and metadata capabilities.
+//
+// The vault data, i.e. the paths and the empty vault, is resolved from the FTVaultData view of the token contract.
+// The transaction does nothing if the account already stores a vault at the storage path.
+func SetupFungibleTokenVault(network flow.ChainID, token FungibleToken, account flow.Address) (*flow.Transaction, error) {
+	script, err := internal.ResolveTemplate(network, setupVaultTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create SetupFungibleTokenVault transaction: %w", err)
+	}
+
+	args := []cadence.Value{
+		cadence.NewAddress(token.ContractAddress),
+		cadence.String(token.ContractName),
+	}
+
+	return internal.NewTransaction(script, args, account), nil
+}
+
+// GetFungibleTokenBalance generates a script that returns the balance of the vault of the account,
+// and the arguments to execute it with.
+//
+// The result of the script can be decoded with DecodeFungibleTokenBalance.
+func GetFungibleTokenBalance(
+	network flow.ChainID,
+	token FungibleToken,
+	account flow.Address,
+) ([]byte, []cadence.Value, error) {
+	script, err := internal.ResolveTemplate(network, getBalanceTemplate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot create GetFungibleTokenBalance script: %w", err)
+	}
+
+	if token.BalancePath.Domain != common.PathDomainPublic {
+		return nil, nil, fmt.Errorf("invalid balance path %s: must be a public path", token.BalancePath)
+	}
+
+	return script, []cadence.Value{cadence.NewAddress(account), token.BalancePath}, nil
+}
+
+// DecodeFungibleTokenBalance decodes the result of the script generated by GetFungibleTokenBalance.
+func DecodeFungibleTokenBalance(value cadence.Value) (cadence.UFix64, error) {
+	balance, ok := value.(cadence.UFix64)
+	if !ok {
+		return 0, fmt.Errorf("invalid balance: expected UFix64, got %s", value)
+	}
+	return balance, nil
+}
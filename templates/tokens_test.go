@@ -0,0 +1,115 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/templates"
+)
+
+// requireValidScript checks that the transaction script parses and its arguments match its parameters.
+func requireValidScript(t *testing.T, tx *flow.Transaction) {
+	for _, diagnostic := range tx.Validate(flow.WithoutSignatureChecks()) {
+		switch diagnostic.Code {
+		case flow.DiagnosticInvalidScript,
+			flow.DiagnosticArgumentCountMismatch,
+			flow.DiagnosticInvalidArgument,
+			flow.DiagnosticArgumentTypeMismatch,
+			flow.DiagnosticAuthorizerCountMismatch:
+			require.NoError(t, diagnostic)
+		}
+	}
+}
+
+func TestTransferFlow(t *testing.T) {
+	sender := flow.HexToAddress("01")
+	recipient := flow.HexToAddress("02")
+
+	tx, err := templates.TransferFlow(flow.Mainnet, sender, recipient, "1.5")
+	require.NoError(t, err)
+
+	requireValidScript(t, tx)
+	assert.Contains(t, string(tx.Script), "import FungibleToken from 0xf233dcee88fe0abe")
+	assert.Equal(t, []flow.Address{sender}, tx.Authorizers)
+
+	amount, err := tx.Argument(0)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.UFix64(1_50000000), amount)
+
+	vaultPath, err := tx.Argument(2)
+	require.NoError(t, err)
+	assert.Equal(t, "/storage/flowTokenVault", vaultPath.String())
+
+	_, err = templates.TransferFlow(flow.Mainnet, sender, recipient, "-1")
+	require.Error(t, err)
+
+	_, err = templates.TransferFlow(flow.ChainID("flow-unknown"), sender, recipient, "1.0")
+	require.ErrorIs(t, err, flow.ErrUnknownContract)
+}
+
+func TestTransferFungibleToken(t *testing.T) {
+	token, err := templates.FlowToken(flow.Emulator)
+	require.NoError(t, err)
+
+	// swapped paths are rejected
+	token.VaultPath, token.ReceiverPath = token.ReceiverPath, token.VaultPath
+
+	_, err = templates.TransferFungibleToken(flow.Emulator, token, flow.HexToAddress("01"), flow.HexToAddress("02"), "1.0")
+	require.Error(t, err)
+}
+
+func TestSetupFungibleTokenVault(t *testing.T) {
+	token, err := templates.FlowToken(flow.Testnet)
+	require.NoError(t, err)
+
+	tx, err := templates.SetupFungibleTokenVault(flow.Testnet, token, flow.HexToAddress("01"))
+	require.NoError(t, err)
+
+	requireValidScript(t, tx)
+	assert.Contains(t, string(tx.Script), "import FungibleTokenMetadataViews from 0x9a0766d93b6608b7")
+
+	contractName, err := tx.Argument(1)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.String("FlowToken"), contractName)
+}
+
+func TestGetFungibleTokenBalance(t *testing.T) {
+	token, err := templates.FlowToken(flow.Emulator)
+	require.NoError(t, err)
+
+	script, args, err := templates.GetFungibleTokenBalance(flow.Emulator, token, flow.HexToAddress("01"))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(script), "import FungibleToken from 0xee82856bf20e2aa6")
+	assert.Equal(t, cadence.NewAddress(flow.HexToAddress("01")), args[0])
+	assert.Equal(t, token.BalancePath, args[1])
+
+	balance, err := templates.DecodeFungibleTokenBalance(cadence.UFix64(10_00000000))
+	require.NoError(t, err)
+	assert.Equal(t, cadence.UFix64(10_00000000), balance)
+
+	_, err = templates.DecodeFungibleTokenBalance(cadence.String("10.0"))
+	require.Error(t, err)
+}
@@ -541,6 +541,14 @@ func (g *TransactionResults) New() flow.TransactionResult {
 	}
 }
 
+// NewWithExecutionError returns a transaction result whose error is a parsed execution error,
+// as returned by the access clients.
+func (g *TransactionResults) NewWithExecutionError() flow.TransactionResult {
+	result := g.New()
+	result.Error = flow.ParseExecutionErrorMessage("[Error Code: 1101] transaction execution error")
+	return result
+}
+
 type ExecutionDatas struct {
 	ids    *Identifiers
 	chunks *ChunkExecutionDatas
@@ -510,7 +510,11 @@ func DecodeTransaction(transactionMessage []byte) (*Transaction, error) {
 		payloadSignatures := make([]TransactionSignature, len(temp.PayloadSignatures))
 		for i, sig := range temp.PayloadSignatures {
 			payloadSignatures[i] = transactionSignatureFromCanonicalForm(sig)
-			payloadSignatures[i].Address = signers[payloadSignatures[i].SignerIndex]
+			signerIndex := payloadSignatures[i].SignerIndex
+			if signerIndex < 0 || signerIndex >= len(signers) {
+				return nil, fmt.Errorf("invalid signer index %d of payload signature %d", signerIndex, i)
+			}
+			payloadSignatures[i].Address = signers[signerIndex]
 		}
 		t.PayloadSignatures = payloadSignatures
 	}
@@ -519,7 +523,11 @@ func DecodeTransaction(transactionMessage []byte) (*Transaction, error) {
 		envelopeSignatures := make([]TransactionSignature, len(temp.EnvelopeSignatures))
 		for i, sig := range temp.EnvelopeSignatures {
 			envelopeSignatures[i] = transactionSignatureFromCanonicalForm(sig)
-			envelopeSignatures[i].Address = signers[envelopeSignatures[i].SignerIndex]
+			signerIndex := envelopeSignatures[i].SignerIndex
+			if signerIndex < 0 || signerIndex >= len(signers) {
+				return nil, fmt.Errorf("invalid signer index %d of envelope signature %d", signerIndex, i)
+			}
+			envelopeSignatures[i].Address = signers[signerIndex]
 		}
 		t.EnvelopeSignatures = envelopeSignatures
 	}
@@ -0,0 +1,496 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/ast"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/parser"
+)
+
+// MaxTransactionComputeLimit is the maximum compute limit accepted by the network for a transaction.
+const MaxTransactionComputeLimit uint64 = 9999
+
+// MaxTransactionByteSize is the maximum encoded size in bytes accepted by the network for a transaction.
+const MaxTransactionByteSize = 1_500_000
+
+// DiagnosticSeverity indicates whether a diagnostic will cause the network to reject a transaction.
+type DiagnosticSeverity int
+
+const (
+	// DiagnosticSeverityError is the severity of a problem the network rejects or fails the transaction for.
+	DiagnosticSeverityError DiagnosticSeverity = iota
+	// DiagnosticSeverityWarning is the severity of a problem that is likely a mistake, but may be accepted.
+	DiagnosticSeverityWarning
+)
+
+// String returns the string representation of a diagnostic severity.
+func (s DiagnosticSeverity) String() string {
+	switch s {
+	case DiagnosticSeverityError:
+		return "error"
+	case DiagnosticSeverityWarning:
+		return "warning"
+	default:
+		return fmt.Sprintf("DiagnosticSeverity(%d)", s)
+	}
+}
+
+// DiagnosticCode identifies the kind of problem reported by a diagnostic.
+type DiagnosticCode string
+
+// List of diagnostic codes reported by Transaction.Validate.
+const (
+	DiagnosticEmptyScript                DiagnosticCode = "empty-script"
+	DiagnosticInvalidScript              DiagnosticCode = "invalid-script"
+	DiagnosticMissingReferenceBlock      DiagnosticCode = "missing-reference-block"
+	DiagnosticMissingPayer               DiagnosticCode = "missing-payer"
+	DiagnosticMissingProposalKey         DiagnosticCode = "missing-proposal-key"
+	DiagnosticAuthorizerCountMismatch    DiagnosticCode = "authorizer-count-mismatch"
+	DiagnosticArgumentCountMismatch      DiagnosticCode = "argument-count-mismatch"
+	DiagnosticInvalidArgument            DiagnosticCode = "invalid-argument"
+	DiagnosticArgumentTypeMismatch       DiagnosticCode = "argument-type-mismatch"
+	DiagnosticInvalidComputeLimit        DiagnosticCode = "invalid-compute-limit"
+	DiagnosticPayloadTooLarge            DiagnosticCode = "payload-too-large"
+	DiagnosticDuplicateSignature         DiagnosticCode = "duplicate-signature"
+	DiagnosticUnsortedSignatures         DiagnosticCode = "unsorted-signatures"
+	DiagnosticUnknownSigner              DiagnosticCode = "unknown-signer"
+	DiagnosticMissingEnvelopeSignature   DiagnosticCode = "missing-envelope-signature"
+	DiagnosticMissingProposalSignature   DiagnosticCode = "missing-proposal-signature"
+	DiagnosticMissingAuthorizerSignature DiagnosticCode = "missing-authorizer-signature"
+)
+
+// A TransactionDiagnostic describes a single problem found in a transaction.
+type TransactionDiagnostic struct {
+	Code     DiagnosticCode
+	Severity DiagnosticSeverity
+	Message  string
+	// Index is the position of the offending argument, authorizer or signature, or -1 if not applicable.
+	Index int
+}
+
+// Error returns the string representation of the diagnostic.
+func (d TransactionDiagnostic) Error() string {
+	return fmt.Sprintf("%s [%s]: %s", d.Severity, d.Code, d.Message)
+}
+
+// TransactionDiagnostics is the list of diagnostics returned by Transaction.Validate.
+type TransactionDiagnostics []TransactionDiagnostic
+
+// HasErrors returns true if at least one diagnostic has error severity.
+func (d TransactionDiagnostics) HasErrors() bool {
+	for _, diagnostic := range d {
+		if diagnostic.Severity == DiagnosticSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Err returns all diagnostics with error severity joined into a single error, or nil if there are none.
+func (d TransactionDiagnostics) Err() error {
+	var errs []error
+	for _, diagnostic := range d {
+		if diagnostic.Severity == DiagnosticSeverityError {
+			errs = append(errs, diagnostic)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type validateConfig struct {
+	maxComputeLimit uint64
+	maxByteSize     int
+	checkSignatures bool
+}
+
+// A ValidateOption configures the checks performed by Transaction.Validate.
+type ValidateOption func(*validateConfig)
+
+// WithMaxComputeLimit overrides the maximum compute limit, MaxTransactionComputeLimit by default.
+func WithMaxComputeLimit(limit uint64) ValidateOption {
+	return func(config *validateConfig) {
+		config.maxComputeLimit = limit
+	}
+}
+
+// WithMaxByteSize overrides the maximum encoded transaction size, MaxTransactionByteSize by default.
+func WithMaxByteSize(size int) ValidateOption {
+	return func(config *validateConfig) {
+		config.maxByteSize = size
+	}
+}
+
+// WithoutSignatureChecks disables signature related checks, which is useful to validate a transaction before signing.
+func WithoutSignatureChecks() ValidateOption {
+	return func(config *validateConfig) {
+		config.checkSignatures = false
+	}
+}
+
+// Validate checks the transaction for mistakes that the network would otherwise only report after submission.
+//
+// The script is parsed with Cadence to check the number of authorizers against the parameters of the
+// prepare block, and the arguments against the transaction parameters. Signatures are checked for
+// duplicates, ordering and the presence of the proposer, authorizer and payer signatures, but are not
+// cryptographically verified; see VerifyTransactionSignatures.
+//
+// An empty result means no problem was found.
+func (t *Transaction) Validate(opts ...ValidateOption) TransactionDiagnostics {
+	config := validateConfig{
+		maxComputeLimit: MaxTransactionComputeLimit,
+		maxByteSize:     MaxTransactionByteSize,
+		checkSignatures: true,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	var diagnostics TransactionDiagnostics
+	report := func(code DiagnosticCode, severity DiagnosticSeverity, index int, format string, args ...any) {
+		diagnostics = append(diagnostics, TransactionDiagnostic{
+			Code:     code,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+			Index:    index,
+		})
+	}
+
+	if len(t.Script) == 0 {
+		report(DiagnosticEmptyScript, DiagnosticSeverityError, -1, "transaction script is empty")
+	} else {
+		t.validateScript(report)
+	}
+
+	if t.ReferenceBlockID == EmptyID {
+		report(DiagnosticMissingReferenceBlock, DiagnosticSeverityError, -1, "reference block ID is not set")
+	}
+
+	if t.Payer == EmptyAddress {
+		report(DiagnosticMissingPayer, DiagnosticSeverityError, -1, "payer is not set")
+	}
+
+	if t.ProposalKey.Address == EmptyAddress {
+		report(DiagnosticMissingProposalKey, DiagnosticSeverityError, -1, "proposal key is not set")
+	}
+
+	if t.GasLimit == 0 {
+		report(DiagnosticInvalidComputeLimit, DiagnosticSeverityError, -1, "compute limit must be greater than zero")
+	} else if t.GasLimit > config.maxComputeLimit {
+		report(
+			DiagnosticInvalidComputeLimit,
+			DiagnosticSeverityError,
+			-1,
+			"compute limit %d exceeds the maximum of %d",
+			t.GasLimit,
+			config.maxComputeLimit,
+		)
+	}
+
+	if size := len(t.Encode()); size > config.maxByteSize {
+		report(
+			DiagnosticPayloadTooLarge,
+			DiagnosticSeverityError,
+			-1,
+			"encoded transaction is %d bytes, exceeds the maximum of %d",
+			size,
+			config.maxByteSize,
+		)
+	}
+
+	if config.checkSignatures {
+		t.validateSignatures(report)
+	}
+
+	return diagnostics
+}
+
+type diagnosticReporter func(code DiagnosticCode, severity DiagnosticSeverity, index int, format string, args ...any)
+
+func (t *Transaction) validateScript(report diagnosticReporter) {
+	program, err := parser.ParseProgram(nil, t.Script, parser.Config{})
+	if err != nil {
+		report(DiagnosticInvalidScript, DiagnosticSeverityError, -1, "failed to parse script: %s", err)
+		return
+	}
+
+	declaration := program.SoleTransactionDeclaration()
+	if declaration == nil {
+		report(DiagnosticInvalidScript, DiagnosticSeverityError, -1, "script must contain exactly one transaction declaration")
+		return
+	}
+
+	prepareParameters := 0
+	if declaration.Prepare != nil && declaration.Prepare.FunctionDeclaration.ParameterList != nil {
+		prepareParameters = len(declaration.Prepare.FunctionDeclaration.ParameterList.Parameters)
+	}
+	if prepareParameters != len(t.Authorizers) {
+		report(
+			DiagnosticAuthorizerCountMismatch,
+			DiagnosticSeverityError,
+			-1,
+			"prepare block expects %d authorizers, transaction has %d",
+			prepareParameters,
+			len(t.Authorizers),
+		)
+	}
+
+	var parameters []*ast.Parameter
+	if declaration.ParameterList != nil {
+		parameters = declaration.ParameterList.Parameters
+	}
+	if len(parameters) != len(t.Arguments) {
+		report(
+			DiagnosticArgumentCountMismatch,
+			DiagnosticSeverityError,
+			-1,
+			"transaction expects %d arguments, got %d",
+			len(parameters),
+			len(t.Arguments),
+		)
+	}
+
+	for i := 0; i < len(parameters) && i < len(t.Arguments); i++ {
+		parameter := parameters[i]
+
+		value, err := jsoncdc.Decode(nil, t.Arguments[i])
+		if err != nil {
+			report(DiagnosticInvalidArgument, DiagnosticSeverityError, i, "argument %d is not valid JSON-CDC: %s", i, err)
+			continue
+		}
+
+		if parameter.TypeAnnotation == nil {
+			continue
+		}
+		if !valueMatchesType(value, parameter.TypeAnnotation.Type) {
+			report(
+				DiagnosticArgumentTypeMismatch,
+				DiagnosticSeverityError,
+				i,
+				"argument %d (%s) expects type %s, got %s",
+				i,
+				parameter.Identifier.Identifier,
+				parameter.TypeAnnotation.Type,
+				valueTypeName(value),
+			)
+		}
+	}
+}
+
+func (t *Transaction) validateSignatures(report diagnosticReporter) {
+	signers := t.signerMap()
+
+	check := func(signatures []TransactionSignature, kind string) map[Address]bool {
+		signed := make(map[Address]bool)
+		seen := make(map[Address]map[uint32]struct{})
+
+		for i, sig := range signatures {
+			if _, ok := signers[sig.Address]; !ok {
+				report(
+					DiagnosticUnknownSigner,
+					DiagnosticSeverityError,
+					i,
+					"%s signature %d is from account %s, which is not a signer of the transaction",
+					kind,
+					i,
+					sig.Address,
+				)
+			}
+
+			if _, ok := seen[sig.Address][sig.KeyIndex]; ok {
+				report(
+					DiagnosticDuplicateSignature,
+					DiagnosticSeverityError,
+					i,
+					"%s signature %d duplicates the signature of account %s key %d",
+					kind,
+					i,
+					sig.Address,
+					sig.KeyIndex,
+				)
+			}
+			if seen[sig.Address] == nil {
+				seen[sig.Address] = make(map[uint32]struct{})
+			}
+			seen[sig.Address][sig.KeyIndex] = struct{}{}
+			signed[sig.Address] = true
+
+			if i > 0 && compareSignatures(signatures)(i, i-1) {
+				report(
+					DiagnosticUnsortedSignatures,
+					DiagnosticSeverityWarning,
+					i,
+					"%s signatures are not sorted by signer index and key index",
+					kind,
+				)
+			}
+		}
+
+		return signed
+	}
+
+	payloadSigned := check(t.PayloadSignatures, "payload")
+	envelopeSigned := check(t.EnvelopeSignatures, "envelope")
+
+	if t.Payer != EmptyAddress && !envelopeSigned[t.Payer] {
+		report(DiagnosticMissingEnvelopeSignature, DiagnosticSeverityError, -1, "payer %s has not signed the envelope", t.Payer)
+	}
+
+	// accounts that are also the payer only need to sign the envelope, with the proposal key if proposing
+	if t.ProposalKey.Address != EmptyAddress {
+		proposalSignatures, kind := t.PayloadSignatures, "payload"
+		if t.ProposalKey.Address == t.Payer {
+			proposalSignatures, kind = t.EnvelopeSignatures, "envelope"
+		}
+
+		proposalSigned := false
+		for _, sig := range proposalSignatures {
+			if sig.Address == t.ProposalKey.Address && sig.KeyIndex == t.ProposalKey.KeyIndex {
+				proposalSigned = true
+				break
+			}
+		}
+		if !proposalSigned {
+			report(
+				DiagnosticMissingProposalSignature,
+				DiagnosticSeverityError,
+				-1,
+				"proposal key %s key %d has not signed the %s",
+				t.ProposalKey.Address,
+				t.ProposalKey.KeyIndex,
+				kind,
+			)
+		}
+	}
+
+	for i, authorizer := range t.Authorizers {
+		if authorizer != t.Payer && !payloadSigned[authorizer] {
+			report(
+				DiagnosticMissingAuthorizerSignature,
+				DiagnosticSeverityError,
+				i,
+				"authorizer %s has not signed the payload",
+				authorizer,
+			)
+		}
+	}
+}
+
+// concretePrimitiveTypes contains the type identifiers for which an argument value must have exactly that type.
+// Other nominal types (composites, abstract supertypes like Number or AnyStruct) are not checked.
+var concretePrimitiveTypes = map[string]struct{}{
+	"Bool": {}, "String": {}, "Character": {}, "Address": {},
+	"Int": {}, "Int8": {}, "Int16": {}, "Int32": {}, "Int64": {}, "Int128": {}, "Int256": {},
+	"UInt": {}, "UInt8": {}, "UInt16": {}, "UInt32": {}, "UInt64": {}, "UInt128": {}, "UInt256": {},
+	"Word8": {}, "Word16": {}, "Word32": {}, "Word64": {}, "Word128": {}, "Word256": {},
+	"Fix64": {}, "Fix128": {}, "UFix64": {}, "UFix128": {},
+	"StoragePath": {}, "PublicPath": {}, "PrivatePath": {},
+}
+
+// valueTypeName returns a readable name for the type of an argument value.
+//
+// Decoded JSON-CDC containers do not carry a static type, so their kind is used instead.
+func valueTypeName(value cadence.Value) string {
+	if t := value.Type(); t != nil {
+		return t.ID()
+	}
+
+	switch value.(type) {
+	case cadence.Array:
+		return "Array"
+	case cadence.Dictionary:
+		return "Dictionary"
+	case cadence.Optional:
+		return "Optional"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// valueMatchesType returns false if the value is known not to conform to the parameter type.
+//
+// The check is conservative: types that cannot be resolved without type checking are accepted.
+func valueMatchesType(value cadence.Value, t ast.Type) bool {
+	switch t := t.(type) {
+	case *ast.OptionalType:
+		optional, ok := value.(cadence.Optional)
+		if !ok {
+			return valueMatchesType(value, t.Type)
+		}
+		return optional.Value == nil || valueMatchesType(optional.Value, t.Type)
+
+	case *ast.VariableSizedType:
+		array, ok := value.(cadence.Array)
+		if !ok {
+			return false
+		}
+		for _, element := range array.Values {
+			if !valueMatchesType(element, t.Type) {
+				return false
+			}
+		}
+		return true
+
+	case *ast.ConstantSizedType:
+		array, ok := value.(cadence.Array)
+		if !ok {
+			return false
+		}
+		if t.Size != nil && t.Size.Value != nil && int64(len(array.Values)) != t.Size.Value.Int64() {
+			return false
+		}
+		for _, element := range array.Values {
+			if !valueMatchesType(element, t.Type) {
+				return false
+			}
+		}
+		return true
+
+	case *ast.DictionaryType:
+		dictionary, ok := value.(cadence.Dictionary)
+		if !ok {
+			return false
+		}
+		for _, pair := range dictionary.Pairs {
+			if !valueMatchesType(pair.Key, t.KeyType) || !valueMatchesType(pair.Value, t.ValueType) {
+				return false
+			}
+		}
+		return true
+
+	case *ast.NominalType:
+		if len(t.NestedIdentifiers) > 0 {
+			return true
+		}
+		if _, ok := concretePrimitiveTypes[t.Identifier.Identifier]; !ok {
+			return true
+		}
+		if _, ok := value.(cadence.Optional); ok {
+			return false
+		}
+		return value.Type() != nil && value.Type().ID() == t.Identifier.Identifier
+
+	default:
+		return true
+	}
+}
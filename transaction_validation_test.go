@@ -0,0 +1,219 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func diagnosticCodes(diagnostics flow.TransactionDiagnostics) []flow.DiagnosticCode {
+	codes := make([]flow.DiagnosticCode, len(diagnostics))
+	for i, diagnostic := range diagnostics {
+		codes[i] = diagnostic.Code
+	}
+	return codes
+}
+
+func TestTransaction_Validate(t *testing.T) {
+	addresses := test.AddressGenerator()
+	keys := test.AccountKeyGenerator()
+
+	proposer := addresses.New()
+	payer := addresses.New()
+
+	proposerKey, proposerSigner := keys.NewWithSigner()
+	payerKey, payerSigner := keys.NewWithSigner()
+
+	const script = `
+		transaction(amount: UFix64, to: Address, memo: String?, ids: [UInt64]) {
+			prepare(signer: &Account) {}
+		}
+	`
+
+	newTx := func(t *testing.T) *flow.Transaction {
+		tx := flow.NewTransaction().
+			SetScript([]byte(script)).
+			SetReferenceBlockID(flow.Identifier{0x01}).
+			SetProposalKey(proposer, proposerKey.Index, proposerKey.SequenceNumber).
+			SetPayer(payer).
+			AddAuthorizer(proposer)
+
+		amount, err := cadence.NewUFix64("10.0")
+		require.NoError(t, err)
+		require.NoError(t, tx.AddArgument(amount))
+		require.NoError(t, tx.AddArgument(cadence.NewAddress(payer)))
+		require.NoError(t, tx.AddArgument(cadence.NewOptional(nil)))
+		require.NoError(t, tx.AddArgument(cadence.NewArray([]cadence.Value{cadence.NewUInt64(1)})))
+
+		return tx
+	}
+
+	sign := func(t *testing.T, tx *flow.Transaction) {
+		require.NoError(t, tx.SignPayload(proposer, proposerKey.Index, proposerSigner))
+		require.NoError(t, tx.SignEnvelope(payer, payerKey.Index, payerSigner))
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		tx := newTx(t)
+		sign(t, tx)
+
+		diagnostics := tx.Validate()
+		assert.Empty(t, diagnostics)
+		assert.NoError(t, diagnostics.Err())
+	})
+
+	t.Run("Unsigned", func(t *testing.T) {
+		tx := newTx(t)
+
+		assert.Empty(t, tx.Validate(flow.WithoutSignatureChecks()))
+		assert.ElementsMatch(t,
+			[]flow.DiagnosticCode{
+				flow.DiagnosticMissingEnvelopeSignature,
+				flow.DiagnosticMissingProposalSignature,
+				flow.DiagnosticMissingAuthorizerSignature,
+			},
+			diagnosticCodes(tx.Validate()),
+		)
+	})
+
+	t.Run("Payer proposes", func(t *testing.T) {
+		tx := newTx(t).SetProposalKey(payer, payerKey.Index, payerKey.SequenceNumber)
+		tx.Authorizers = []flow.Address{payer}
+		require.NoError(t, tx.SignEnvelope(payer, payerKey.Index, payerSigner))
+		assert.Empty(t, tx.Validate())
+
+		// the envelope is signed by another key of the payer
+		tx = newTx(t).SetProposalKey(payer, payerKey.Index+1, payerKey.SequenceNumber)
+		tx.Authorizers = []flow.Address{payer}
+		require.NoError(t, tx.SignEnvelope(payer, payerKey.Index, payerSigner))
+
+		diagnostics := tx.Validate()
+		assert.Equal(t, []flow.DiagnosticCode{flow.DiagnosticMissingProposalSignature}, diagnosticCodes(diagnostics))
+		assert.Contains(t, diagnostics[0].Message, "has not signed the envelope")
+	})
+
+	t.Run("Empty transaction", func(t *testing.T) {
+		tx := flow.NewTransaction().SetComputeLimit(0)
+
+		diagnostics := tx.Validate(flow.WithoutSignatureChecks())
+		assert.True(t, diagnostics.HasErrors())
+		assert.ElementsMatch(t,
+			[]flow.DiagnosticCode{
+				flow.DiagnosticEmptyScript,
+				flow.DiagnosticMissingReferenceBlock,
+				flow.DiagnosticMissingPayer,
+				flow.DiagnosticMissingProposalKey,
+				flow.DiagnosticInvalidComputeLimit,
+			},
+			diagnosticCodes(diagnostics),
+		)
+	})
+
+	t.Run("Invalid script", func(t *testing.T) {
+		tx := newTx(t).SetScript([]byte(`transaction {`))
+
+		assert.Equal(t,
+			[]flow.DiagnosticCode{flow.DiagnosticInvalidScript},
+			diagnosticCodes(tx.Validate(flow.WithoutSignatureChecks())),
+		)
+	})
+
+	t.Run("Authorizer count mismatch", func(t *testing.T) {
+		tx := newTx(t).AddAuthorizer(payer)
+
+		assert.Equal(t,
+			[]flow.DiagnosticCode{flow.DiagnosticAuthorizerCountMismatch},
+			diagnosticCodes(tx.Validate(flow.WithoutSignatureChecks())),
+		)
+	})
+
+	t.Run("Argument count mismatch", func(t *testing.T) {
+		tx := newTx(t)
+		tx.Arguments = tx.Arguments[:3]
+
+		assert.Equal(t,
+			[]flow.DiagnosticCode{flow.DiagnosticArgumentCountMismatch},
+			diagnosticCodes(tx.Validate(flow.WithoutSignatureChecks())),
+		)
+	})
+
+	t.Run("Argument type mismatch", func(t *testing.T) {
+		tx := newTx(t)
+		tx.Arguments[0] = []byte(`{"type":"String","value":"10.0"}`)
+		tx.Arguments[2] = []byte(`{"type":"Optional","value":{"type":"Int","value":"1"}}`)
+		tx.Arguments[3] = []byte(`{"type":"Array","value":[{"type":"UInt8","value":"1"}]}`)
+
+		diagnostics := tx.Validate(flow.WithoutSignatureChecks())
+		require.Len(t, diagnostics, 3)
+		for i, index := range []int{0, 2, 3} {
+			assert.Equal(t, flow.DiagnosticArgumentTypeMismatch, diagnostics[i].Code)
+			assert.Equal(t, index, diagnostics[i].Index)
+		}
+	})
+
+	t.Run("Invalid argument", func(t *testing.T) {
+		tx := newTx(t)
+		tx.Arguments[1] = []byte(`not json`)
+
+		diagnostics := tx.Validate(flow.WithoutSignatureChecks())
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, flow.DiagnosticInvalidArgument, diagnostics[0].Code)
+		assert.Equal(t, 1, diagnostics[0].Index)
+	})
+
+	t.Run("Limits", func(t *testing.T) {
+		tx := newTx(t).SetComputeLimit(flow.MaxTransactionComputeLimit + 1)
+
+		assert.Equal(t,
+			[]flow.DiagnosticCode{flow.DiagnosticInvalidComputeLimit, flow.DiagnosticPayloadTooLarge},
+			diagnosticCodes(tx.Validate(flow.WithoutSignatureChecks(), flow.WithMaxByteSize(100))),
+		)
+		assert.Empty(t, tx.Validate(flow.WithoutSignatureChecks(), flow.WithMaxComputeLimit(flow.MaxTransactionComputeLimit+1)))
+	})
+
+	t.Run("Duplicate and unsorted signatures", func(t *testing.T) {
+		tx := newTx(t)
+		sign(t, tx)
+		tx.PayloadSignatures = append(tx.PayloadSignatures, tx.PayloadSignatures[0])
+		tx.EnvelopeSignatures = append(
+			[]flow.TransactionSignature{{Address: payer, SignerIndex: 1, KeyIndex: 9}},
+			tx.EnvelopeSignatures...,
+		)
+
+		diagnostics := tx.Validate()
+		assert.Equal(t,
+			[]flow.DiagnosticCode{flow.DiagnosticDuplicateSignature, flow.DiagnosticUnsortedSignatures},
+			diagnosticCodes(diagnostics),
+		)
+		assert.Equal(t, flow.DiagnosticSeverityWarning, diagnostics[1].Severity)
+	})
+}
+
+func TestDiagnosticSeverity_String(t *testing.T) {
+	assert.Equal(t, "error", flow.DiagnosticSeverityError.String())
+	assert.Equal(t, "warning", flow.DiagnosticSeverityWarning.String())
+	assert.Equal(t, "DiagnosticSeverity(2)", flow.DiagnosticSeverity(2).String())
+}
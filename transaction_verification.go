@@ -0,0 +1,274 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/internal/webauthn"
+)
+
+var (
+	// ErrMissingAccountKey is returned when a signature references an account key that was not provided.
+	ErrMissingAccountKey = errors.New("account key not found")
+	// ErrRevokedAccountKey is returned when a signature was produced by a revoked account key.
+	ErrRevokedAccountKey = errors.New("account key is revoked")
+	// ErrInvalidSignature is returned when a signature does not verify against its account key.
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrDuplicateSignature is returned when the same account key signed the same message more than once.
+	ErrDuplicateSignature = errors.New("duplicate signature")
+	// ErrInsufficientKeyWeight is returned when the signatures of an account do not reach AccountKeyWeightThreshold.
+	ErrInsufficientKeyWeight = errors.New("insufficient key weight")
+	// ErrMissingProposalKeySignature is returned when the proposal key did not sign the transaction.
+	ErrMissingProposalKeySignature = errors.New("missing signature for proposal key")
+	// ErrUnexpectedSigner is returned when an account which is not a signer of the transaction signed it.
+	ErrUnexpectedSigner = errors.New("signature from an account which is not a signer of the transaction")
+)
+
+// SignatureVerificationResult is the outcome of verifying a single transaction signature.
+type SignatureVerificationResult struct {
+	Signature TransactionSignature
+	// Envelope is true if the signature is an envelope signature, false if it is a payload signature.
+	Envelope bool
+	// Weight is the weight of the account key that produced the signature, or zero if the signature is invalid.
+	Weight int
+	// Err is nil if the signature is valid.
+	Err error
+}
+
+// SignerVerificationReport summarizes the signatures of a single account participating in a transaction.
+type SignerVerificationReport struct {
+	Address    Address
+	Proposer   bool
+	Payer      bool
+	Authorizer bool
+	// PayloadWeight is the total weight of the valid payload signatures of this account.
+	PayloadWeight int
+	// EnvelopeWeight is the total weight of the valid envelope signatures of this account.
+	EnvelopeWeight int
+	// Signatures contains a result for each signature produced by this account.
+	Signatures []SignatureVerificationResult
+	// Err is nil if this account has sufficiently signed the transaction for all of its roles.
+	Err error
+}
+
+// TransactionVerificationReport is the outcome of verifying all signatures of a transaction.
+type TransactionVerificationReport struct {
+	// Signers contains one report per account required to sign the transaction, in signer order
+	// (proposer, payer, authorizers).
+	Signers []SignerVerificationReport
+	// ProposalKeySigned is true if a valid signature from the proposal key was found.
+	ProposalKeySigned bool
+	// UnexpectedSignatures contains a result for each signature produced by an account which is not
+	// a signer of the transaction. These signatures are not verified.
+	UnexpectedSignatures []SignatureVerificationResult
+}
+
+// Signer returns the report for the given address, or nil if the address is not a signer of the transaction.
+func (r *TransactionVerificationReport) Signer(address Address) *SignerVerificationReport {
+	for i := range r.Signers {
+		if r.Signers[i].Address == address {
+			return &r.Signers[i]
+		}
+	}
+	return nil
+}
+
+// Err returns the first error found in the report, or nil if the transaction is sufficiently signed.
+func (r *TransactionVerificationReport) Err() error {
+	if !r.ProposalKeySigned {
+		return ErrMissingProposalKeySignature
+	}
+
+	if len(r.UnexpectedSignatures) > 0 {
+		return fmt.Errorf("%w: %s", ErrUnexpectedSigner, r.UnexpectedSignatures[0].Signature.Address)
+	}
+
+	for _, signer := range r.Signers {
+		if signer.Err != nil {
+			return fmt.Errorf("signer %s: %w", signer.Address, signer.Err)
+		}
+	}
+
+	return nil
+}
+
+// VerifyTransactionSignatures verifies all payload and envelope signatures of a transaction offline.
+//
+// The accountKeys map must contain the keys of the proposer, payer and authorizers, typically obtained
+// from GetAccountKeysAtLatestBlock. Every signature is checked against its account key using the key's signature
+// and hashing algorithms, the transaction domain tag and, if present, the signature extension data.
+//
+// Following the rules applied by the network, authorizers must reach AccountKeyWeightThreshold with payload
+// signatures, and the payer must reach it with envelope signatures. An account that is also the payer only needs
+// to sign the envelope. The proposer only needs a valid signature from the proposal key, regardless of its weight.
+//
+// This function only returns an error if the transaction cannot be verified at all; signature failures are
+// reported in the returned report, see TransactionVerificationReport.Err.
+func VerifyTransactionSignatures(
+	tx *Transaction,
+	accountKeys map[Address][]*AccountKey,
+) (*TransactionVerificationReport, error) {
+	if tx.Payer == EmptyAddress {
+		return nil, errors.New("transaction has no payer")
+	}
+
+	signerIndex := make(map[Address]int)
+	report := &TransactionVerificationReport{}
+	for i, address := range tx.signerList() {
+		signerIndex[address] = i
+		report.Signers = append(report.Signers, SignerVerificationReport{
+			Address:  address,
+			Proposer: address == tx.ProposalKey.Address,
+			Payer:    address == tx.Payer,
+		})
+	}
+	for _, authorizer := range tx.Authorizers {
+		report.Signers[signerIndex[authorizer]].Authorizer = true
+	}
+
+	verify := func(signatures []TransactionSignature, message []byte, envelope bool) {
+		seen := make(map[Address]map[uint32]struct{})
+
+		for _, sig := range signatures {
+			result := SignatureVerificationResult{
+				Signature: sig,
+				Envelope:  envelope,
+			}
+
+			i, ok := signerIndex[sig.Address]
+			if !ok {
+				result.Err = ErrUnexpectedSigner
+				report.UnexpectedSignatures = append(report.UnexpectedSignatures, result)
+				continue
+			}
+			signer := &report.Signers[i]
+
+			if _, ok := seen[sig.Address][sig.KeyIndex]; ok {
+				result.Err = ErrDuplicateSignature
+				signer.Signatures = append(signer.Signatures, result)
+				continue
+			}
+			if seen[sig.Address] == nil {
+				seen[sig.Address] = make(map[uint32]struct{})
+			}
+			seen[sig.Address][sig.KeyIndex] = struct{}{}
+
+			key := findAccountKey(accountKeys[sig.Address], sig.KeyIndex)
+			result.Err = verifyTransactionSignature(key, sig, message)
+			if result.Err == nil {
+				result.Weight = key.Weight
+				if envelope {
+					signer.EnvelopeWeight += key.Weight
+				} else {
+					signer.PayloadWeight += key.Weight
+				}
+
+				if sig.Address == tx.ProposalKey.Address && sig.KeyIndex == tx.ProposalKey.KeyIndex {
+					report.ProposalKeySigned = true
+				}
+			}
+
+			signer.Signatures = append(signer.Signatures, result)
+		}
+	}
+
+	verify(tx.PayloadSignatures, tx.PayloadMessage(), false)
+	verify(tx.EnvelopeSignatures, tx.EnvelopeMessage(), true)
+
+	for i := range report.Signers {
+		signer := &report.Signers[i]
+
+		switch {
+		case signer.Payer:
+			if signer.EnvelopeWeight < AccountKeyWeightThreshold {
+				signer.Err = fmt.Errorf(
+					"%w: envelope signatures have weight %d, required %d",
+					ErrInsufficientKeyWeight,
+					signer.EnvelopeWeight,
+					AccountKeyWeightThreshold,
+				)
+			}
+		case signer.Authorizer:
+			if signer.PayloadWeight < AccountKeyWeightThreshold {
+				signer.Err = fmt.Errorf(
+					"%w: payload signatures have weight %d, required %d",
+					ErrInsufficientKeyWeight,
+					signer.PayloadWeight,
+					AccountKeyWeightThreshold,
+				)
+			}
+		}
+	}
+
+	return report, nil
+}
+
+func findAccountKey(keys []*AccountKey, index uint32) *AccountKey {
+	for _, key := range keys {
+		if key != nil && key.Index == index {
+			return key
+		}
+	}
+	return nil
+}
+
+// verifyTransactionSignature verifies a single transaction signature over the given payload or envelope message.
+func verifyTransactionSignature(key *AccountKey, sig TransactionSignature, message []byte) error {
+	if key == nil {
+		return fmt.Errorf("%w: %s key %d", ErrMissingAccountKey, sig.Address, sig.KeyIndex)
+	}
+	if key.Revoked {
+		return fmt.Errorf("%w: %s key %d", ErrRevokedAccountKey, sig.Address, sig.KeyIndex)
+	}
+	if key.PublicKey == nil {
+		return fmt.Errorf("%w: %s key %d has no public key", ErrMissingAccountKey, sig.Address, sig.KeyIndex)
+	}
+
+	hasher, err := crypto.NewHasher(key.HashAlgo)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSignature, err)
+	}
+
+	signedData := append(TransactionDomainTag[:], message...)
+
+	if !sig.shouldUseLegacyCanonicalForm() {
+		authenticatorData, clientDataJSON, err := webauthn.ParseExtensionData(sig.ExtensionData)
+		if err != nil {
+			return fmt.Errorf("%w: WebAuthn %s", ErrInvalidSignature, err)
+		}
+
+		signedData, err = webauthn.SignedData(authenticatorData, clientDataJSON, signedData)
+		if err != nil {
+			return fmt.Errorf("%w: WebAuthn %s", ErrInvalidSignature, err)
+		}
+	}
+
+	valid, err := key.PublicKey.Verify(sig.Signature, signedData, hasher)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSignature, err)
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
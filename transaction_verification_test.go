@@ -0,0 +1,192 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestVerifyTransactionSignatures(t *testing.T) {
+	addresses := test.AddressGenerator()
+	keys := test.AccountKeyGenerator()
+
+	proposer := addresses.New()
+	payer := addresses.New()
+
+	proposerKey, proposerSigner := keys.NewWithSigner()
+	payerKey, payerSigner := keys.NewWithSigner()
+
+	newTx := func() *flow.Transaction {
+		return flow.NewTransaction().
+			SetScript([]byte(`transaction { prepare(signer: &Account) {} }`)).
+			SetReferenceBlockID(flow.Identifier{0x01}).
+			SetProposalKey(proposer, proposerKey.Index, proposerKey.SequenceNumber).
+			SetPayer(payer).
+			AddAuthorizer(proposer)
+	}
+
+	accountKeys := map[flow.Address][]*flow.AccountKey{
+		proposer: {proposerKey},
+		payer:    {payerKey},
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		tx := newTx()
+		require.NoError(t, tx.SignPayload(proposer, proposerKey.Index, proposerSigner))
+		require.NoError(t, tx.SignEnvelope(payer, payerKey.Index, payerSigner))
+
+		report, err := flow.VerifyTransactionSignatures(tx, accountKeys)
+		require.NoError(t, err)
+		require.NoError(t, report.Err())
+
+		assert.True(t, report.ProposalKeySigned)
+		require.Len(t, report.Signers, 2)
+		assert.Equal(t, flow.AccountKeyWeightThreshold, report.Signer(proposer).PayloadWeight)
+		assert.Equal(t, flow.AccountKeyWeightThreshold, report.Signer(payer).EnvelopeWeight)
+	})
+
+	t.Run("Invalid signature", func(t *testing.T) {
+		tx := newTx()
+		require.NoError(t, tx.SignPayload(proposer, proposerKey.Index, payerSigner))
+		require.NoError(t, tx.SignEnvelope(payer, payerKey.Index, payerSigner))
+
+		report, err := flow.VerifyTransactionSignatures(tx, accountKeys)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, report.Err(), flow.ErrMissingProposalKeySignature)
+		assert.ErrorIs(t, report.Signer(proposer).Signatures[0].Err, flow.ErrInvalidSignature)
+		assert.ErrorIs(t, report.Signer(proposer).Err, flow.ErrInsufficientKeyWeight)
+	})
+
+	t.Run("Insufficient weight", func(t *testing.T) {
+		lightKey := *proposerKey
+		lightKey.Weight = flow.AccountKeyWeightThreshold / 2
+
+		tx := newTx()
+		require.NoError(t, tx.SignPayload(proposer, proposerKey.Index, proposerSigner))
+		require.NoError(t, tx.SignEnvelope(payer, payerKey.Index, payerSigner))
+
+		report, err := flow.VerifyTransactionSignatures(tx, map[flow.Address][]*flow.AccountKey{
+			proposer: {&lightKey},
+			payer:    {payerKey},
+		})
+		require.NoError(t, err)
+
+		assert.True(t, report.ProposalKeySigned)
+		assert.ErrorIs(t, report.Err(), flow.ErrInsufficientKeyWeight)
+		assert.NoError(t, report.Signer(payer).Err)
+	})
+
+	t.Run("Revoked key", func(t *testing.T) {
+		revokedKey := *payerKey
+		revokedKey.Revoked = true
+
+		tx := newTx()
+		require.NoError(t, tx.SignPayload(proposer, proposerKey.Index, proposerSigner))
+		require.NoError(t, tx.SignEnvelope(payer, payerKey.Index, payerSigner))
+
+		report, err := flow.VerifyTransactionSignatures(tx, map[flow.Address][]*flow.AccountKey{
+			proposer: {proposerKey},
+			payer:    {&revokedKey},
+		})
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, report.Signer(payer).Signatures[0].Err, flow.ErrRevokedAccountKey)
+		assert.ErrorIs(t, report.Err(), flow.ErrInsufficientKeyWeight)
+	})
+
+	t.Run("Missing envelope signature", func(t *testing.T) {
+		tx := newTx()
+		require.NoError(t, tx.SignPayload(proposer, proposerKey.Index, proposerSigner))
+
+		report, err := flow.VerifyTransactionSignatures(tx, accountKeys)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, report.Signer(payer).Err, flow.ErrInsufficientKeyWeight)
+	})
+
+	t.Run("Missing account key", func(t *testing.T) {
+		tx := newTx()
+		require.NoError(t, tx.SignPayload(proposer, proposerKey.Index, proposerSigner))
+		require.NoError(t, tx.SignEnvelope(payer, payerKey.Index, payerSigner))
+
+		report, err := flow.VerifyTransactionSignatures(tx, map[flow.Address][]*flow.AccountKey{
+			payer: {payerKey},
+		})
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, report.Signer(proposer).Signatures[0].Err, flow.ErrMissingAccountKey)
+	})
+
+	t.Run("Proposer below threshold", func(t *testing.T) {
+		lightKey := *proposerKey
+		lightKey.Weight = 1
+
+		tx := newTx()
+		tx.Authorizers = []flow.Address{payer}
+		require.NoError(t, tx.SignPayload(proposer, proposerKey.Index, proposerSigner))
+		require.NoError(t, tx.SignEnvelope(payer, payerKey.Index, payerSigner))
+
+		report, err := flow.VerifyTransactionSignatures(tx, map[flow.Address][]*flow.AccountKey{
+			proposer: {&lightKey},
+			payer:    {payerKey},
+		})
+		require.NoError(t, err)
+		assert.NoError(t, report.Err())
+	})
+
+	t.Run("Unexpected signer", func(t *testing.T) {
+		other := addresses.New()
+		otherKey, otherSigner := keys.NewWithSigner()
+
+		tx := newTx()
+		require.NoError(t, tx.SignPayload(proposer, proposerKey.Index, proposerSigner))
+		require.NoError(t, tx.SignPayload(other, otherKey.Index, otherSigner))
+		require.NoError(t, tx.SignEnvelope(payer, payerKey.Index, payerSigner))
+
+		report, err := flow.VerifyTransactionSignatures(tx, accountKeys)
+		require.NoError(t, err)
+
+		require.Len(t, report.UnexpectedSignatures, 1)
+		assert.Equal(t, other, report.UnexpectedSignatures[0].Signature.Address)
+		assert.ErrorIs(t, report.UnexpectedSignatures[0].Err, flow.ErrUnexpectedSigner)
+		assert.ErrorIs(t, report.Err(), flow.ErrUnexpectedSigner)
+		assert.NoError(t, report.Signer(proposer).Err)
+		assert.NoError(t, report.Signer(payer).Err)
+	})
+
+	t.Run("Payer only signs envelope", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetScript([]byte(`transaction { prepare(signer: &Account) {} }`)).
+			SetProposalKey(payer, payerKey.Index, payerKey.SequenceNumber).
+			SetPayer(payer).
+			AddAuthorizer(payer)
+		require.NoError(t, tx.SignEnvelope(payer, payerKey.Index, payerSigner))
+
+		report, err := flow.VerifyTransactionSignatures(tx, accountKeys)
+		require.NoError(t, err)
+		assert.NoError(t, report.Err())
+	})
+}
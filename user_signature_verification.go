@@ -0,0 +1,190 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// compositeSignatureType is the FCL type of composite signatures.
+const compositeSignatureType = "CompositeSignature"
+
+// CompositeSignature is a signature of an account key, as returned by FCL for signed user messages
+// and account proofs.
+type CompositeSignature struct {
+	Address   Address
+	KeyIndex  uint32
+	Signature []byte
+}
+
+type compositeSignatureJSON struct {
+	FType     string `json:"f_type,omitempty"`
+	FVsn      string `json:"f_vsn,omitempty"`
+	Addr      string `json:"addr"`
+	KeyID     uint32 `json:"keyId"`
+	Signature string `json:"signature"`
+}
+
+// MarshalJSON encodes the signature in the FCL format, with a hex encoded signature.
+func (s CompositeSignature) MarshalJSON() ([]byte, error) {
+	return json.Marshal(compositeSignatureJSON{
+		FType:     compositeSignatureType,
+		FVsn:      "1.0.0",
+		Addr:      s.Address.HexWithPrefix(),
+		KeyID:     s.KeyIndex,
+		Signature: hex.EncodeToString(s.Signature),
+	})
+}
+
+// UnmarshalJSON decodes a signature in the FCL format.
+func (s *CompositeSignature) UnmarshalJSON(data []byte) error {
+	var v compositeSignatureJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.FType != "" && v.FType != compositeSignatureType {
+		return fmt.Errorf("invalid composite signature type %q", v.FType)
+	}
+
+	signature, err := hex.DecodeString(strings.TrimPrefix(v.Signature, "0x"))
+	if err != nil {
+		return fmt.Errorf("invalid composite signature: %w", err)
+	}
+
+	*s = CompositeSignature{
+		Address:   HexToAddress(v.Addr),
+		KeyIndex:  v.KeyID,
+		Signature: signature,
+	}
+	return nil
+}
+
+// UserSignatureVerificationMode determines how VerifyUserSignatures treats signatures which fail verification.
+type UserSignatureVerificationMode int
+
+const (
+	// VerifyAllSignatures requires every signature of a key which is not revoked to be valid, and their keys
+	// to reach AccountKeyWeightThreshold.
+	VerifyAllSignatures UserSignatureVerificationMode = iota
+	// VerifyAnySignatures ignores signatures which fail verification, and requires the keys of the valid signatures
+	// to reach AccountKeyWeightThreshold.
+	VerifyAnySignatures
+)
+
+// VerifyUserSignatures verifies signatures of a user message offline, see SignUserMessage.
+//
+// The accountKeys must be the keys of the account, typically obtained from GetAccountKeysAtLatestBlock.
+// Every signature is checked against its account key using the key's signature and hashing algorithms
+// and the user domain tag. Each key is counted at most once.
+//
+// Signatures of revoked keys are skipped in both modes, and the weights of revoked keys are not counted.
+//
+// An error wrapping ErrInsufficientKeyWeight is returned if the weights of the keys of the valid signatures
+// do not reach AccountKeyWeightThreshold. In VerifyAllSignatures mode, the error of the first invalid signature
+// is returned instead.
+func VerifyUserSignatures(
+	address Address,
+	accountKeys []*AccountKey,
+	message []byte,
+	signatures []CompositeSignature,
+	mode UserSignatureVerificationMode,
+) error {
+	if len(signatures) == 0 {
+		return errors.New("no signatures")
+	}
+
+	signedData := append(UserDomainTag[:], message...)
+
+	weight := 0
+	seen := make(map[uint32]struct{})
+
+	for _, sig := range signatures {
+		err := verifyUserSignature(address, accountKeys, sig, signedData, seen)
+		if errors.Is(err, ErrRevokedAccountKey) {
+			continue
+		}
+		if err != nil {
+			if mode == VerifyAllSignatures {
+				return err
+			}
+			continue
+		}
+
+		seen[sig.KeyIndex] = struct{}{}
+		weight += findAccountKey(accountKeys, sig.KeyIndex).Weight
+	}
+
+	if weight < AccountKeyWeightThreshold {
+		return fmt.Errorf(
+			"%w: signatures have weight %d, required %d",
+			ErrInsufficientKeyWeight,
+			weight,
+			AccountKeyWeightThreshold,
+		)
+	}
+
+	return nil
+}
+
+func verifyUserSignature(
+	address Address,
+	accountKeys []*AccountKey,
+	sig CompositeSignature,
+	signedData []byte,
+	seen map[uint32]struct{},
+) error {
+	if sig.Address != address {
+		return fmt.Errorf("%w: signature of account %s, expected %s", ErrInvalidSignature, sig.Address, address)
+	}
+	if _, ok := seen[sig.KeyIndex]; ok {
+		return fmt.Errorf("%w: %s key %d", ErrDuplicateSignature, sig.Address, sig.KeyIndex)
+	}
+
+	key := findAccountKey(accountKeys, sig.KeyIndex)
+	if key == nil {
+		return fmt.Errorf("%w: %s key %d", ErrMissingAccountKey, sig.Address, sig.KeyIndex)
+	}
+	if key.Revoked {
+		return fmt.Errorf("%w: %s key %d", ErrRevokedAccountKey, sig.Address, sig.KeyIndex)
+	}
+	if key.PublicKey == nil {
+		return fmt.Errorf("%w: %s key %d has no public key", ErrMissingAccountKey, sig.Address, sig.KeyIndex)
+	}
+
+	hasher, err := crypto.NewHasher(key.HashAlgo)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSignature, err)
+	}
+
+	valid, err := key.PublicKey.Verify(sig.Signature, signedData, hasher)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSignature, err)
+	}
+	if !valid {
+		return fmt.Errorf("%w: %s key %d", ErrInvalidSignature, sig.Address, sig.KeyIndex)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestVerifyUserSignatures(t *testing.T) {
+	address := test.AddressGenerator().New()
+	keys := test.AccountKeyGenerator()
+
+	fullKey, fullSigner := keys.NewWithSigner()
+	halfKey1, halfSigner1 := keys.NewWithSigner()
+	halfKey1.Weight = 500
+	halfKey2, halfSigner2 := keys.NewWithSigner()
+	halfKey2.Weight = 500
+	revokedKey, revokedSigner := keys.NewWithSigner()
+	revokedKey.Revoked = true
+
+	accountKeys := []*flow.AccountKey{fullKey, halfKey1, halfKey2, revokedKey}
+	message := []byte("login")
+
+	sign := func(key *flow.AccountKey, signer crypto.Signer) flow.CompositeSignature {
+		signature, err := flow.SignUserMessage(signer, message)
+		require.NoError(t, err)
+		return flow.CompositeSignature{Address: address, KeyIndex: key.Index, Signature: signature}
+	}
+
+	full := sign(fullKey, fullSigner)
+	half1 := sign(halfKey1, halfSigner1)
+	half2 := sign(halfKey2, halfSigner2)
+	revoked := sign(revokedKey, revokedSigner)
+	invalid := flow.CompositeSignature{Address: address, KeyIndex: halfKey2.Index, Signature: half1.Signature}
+
+	verify := func(mode flow.UserSignatureVerificationMode, signatures ...flow.CompositeSignature) error {
+		return flow.VerifyUserSignatures(address, accountKeys, message, signatures, mode)
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		assert.NoError(t, verify(flow.VerifyAllSignatures, full))
+		assert.NoError(t, verify(flow.VerifyAllSignatures, half1, half2))
+	})
+
+	t.Run("Insufficient weight", func(t *testing.T) {
+		assert.ErrorIs(t, verify(flow.VerifyAllSignatures, half1), flow.ErrInsufficientKeyWeight)
+		assert.ErrorIs(t, verify(flow.VerifyAllSignatures, half1, half1), flow.ErrDuplicateSignature)
+		assert.ErrorIs(t, verify(flow.VerifyAnySignatures, half1, half1), flow.ErrInsufficientKeyWeight)
+	})
+
+	t.Run("Revoked key", func(t *testing.T) {
+		// signatures of revoked keys are skipped in both modes
+		for _, mode := range []flow.UserSignatureVerificationMode{flow.VerifyAllSignatures, flow.VerifyAnySignatures} {
+			assert.ErrorIs(t, verify(mode, revoked), flow.ErrInsufficientKeyWeight)
+			assert.NoError(t, verify(mode, revoked, full))
+		}
+
+		// the weight of a revoked key is not counted
+		heavyRevokedKey := *revokedKey
+		heavyRevokedKey.Weight = 500
+		err := flow.VerifyUserSignatures(
+			address,
+			[]*flow.AccountKey{fullKey, halfKey1, halfKey2, &heavyRevokedKey},
+			message,
+			[]flow.CompositeSignature{half1, revoked},
+			flow.VerifyAllSignatures,
+		)
+		assert.ErrorIs(t, err, flow.ErrInsufficientKeyWeight)
+	})
+
+	t.Run("Invalid signature", func(t *testing.T) {
+		assert.ErrorIs(t, verify(flow.VerifyAllSignatures, full, invalid), flow.ErrInvalidSignature)
+		assert.NoError(t, verify(flow.VerifyAnySignatures, full, invalid))
+
+		other := full
+		other.Address = flow.HexToAddress("01")
+		assert.ErrorIs(t, verify(flow.VerifyAllSignatures, other), flow.ErrInvalidSignature)
+
+		err := flow.VerifyUserSignatures(address, accountKeys, []byte("other"), []flow.CompositeSignature{full}, flow.VerifyAllSignatures)
+		assert.ErrorIs(t, err, flow.ErrInvalidSignature)
+	})
+
+	t.Run("Missing key", func(t *testing.T) {
+		missing := full
+		missing.KeyIndex = 42
+		assert.ErrorIs(t, verify(flow.VerifyAllSignatures, missing), flow.ErrMissingAccountKey)
+	})
+
+	t.Run("No signatures", func(t *testing.T) {
+		assert.Error(t, verify(flow.VerifyAnySignatures))
+	})
+}
+
+func TestCompositeSignature_JSON(t *testing.T) {
+	data := []byte(`{"f_type":"CompositeSignature","f_vsn":"1.0.0","addr":"0xf8d6e0586b0a20c7","keyId":1,"signature":"0a0b"}`)
+
+	var sig flow.CompositeSignature
+	require.NoError(t, json.Unmarshal(data, &sig))
+	assert.Equal(t, flow.HexToAddress("f8d6e0586b0a20c7"), sig.Address)
+	assert.Equal(t, uint32(1), sig.KeyIndex)
+	assert.Equal(t, []byte{0x0a, 0x0b}, sig.Signature)
+
+	encoded, err := json.Marshal(sig)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(data), string(encoded))
+
+	err = json.Unmarshal([]byte(`{"f_type":"Service","addr":"0x01","keyId":0,"signature":""}`), &sig)
+	assert.Error(t, err)
+}